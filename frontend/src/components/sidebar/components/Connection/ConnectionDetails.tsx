@@ -8,6 +8,7 @@ import {
   DialogTitle,
 } from "@/components/ui/dialog";
 import { Input } from "@/components/ui/input";
+import { Checkbox } from "@/components/ui/checkbox";
 import { Label } from "../../../ui/label";
 import {
   Select,
@@ -16,11 +17,25 @@ import {
   SelectTrigger,
   SelectValue,
 } from "@/components/ui/select";
-import { useForm, SubmitHandler, Controller } from "react-hook-form";
+import {
+  useForm,
+  useFieldArray,
+  SubmitHandler,
+  Controller,
+} from "react-hook-form";
 import { TestConnection } from "wailsjs/go/weaviate/Weaviate";
 import { useEffect, useState } from "react";
 import { Separator } from "@/components/ui/separator";
-import { Check, Eye, EyeOff, LoaderCircle, Star, X } from "lucide-react";
+import {
+  Check,
+  Eye,
+  EyeOff,
+  LoaderCircle,
+  Plus,
+  Star,
+  Trash2,
+  X,
+} from "lucide-react";
 import { useConnectionStore } from "@/store/connection-store";
 import { ConnectionStatus } from "@/types/enums";
 import { errorReporting } from "@/lib/utils";
@@ -40,12 +55,47 @@ interface Props {
 
 const RestTestAfterMS = 3000;
 
-interface NewConnectionForm {
+const NoAuth = "none";
+
+const authTypes = [
+  { value: NoAuth, label: "None" },
+  { value: "api_key", label: "API key" },
+  { value: "password", label: "OIDC username and password" },
+  { value: "client_credentials", label: "OIDC client credentials" },
+  { value: "bearer_token", label: "OIDC bearer token" },
+];
+
+interface AuthForm {
+  authType: string;
+  apiKey?: string;
+  username?: string;
+  password?: string;
+  clientSecret?: string;
+  scopes?: string;
+  accessToken?: string;
+  refreshToken?: string;
+}
+
+interface NewConnectionForm extends AuthForm {
   uri: string;
   name: string;
-  apiKey?: string;
   color: string;
   favorite: boolean;
+  // headers are sent with every request, e.g. X-OpenAI-Api-Key for the modules
+  headers: { name: string; value: string }[];
+  // paths to PEM encoded files
+  caCertFile?: string;
+  clientCertFile?: string;
+  clientKeyFile?: string;
+  insecureSkipVerify: boolean;
+  // a connection either goes through a proxy or through an SSH tunnel
+  proxyUrl?: string;
+  sshHost?: string;
+  sshUser?: string;
+  sshKeyFile?: string;
+  // gRPC is used for search, objects and batching when the host or port is set
+  grpcHost?: string;
+  grpcPort?: string;
 }
 
 export const ConnectionDetails: React.FC<Props> = ({
@@ -66,10 +116,29 @@ export const ConnectionDetails: React.FC<Props> = ({
         favorite: connection.favorite,
         name: connection.name,
         uri: connection.uri,
+        // connections created before OIDC was supported have no auth type
+        authType:
+          connection.auth_type || (connection.api_key ? "api_key" : NoAuth),
+        username: connection.username,
+        scopes: connection.scopes,
+        headers: connection.headers ?? [],
+        caCertFile: connection.ca_cert_file,
+        clientCertFile: connection.client_cert_file,
+        clientKeyFile: connection.client_key_file,
+        insecureSkipVerify: connection.insecure_skip_verify,
+        proxyUrl: connection.proxy_url,
+        sshHost: connection.ssh_host,
+        sshUser: connection.ssh_user,
+        sshKeyFile: connection.ssh_key_file,
+        grpcHost: connection.grpc_host,
+        grpcPort: connection.grpc_port?.toString(),
       }
     : {
         color: connectionColors[0].value, // Default to "No color"
         favorite: false, // Default favorite to false
+        authType: NoAuth,
+        headers: [],
+        insecureSkipVerify: false,
       };
 
   useEffect(() => {
@@ -84,6 +153,7 @@ export const ConnectionDetails: React.FC<Props> = ({
     formState: { errors, isValid, isDirty },
     getValues,
     reset,
+    watch,
   } = useForm<NewConnectionForm>({
     mode: "onChange",
     defaultValues,
@@ -100,20 +170,106 @@ export const ConnectionDetails: React.FC<Props> = ({
     (state) => state.updateByConnection
   );
 
-  const save: SubmitHandler<NewConnectionForm> = async ({
-    name,
-    uri,
+  const selectedAuthType = watch("authType");
+  const {
+    fields: headerFields,
+    append: appendHeader,
+    remove: removeHeader,
+  } = useFieldArray({ control, name: "headers" });
+
+  const tlsFields = ({
+    caCertFile,
+    clientCertFile,
+    clientKeyFile,
+    insecureSkipVerify,
+  }: NewConnectionForm) => ({
+    ca_cert_file: caCertFile || undefined,
+    client_cert_file: clientCertFile || undefined,
+    client_key_file: clientKeyFile || undefined,
+    insecure_skip_verify: insecureSkipVerify,
+  });
+
+  const networkFields = ({
+    proxyUrl,
+    sshHost,
+    sshUser,
+    sshKeyFile,
+  }: NewConnectionForm) => ({
+    proxy_url: proxyUrl || undefined,
+    ssh_host: sshHost || undefined,
+    ssh_user: sshHost ? sshUser : undefined,
+    ssh_key_file: sshHost ? sshKeyFile || undefined : undefined,
+  });
+
+  const grpcFields = ({ grpcHost, grpcPort }: NewConnectionForm) => ({
+    grpc_host: grpcHost || undefined,
+    grpc_port: grpcPort ? Number(grpcPort) : undefined,
+  });
+
+  // rows without a name are dropped
+  const connectionHeaders = (headers: NewConnectionForm["headers"]) =>
+    headers
+      .map((h) => ({ name: h.name.trim(), value: h.value }))
+      .filter((h) => h.name);
+
+  // authFields returns the auth fields of the connection, secrets left empty
+  // while editing keep their stored value, other auth types are cleared
+  const authFields = ({
+    authType,
     apiKey,
-    color,
-    favorite,
-  }) => {
+    username,
+    password,
+    clientSecret,
+    scopes,
+    accessToken,
+    refreshToken,
+  }: AuthForm) => {
+    const stored = (value?: string, current?: string) =>
+      value ? value : current;
+
+    return {
+      auth_type: authType === NoAuth ? "" : authType,
+      api_key:
+        authType === "api_key"
+          ? stored(apiKey, connection?.api_key)
+          : undefined,
+      username: authType === "password" ? username : undefined,
+      password:
+        authType === "password"
+          ? stored(password, connection?.password)
+          : undefined,
+      client_secret:
+        authType === "client_credentials"
+          ? stored(clientSecret, connection?.client_secret)
+          : undefined,
+      scopes:
+        authType === "password" || authType === "client_credentials"
+          ? scopes
+          : undefined,
+      access_token:
+        authType === "bearer_token"
+          ? stored(accessToken, connection?.access_token)
+          : undefined,
+      refresh_token:
+        authType === "bearer_token"
+          ? stored(refreshToken, connection?.refresh_token)
+          : undefined,
+    };
+  };
+
+  const save: SubmitHandler<NewConnectionForm> = async (form) => {
+    const { name, uri, color, favorite, headers } = form;
     try {
       if (isEditing) {
         await updateConnection({
           id: connection.id,
           name,
           uri: uri ? uri : connection.uri,
-          api_key: apiKey ? apiKey : connection.api_key,
+          ...authFields(form),
+          ...tlsFields(form),
+          ...networkFields(form),
+          ...grpcFields(form),
+          headers: connectionHeaders(headers),
           color,
           favorite,
         });
@@ -126,7 +282,11 @@ export const ConnectionDetails: React.FC<Props> = ({
           uri,
           status: ConnectionStatus.Disconnected,
           favorite,
-          api_key: apiKey,
+          ...authFields(form),
+          ...tlsFields(form),
+          ...networkFields(form),
+          ...grpcFields(form),
+          headers: connectionHeaders(headers),
           color: color,
         });
       }
@@ -140,13 +300,8 @@ export const ConnectionDetails: React.FC<Props> = ({
 
   const [isSavingAndConnecting, setIsSavingAndConnecting] = useState(false);
 
-  const saveAndConnect: SubmitHandler<NewConnectionForm> = async ({
-    name,
-    uri,
-    apiKey,
-    color,
-    favorite,
-  }) => {
+  const saveAndConnect: SubmitHandler<NewConnectionForm> = async (form) => {
+    const { name, uri, color, favorite, headers } = form;
     try {
       setIsSavingAndConnecting(true);
       if (isEditing) {
@@ -154,7 +309,11 @@ export const ConnectionDetails: React.FC<Props> = ({
           id: connection.id,
           name,
           uri: uri ? uri : connection.uri,
-          api_key: apiKey ? apiKey : connection.api_key,
+          ...authFields(form),
+          ...tlsFields(form),
+          ...networkFields(form),
+          ...grpcFields(form),
+          headers: connectionHeaders(headers),
           color,
           favorite,
         });
@@ -168,7 +327,11 @@ export const ConnectionDetails: React.FC<Props> = ({
           uri,
           status: ConnectionStatus.Disconnected,
           favorite,
-          api_key: apiKey,
+          ...authFields(form),
+          ...tlsFields(form),
+          ...networkFields(form),
+          ...grpcFields(form),
+          headers: connectionHeaders(headers),
           color: color,
         });
         await connectToConnection(id);
@@ -190,9 +353,31 @@ export const ConnectionDetails: React.FC<Props> = ({
     setTestStatus("loading");
 
     try {
+      const auth = authFields(getValues());
+      const tls = tlsFields(getValues());
+      const network = networkFields(getValues());
+      const grpc = grpcFields(getValues());
       await TestConnection({
         URI: getValues("uri"),
-        ApiKey: getValues("apiKey") ? getValues("apiKey") : connection?.api_key,
+        AuthType: auth.auth_type,
+        ApiKey: auth.api_key,
+        Username: auth.username,
+        Password: auth.password,
+        ClientSecret: auth.client_secret,
+        Scopes: auth.scopes,
+        AccessToken: auth.access_token,
+        RefreshToken: auth.refresh_token,
+        Headers: connectionHeaders(getValues("headers")),
+        CACertFile: tls.ca_cert_file,
+        ClientCertFile: tls.client_cert_file,
+        ClientKeyFile: tls.client_key_file,
+        InsecureSkipVerify: tls.insecure_skip_verify,
+        ProxyURL: network.proxy_url,
+        SSHHost: network.ssh_host,
+        SSHUser: network.ssh_user,
+        SSHKeyFile: network.ssh_key_file,
+        GRPCHost: grpc.grpc_host,
+        GRPCPort: grpc.grpc_port,
       });
 
       setTestStatus("success");
@@ -239,7 +424,7 @@ export const ConnectionDetails: React.FC<Props> = ({
         onInteractOutside={(e) => {
           e.preventDefault();
         }}
-        className="sm:max-w-[50vw]"
+        className="max-h-[90vh] overflow-y-auto sm:max-w-[50vw]"
       >
         <DialogHeader>
           <DialogTitle>{isEditing ? "Edit" : "New"} Connection</DialogTitle>
@@ -356,6 +541,116 @@ export const ConnectionDetails: React.FC<Props> = ({
             Authentication
           </h1>
           <div className="flex flex-col gap-1">
+            <Label>Method</Label>
+            <Controller
+              control={control}
+              name="authType"
+              disabled={disableWhenConnected}
+              render={({ field }) => (
+                <Select
+                  onValueChange={field.onChange}
+                  value={field.value}
+                  disabled={field.disabled}
+                >
+                  <SelectTrigger className="w-full">
+                    <SelectValue placeholder="Select an authentication method" />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {authTypes.map((t) => (
+                      <SelectItem key={t.value} value={t.value}>
+                        {t.label}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              )}
+            />
+          </div>
+          {selectedAuthType === "password" && (
+            <div className="flex flex-row gap-4">
+              <div className="flex flex-1 flex-col gap-1">
+                <Label>Username</Label>
+                <Input
+                  id="username"
+                  {...register("username", {
+                    disabled: disableWhenConnected,
+                  })}
+                />
+              </div>
+              <div className="flex flex-1 flex-col gap-1">
+                <Label>Password</Label>
+                <Input
+                  id="password"
+                  type="password"
+                  placeholder={storedSecretPlaceholder(connection?.password)}
+                  {...register("password", {
+                    disabled: disableWhenConnected,
+                  })}
+                />
+              </div>
+            </div>
+          )}
+          {selectedAuthType === "client_credentials" && (
+            <div className="flex flex-col gap-1">
+              <Label>Client Secret</Label>
+              <Input
+                id="clientSecret"
+                type="password"
+                placeholder={storedSecretPlaceholder(connection?.client_secret)}
+                {...register("clientSecret", {
+                  disabled: disableWhenConnected,
+                })}
+              />
+            </div>
+          )}
+          {(selectedAuthType === "password" ||
+            selectedAuthType === "client_credentials") && (
+            <div className="flex flex-col gap-1">
+              <Label>Scopes</Label>
+              <Input
+                id="scopes"
+                placeholder="e.g. openid offline_access"
+                {...register("scopes", {
+                  disabled: disableWhenConnected,
+                })}
+              />
+            </div>
+          )}
+          {selectedAuthType === "bearer_token" && (
+            <>
+              <div className="flex flex-col gap-1">
+                <Label>Access Token</Label>
+                <Input
+                  id="accessToken"
+                  type="password"
+                  placeholder={storedSecretPlaceholder(
+                    connection?.access_token
+                  )}
+                  {...register("accessToken", {
+                    disabled: disableWhenConnected,
+                  })}
+                />
+              </div>
+              <div className="flex flex-col gap-1">
+                <Label>Refresh Token</Label>
+                <Input
+                  id="refreshToken"
+                  type="password"
+                  placeholder={storedSecretPlaceholder(
+                    connection?.refresh_token
+                  )}
+                  {...register("refreshToken", {
+                    disabled: disableWhenConnected,
+                  })}
+                />
+              </div>
+            </>
+          )}
+          <div
+            className={
+              selectedAuthType === "api_key" ? "flex flex-col gap-1" : "hidden"
+            }
+          >
             <Label>Api Key</Label>
             <div className="relative">
               <Input
@@ -385,6 +680,214 @@ export const ConnectionDetails: React.FC<Props> = ({
             </div>
             <FieldError message={errors.apiKey?.message} />
           </div>
+          <Separator />
+          <h1 className="text-sm font-semibold text-gray-500">TLS</h1>
+          <div className="flex flex-col gap-1">
+            <Label>CA Bundle</Label>
+            <Input
+              id="caCertFile"
+              placeholder="e.g. /etc/ssl/private-ca.pem"
+              {...register("caCertFile", { disabled: disableWhenConnected })}
+            />
+          </div>
+          <div className="flex flex-row gap-4">
+            <div className="flex flex-1 flex-col gap-1">
+              <Label>Client Certificate</Label>
+              <Input
+                id="clientCertFile"
+                placeholder="e.g. /etc/ssl/client.pem"
+                {...register("clientCertFile", {
+                  disabled: disableWhenConnected,
+                })}
+              />
+            </div>
+            <div className="flex flex-1 flex-col gap-1">
+              <Label>Client Key</Label>
+              <Input
+                id="clientKeyFile"
+                placeholder="e.g. /etc/ssl/client-key.pem"
+                {...register("clientKeyFile", {
+                  disabled: disableWhenConnected,
+                })}
+              />
+            </div>
+          </div>
+          <Controller
+            control={control}
+            name="insecureSkipVerify"
+            disabled={disableWhenConnected}
+            render={({ field }) => (
+              <div className="flex flex-col gap-1">
+                <div className="flex items-center space-x-2">
+                  <Checkbox
+                    id="insecureSkipVerify"
+                    checked={field.value}
+                    disabled={field.disabled}
+                    onCheckedChange={(checked: boolean | "indeterminate") =>
+                      field.onChange(checked === true)
+                    }
+                  />
+                  <Label
+                    htmlFor="insecureSkipVerify"
+                    className="text-sm leading-none font-normal text-red-600"
+                  >
+                    Skip certificate verification (insecure)
+                  </Label>
+                </div>
+                {field.value && (
+                  <p className="pl-6 text-xs text-red-500">
+                    The server certificate isn&apos;t verified, anyone on the
+                    network can intercept the connection. Only use it for
+                    testing.
+                  </p>
+                )}
+              </div>
+            )}
+          />
+          <Separator />
+          <h1 className="text-sm font-semibold text-gray-500">
+            Proxy / SSH Tunnel
+          </h1>
+          <div className="flex flex-col gap-1">
+            <Label>Proxy URL</Label>
+            <Input
+              id="proxyUrl"
+              placeholder="e.g. http://proxy:3128 or socks5://proxy:1080"
+              {...register("proxyUrl", {
+                disabled: disableWhenConnected,
+                pattern: {
+                  value: /^(https?|socks5h?):\/\/.+/,
+                  message: "Expected an http, https, socks5 or socks5h URL",
+                },
+                validate: (value, form) =>
+                  !value ||
+                  !form.sshHost ||
+                  "Use either a proxy or an SSH tunnel",
+              })}
+            />
+            <FieldError message={errors.proxyUrl?.message} />
+          </div>
+          <div className="flex flex-row gap-4">
+            <div className="flex flex-1 flex-col gap-1">
+              <Label>SSH Host</Label>
+              <Input
+                id="sshHost"
+                placeholder="e.g. bastion.example.com:22"
+                {...register("sshHost", {
+                  disabled: disableWhenConnected,
+                  deps: ["proxyUrl", "sshUser"],
+                })}
+              />
+            </div>
+            <div className="flex flex-1 flex-col gap-1">
+              <Label>SSH User</Label>
+              <Input
+                id="sshUser"
+                placeholder="e.g. ubuntu"
+                {...register("sshUser", {
+                  disabled: disableWhenConnected,
+                  validate: (value, form) =>
+                    !!value ||
+                    !form.sshHost ||
+                    "The SSH user is required for the tunnel",
+                })}
+              />
+              <FieldError message={errors.sshUser?.message} />
+            </div>
+          </div>
+          <div className="flex flex-col gap-1">
+            <Label>SSH Private Key</Label>
+            <Input
+              id="sshKeyFile"
+              placeholder="e.g. ~/.ssh/id_ed25519, the SSH agent when empty"
+              {...register("sshKeyFile", { disabled: disableWhenConnected })}
+            />
+            <p className="text-xs text-gray-500">
+              The host key of the SSH server is verified with
+              ~/.ssh/known_hosts, connect with ssh once to trust it.
+            </p>
+          </div>
+          <Separator />
+          <h1 className="text-sm font-semibold text-gray-500">gRPC</h1>
+          <div className="flex flex-row gap-4">
+            <div className="flex flex-1 flex-col gap-1">
+              <Label>gRPC Host</Label>
+              <Input
+                id="grpcHost"
+                placeholder="the host of the URI when empty"
+                {...register("grpcHost", { disabled: disableWhenConnected })}
+              />
+            </div>
+            <div className="flex w-32 flex-col gap-1">
+              <Label>gRPC Port</Label>
+              <Input
+                id="grpcPort"
+                placeholder="e.g. 50051"
+                {...register("grpcPort", {
+                  disabled: disableWhenConnected,
+                  validate: (value) =>
+                    !value ||
+                    (/^\d+$/.test(value) &&
+                      Number(value) > 0 &&
+                      Number(value) <= 65535) ||
+                    "Expected a port between 1 and 65535",
+                })}
+              />
+            </div>
+          </div>
+          <FieldError message={errors.grpcPort?.message} />
+          <p className="text-xs text-gray-500">
+            Search, object browsing and batching use gRPC when the host or port
+            is set, the REST API is used when gRPC isn&apos;t available.
+          </p>
+          <Separator />
+          <div className="flex items-center justify-between">
+            <h1 className="text-sm font-semibold text-gray-500">Headers</h1>
+            <Button
+              type="button"
+              variant="ghost"
+              size="sm"
+              className="h-6 w-6 p-0"
+              disabled={disableWhenConnected}
+              onClick={() => appendHeader({ name: "", value: "" })}
+            >
+              <Plus className="h-4 w-4" />
+            </Button>
+          </div>
+          {headerFields.length === 0 && (
+            <p className="text-muted-foreground text-xs">
+              Headers sent with every request, e.g. X-OpenAI-Api-Key for the
+              vectorizer and generative modules.
+            </p>
+          )}
+          {headerFields.map((field, index) => (
+            <div key={field.id} className="flex flex-row gap-2">
+              <Input
+                placeholder="e.g. X-OpenAI-Api-Key"
+                className="flex-1"
+                {...register(`headers.${index}.name`, {
+                  disabled: disableWhenConnected,
+                })}
+              />
+              <Input
+                placeholder="Value"
+                type="password"
+                className="flex-1"
+                {...register(`headers.${index}.value`, {
+                  disabled: disableWhenConnected,
+                })}
+              />
+              <Button
+                type="button"
+                variant="ghost"
+                size="sm"
+                disabled={disableWhenConnected}
+                onClick={() => removeHeader(index)}
+              >
+                <Trash2 className="h-3.5 w-3.5 text-red-500" />
+              </Button>
+            </div>
+          ))}
           <DialogFooter>
             <div className="flex min-w-full justify-between">
               <div>
@@ -458,6 +961,9 @@ const TestConnectionButton = ({
   );
 };
 
+const storedSecretPlaceholder = (secret?: string) =>
+  secret ? "unchanged" : "";
+
 const obscureApiKey = (apiKey?: string) => {
   if (!apiKey) return "";
   const length = apiKey.length;
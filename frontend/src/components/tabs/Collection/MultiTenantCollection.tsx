@@ -94,7 +94,8 @@ const MultiTenantCollection: React.FC<Props> = ({
         const total = await GetTotalObjects(
           connection.id,
           name,
-          selectedTenant
+          selectedTenant,
+          null!
         );
 
         return total;
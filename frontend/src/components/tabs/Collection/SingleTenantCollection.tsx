@@ -54,7 +54,7 @@ const SingleTenantCollection: React.FC<Props> = ({
     initialData: 0,
     queryFn: async () => {
       try {
-        const total = await GetTotalObjects(connection.id, name, "");
+        const total = await GetTotalObjects(connection.id, name, "", null!);
 
         // reset cursor history and page size
         setCursorHistory([]);
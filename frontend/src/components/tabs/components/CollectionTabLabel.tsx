@@ -5,12 +5,7 @@ import {
   TooltipTrigger,
 } from "@/components/ui/tooltip";
 import { borderColor } from "@/lib/dynamic-colors";
-import { useQuery } from "@tanstack/react-query";
-import { ClusterStatus } from "wailsjs/go/weaviate/Weaviate";
-import {
-  clusterStatusQueryKey,
-  refetchClusterStatusInterval,
-} from "../constants";
+import { useClusterHealthStore } from "@/store/cluster-health-store";
 
 type Props =
   | {
@@ -54,15 +49,9 @@ const CollectionTabLabel: React.FC<Props> = ({
     </div>
   );
 
-  const { data: status } = useQuery({
-    queryFn: async () => {
-      const data = await ClusterStatus(connectionID!);
-      return data;
-    },
-    queryKey: clusterStatusQueryKey(connectionID!),
-    enabled: tooltip,
-    refetchInterval: refetchClusterStatusInterval,
-  });
+  const health = useClusterHealthStore((state) =>
+    connectionID === undefined ? undefined : state.health[connectionID]
+  );
 
   if (!tooltip) {
     return content;
@@ -74,7 +63,21 @@ const CollectionTabLabel: React.FC<Props> = ({
       <TooltipContent align="start">
         <p className="text-xs">Connection: {connectionName}</p>
         <p className="text-xs">Collection: {name}</p>
-        <p className="text-xs">Status: {status ? "Healthy" : "Unhealthy"}</p>
+        <p className="text-xs">
+          Status: {health?.healthy ? "Healthy" : "Unhealthy"}
+        </p>
+        {health?.version && (
+          <p className="text-xs">Version: {health.version}</p>
+        )}
+        {health && (
+          <p className="text-xs">
+            Checked: {new Date(health.checkedAt).toLocaleTimeString()} (
+            {health.latencyMs} ms)
+          </p>
+        )}
+        {health?.error && (
+          <p className="max-w-80 text-xs text-red-500">{health.error}</p>
+        )}
       </TooltipContent>
     </Tooltip>
   );
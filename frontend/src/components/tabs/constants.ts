@@ -1,12 +1,5 @@
 export const refetchNodeStatusInterval = 5 * 60000;
 
-export const refetchClusterStatusInterval = 10000;
-
-export const clusterStatusQueryKey = (connectionID: number) => [
-  "cluster-status",
-  connectionID,
-];
-
 export const tenantsQueryKey = (
   connectionID: number,
   collectionName: string
@@ -0,0 +1,29 @@
+import { create } from "zustand";
+import { weaviate } from "wailsjs/go/models";
+import { EventsOn } from "wailsjs/runtime/runtime";
+
+// the health of the connected connections, pushed by the backend after every
+// check instead of being polled
+interface ClusterHealthStore {
+  health: Record<number, weaviate.w_ClusterHealth>;
+  remove: (connectionID: number) => void;
+}
+
+export const useClusterHealthStore = create<ClusterHealthStore>((set) => ({
+  health: {},
+  remove: (connectionID: number) => {
+    set((state) => ({
+      health: Object.fromEntries(
+        Object.entries(state.health).filter(
+          ([id]) => Number(id) !== connectionID
+        )
+      ),
+    }));
+  },
+}));
+
+EventsOn("cluster-health", (health: weaviate.w_ClusterHealth) => {
+  useClusterHealthStore.setState((state) => ({
+    health: { ...state.health, [health.connectionID]: health },
+  }));
+});
@@ -17,6 +17,7 @@ import {
   UsersEnabled,
 } from "wailsjs/go/weaviate/Weaviate";
 import { ConnectionStatus } from "@/types/enums";
+import { useClusterHealthStore } from "./cluster-health-store";
 
 interface ConnectionStore {
   connections: Connection[];
@@ -45,6 +46,24 @@ export const useConnectionStore = create<ConnectionStore>((set) => ({
         favorite: c.favorite,
         api_key: c.api_key,
         color: c.color,
+        auth_type: c.auth_type,
+        username: c.username,
+        password: c.password,
+        client_secret: c.client_secret,
+        scopes: c.scopes,
+        access_token: c.access_token,
+        refresh_token: c.refresh_token,
+        headers: c.headers,
+        ca_cert_file: c.ca_cert_file,
+        client_cert_file: c.client_cert_file,
+        client_key_file: c.client_key_file,
+        insecure_skip_verify: c.insecure_skip_verify,
+        proxy_url: c.proxy_url,
+        ssh_host: c.ssh_host,
+        ssh_user: c.ssh_user,
+        ssh_key_file: c.ssh_key_file,
+        grpc_host: c.grpc_host,
+        grpc_port: c.grpc_port,
       })
     );
     set((state) => ({
@@ -157,6 +176,7 @@ export const useConnectionStore = create<ConnectionStore>((set) => ({
   },
   disconnect: async (id: number) => {
     await Disconnect(id);
+    useClusterHealthStore.getState().remove(id);
 
     set((state) => ({
       connections: state.connections.map((c) =>
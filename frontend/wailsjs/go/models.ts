@@ -1,3 +1,165 @@
+export namespace exporter {
+	
+	export class w_ExportInput {
+	    id: string;
+	    connectionID: number;
+	    collection: string;
+	    tenant?: string;
+	    filter?: weaviate.w_Filter;
+	    path: string;
+	    format?: string;
+	    includeVectors?: boolean;
+	    pageSize?: number;
+	    resume?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ExportInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.connectionID = source["connectionID"];
+	        this.collection = source["collection"];
+	        this.tenant = source["tenant"];
+	        this.filter = this.convertValues(source["filter"], weaviate.w_Filter);
+	        this.path = source["path"];
+	        this.format = source["format"];
+	        this.includeVectors = source["includeVectors"];
+	        this.pageSize = source["pageSize"];
+	        this.resume = source["resume"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_ExportResult {
+	    exported: number;
+	    path: string;
+	    resumed: boolean;
+	    canceled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ExportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.exported = source["exported"];
+	        this.path = source["path"];
+	        this.resumed = source["resumed"];
+	        this.canceled = source["canceled"];
+	    }
+	}
+
+}
+
+export namespace importer {
+	
+	export class w_ImportInput {
+	    id: string;
+	    connectionID: number;
+	    collection: string;
+	    tenant?: string;
+	    path: string;
+	    format?: string;
+	    mapping?: Record<string, string>;
+	    idColumn?: string;
+	    vectorColumn?: string;
+	    batchSize?: number;
+	    concurrency?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ImportInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.connectionID = source["connectionID"];
+	        this.collection = source["collection"];
+	        this.tenant = source["tenant"];
+	        this.path = source["path"];
+	        this.format = source["format"];
+	        this.mapping = source["mapping"];
+	        this.idColumn = source["idColumn"];
+	        this.vectorColumn = source["vectorColumn"];
+	        this.batchSize = source["batchSize"];
+	        this.concurrency = source["concurrency"];
+	    }
+	}
+	export class w_ObjectError {
+	    row: number;
+	    id?: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ObjectError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.id = source["id"];
+	        this.message = source["message"];
+	    }
+	}
+	export class w_ImportResult {
+	    imported: number;
+	    failed: number;
+	    errors: w_ObjectError[];
+	    ignoredColumns: string[];
+	    canceled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.imported = source["imported"];
+	        this.failed = source["failed"];
+	        this.errors = this.convertValues(source["errors"], w_ObjectError);
+	        this.ignoredColumns = source["ignoredColumns"];
+	        this.canceled = source["canceled"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace models {
 	
 	export class w_AsyncReplicationStatus {
@@ -44,6 +206,34 @@ export namespace models {
 	        this.ratePerSecond = source["ratePerSecond"];
 	    }
 	}
+	export class w_RequestHistory {
+	    id: number;
+	    connection_id: number;
+	    method: string;
+	    path: string;
+	    body: string;
+	    status: number;
+	    error: string;
+	    duration_ms: number;
+	    created_at: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_RequestHistory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.connection_id = source["connection_id"];
+	        this.method = source["method"];
+	        this.path = source["path"];
+	        this.body = source["body"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	        this.duration_ms = source["duration_ms"];
+	        this.created_at = source["created_at"];
+	    }
+	}
 	export class w_VectorConfig {
 	    vectorIndexConfig?: any;
 	    vectorIndexType?: string;
@@ -422,6 +612,20 @@ export namespace models {
 		    return a;
 		}
 	}
+	export class w_ConnectionHeader {
+	    name: string;
+	    value: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ConnectionHeader(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.value = source["value"];
+	    }
+	}
 	export class w_Connection {
 	    id: number;
 	    uri: string;
@@ -429,6 +633,24 @@ export namespace models {
 	    favorite: boolean;
 	    api_key?: string;
 	    color: string;
+	    auth_type?: string;
+	    username?: string;
+	    password?: string;
+	    client_secret?: string;
+	    scopes?: string;
+	    access_token?: string;
+	    refresh_token?: string;
+	    ca_cert_file?: string;
+	    client_cert_file?: string;
+	    client_key_file?: string;
+	    insecure_skip_verify: boolean;
+	    proxy_url?: string;
+	    ssh_host?: string;
+	    ssh_user?: string;
+	    ssh_key_file?: string;
+	    grpc_host?: string;
+	    grpc_port?: number;
+	    headers?: w_ConnectionHeader[];
 	
 	    static createFrom(source: any = {}) {
 	        return new w_Connection(source);
@@ -442,11 +664,48 @@ export namespace models {
 	        this.favorite = source["favorite"];
 	        this.api_key = source["api_key"];
 	        this.color = source["color"];
+	        this.auth_type = source["auth_type"];
+	        this.username = source["username"];
+	        this.password = source["password"];
+	        this.client_secret = source["client_secret"];
+	        this.scopes = source["scopes"];
+	        this.access_token = source["access_token"];
+	        this.refresh_token = source["refresh_token"];
+	        this.ca_cert_file = source["ca_cert_file"];
+	        this.client_cert_file = source["client_cert_file"];
+	        this.client_key_file = source["client_key_file"];
+	        this.insecure_skip_verify = source["insecure_skip_verify"];
+	        this.proxy_url = source["proxy_url"];
+	        this.ssh_host = source["ssh_host"];
+	        this.ssh_user = source["ssh_user"];
+	        this.ssh_key_file = source["ssh_key_file"];
+	        this.grpc_host = source["grpc_host"];
+	        this.grpc_port = source["grpc_port"];
+	        this.headers = this.convertValues(source["headers"], w_ConnectionHeader);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	
 	
 	
+	
 	export class w_NodeShardStatus {
 	    asyncReplicationStatus: w_AsyncReplicationStatus[];
 	    class: string;
@@ -632,204 +891,1801 @@ export namespace updater {
 
 export namespace weaviate {
 	
-	export class w_AliasPermission {
-	    actions: string[];
-	    alias: string;
-	    collection: string;
+	export class w_AggregationGroup {
+	    groupedBy?: w_GroupedBy;
+	    count: number;
+	    properties: w_PropertyAggregation[];
 	
 	    static createFrom(source: any = {}) {
-	        return new w_AliasPermission(source);
+	        return new w_AggregationGroup(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.actions = source["actions"];
-	        this.alias = source["alias"];
-	        this.collection = source["collection"];
+	        this.groupedBy = this.convertValues(source["groupedBy"], w_GroupedBy);
+	        this.count = source["count"];
+	        this.properties = this.convertValues(source["properties"], w_PropertyAggregation);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class w_Backup {
-	    classes: string[];
-	    completedAt?: string;
-	    id: string;
-	    size?: number;
-	    startedAt: string;
-	    status: string;
-	    backend: string;
+	export class w_AggregationInput {
+	    collection: string;
+	    tenant?: string;
+	    filter?: w_Filter;
+	    properties?: string[];
+	    topOccurrences?: number;
+	    groupBy?: string;
+	    limit?: number;
+	    searchType?: string;
+	    query?: string;
+	    objectLimit?: number;
+	    distance?: number;
+	    certainty?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_Backup(source);
+	        return new w_AggregationInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.classes = source["classes"];
-	        this.completedAt = source["completedAt"];
-	        this.id = source["id"];
-	        this.size = source["size"];
-	        this.startedAt = source["startedAt"];
-	        this.status = source["status"];
-	        this.backend = source["backend"];
+	        this.collection = source["collection"];
+	        this.tenant = source["tenant"];
+	        this.filter = this.convertValues(source["filter"], w_Filter);
+	        this.properties = source["properties"];
+	        this.topOccurrences = source["topOccurrences"];
+	        this.groupBy = source["groupBy"];
+	        this.limit = source["limit"];
+	        this.searchType = source["searchType"];
+	        this.query = source["query"];
+	        this.objectLimit = source["objectLimit"];
+	        this.distance = source["distance"];
+	        this.certainty = source["certainty"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class w_BackupsPermission {
-	    actions: string[];
-	    collection: string;
+	export class w_AggregationResult {
+	    groups: w_AggregationGroup[];
+	    executionTime: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_BackupsPermission(source);
+	        return new w_AggregationResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.actions = source["actions"];
-	        this.collection = source["collection"];
+	        this.groups = this.convertValues(source["groups"], w_AggregationGroup);
+	        this.executionTime = source["executionTime"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class w_ClusterPermission {
-	    actions: string[];
+	export class w_Alias {
+	    alias: string;
+	    collection: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_ClusterPermission(source);
+	        return new w_Alias(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.actions = source["actions"];
+	        this.alias = source["alias"];
+	        this.collection = source["collection"];
 	    }
 	}
-	export class w_CollectionsPermission {
+	export class w_AliasPermission {
 	    actions: string[];
+	    alias: string;
 	    collection: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_CollectionsPermission(source);
+	        return new w_AliasPermission(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.actions = source["actions"];
+	        this.alias = source["alias"];
 	        this.collection = source["collection"];
 	    }
 	}
-	export class w_CreateBackupInput {
-	    backend: string;
-	    id: string;
-	    include?: string[];
-	    exclude?: string[];
-	    compressionLevel?: string;
-	    cpuPercentage?: number;
+	export class w_BooleanAggregation {
+	    totalTrue: number;
+	    totalFalse: number;
+	    percentageTrue: number;
+	    percentageFalse: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_CreateBackupInput(source);
+	        return new w_BooleanAggregation(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.backend = source["backend"];
-	        this.id = source["id"];
-	        this.include = source["include"];
-	        this.exclude = source["exclude"];
-	        this.compressionLevel = source["compressionLevel"];
-	        this.cpuPercentage = source["cpuPercentage"];
+	        this.totalTrue = source["totalTrue"];
+	        this.totalFalse = source["totalFalse"];
+	        this.percentageTrue = source["percentageTrue"];
+	        this.percentageFalse = source["percentageFalse"];
 	    }
 	}
-	export class w_DataPermission {
+	export class w_DateAggregation {
+	    minimum?: string;
+	    maximum?: string;
+	    median?: string;
+	    mode?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_DateAggregation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.minimum = source["minimum"];
+	        this.maximum = source["maximum"];
+	        this.median = source["median"];
+	        this.mode = source["mode"];
+	    }
+	}
+	export class w_GenerativeOptions {
+	    SinglePrompt: string;
+	    GroupedTask: string;
+	    GroupedProperties: string[];
+	    Provider: string;
+	    Model: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_GenerativeOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.SinglePrompt = source["SinglePrompt"];
+	        this.GroupedTask = source["GroupedTask"];
+	        this.GroupedProperties = source["GroupedProperties"];
+	        this.Provider = source["Provider"];
+	        this.Model = source["Model"];
+	    }
+	}
+	export class w_GraphQLError {
+	    message: string;
+	    locations?: w_GraphQLErrorLocation[];
+	    path?: any[];
+	
+	    static createFrom(source: any = {}) {
+	        return new w_GraphQLError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.message = source["message"];
+	        this.locations = this.convertValues(source["locations"], w_GraphQLErrorLocation);
+	        this.path = source["path"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_GraphQLErrorLocation {
+	    line: number;
+	    column: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_GraphQLErrorLocation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.line = source["line"];
+	        this.column = source["column"];
+	    }
+	}
+	export class w_GraphQLInput {
+	    query: string;
+	    variables?: Record<string, any>;
+	    operationName?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_GraphQLInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.query = source["query"];
+	        this.variables = source["variables"];
+	        this.operationName = source["operationName"];
+	    }
+	}
+	export class w_GraphQLResult {
+	    data: any;
+	    errors?: w_GraphQLError[];
+	    validated: boolean;
+	    executionTime: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_GraphQLResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = source["data"];
+	        this.errors = this.convertValues(source["errors"], w_GraphQLError);
+	        this.validated = source["validated"];
+	        this.executionTime = source["executionTime"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_GroupedBy {
+	    path: string[];
+	    value: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_GroupedBy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.value = source["value"];
+	    }
+	}
+	export class w_HybridScore {
+	    score: number;
+	    originalScore?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_HybridScore(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.score = source["score"];
+	        this.originalScore = source["originalScore"];
+	    }
+	}
+	export class w_NumericAggregation {
+	    minimum?: number;
+	    maximum?: number;
+	    mean?: number;
+	    median?: number;
+	    mode?: number;
+	    sum?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_NumericAggregation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.minimum = source["minimum"];
+	        this.maximum = source["maximum"];
+	        this.mean = source["mean"];
+	        this.median = source["median"];
+	        this.mode = source["mode"];
+	        this.sum = source["sum"];
+	    }
+	}
+	export class w_Occurrence {
+	    value: string;
+	    occurs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_Occurrence(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.occurs = source["occurs"];
+	    }
+	}
+	export class w_PropertyAggregation {
+	    property: string;
+	    kind: string;
+	    count: number;
+	    numeric?: w_NumericAggregation;
+	    text?: w_TextAggregation;
+	    boolean?: w_BooleanAggregation;
+	    date?: w_DateAggregation;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_PropertyAggregation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.property = source["property"];
+	        this.kind = source["kind"];
+	        this.count = source["count"];
+	        this.numeric = this.convertValues(source["numeric"], w_NumericAggregation);
+	        this.text = this.convertValues(source["text"], w_TextAggregation);
+	        this.boolean = this.convertValues(source["boolean"], w_BooleanAggregation);
+	        this.date = this.convertValues(source["date"], w_DateAggregation);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_RawRequestInput {
+	    method: string;
+	    path: string;
+	    query?: Record<string, string[]>;
+	    body?: string;
+	    confirm?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_RawRequestInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.method = source["method"];
+	        this.path = source["path"];
+	        this.query = source["query"];
+	        this.body = source["body"];
+	        this.confirm = source["confirm"];
+	    }
+	}
+	export class w_RawResponse {
+	    status: number;
+	    statusText: string;
+	    headers: Record<string, string[]>;
+	    body: string;
+	    executionTime: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_RawResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.statusText = source["statusText"];
+	        this.headers = source["headers"];
+	        this.body = source["body"];
+	        this.executionTime = source["executionTime"];
+	    }
+	}
+	export class w_RerankOptions {
+	    Property: string;
+	    Query: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_RerankOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Property = source["Property"];
+	        this.Query = source["Query"];
+	    }
+	}
+	export class w_SchemaChange {
+	    kind: string;
+	    collection: string;
+	    property?: string;
+	    field?: string;
+	    source?: any;
+	    target?: any;
+	    immutable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_SchemaChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.collection = source["collection"];
+	        this.property = source["property"];
+	        this.field = source["field"];
+	        this.source = source["source"];
+	        this.target = source["target"];
+	        this.immutable = source["immutable"];
+	    }
+	}
+	export class w_AliasSwap {
+	    alias: string;
+	    from: string;
+	    to: string;
+	    incompatible: w_SchemaChange[];
+	    changes: w_SchemaChange[];
+	    swapped: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_AliasSwap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.alias = source["alias"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.incompatible = this.convertValues(source["incompatible"], w_SchemaChange);
+	        this.changes = this.convertValues(source["changes"], w_SchemaChange);
+	        this.swapped = source["swapped"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_AliasSwapInput {
+	    alias: string;
+	    collection: string;
+	    confirm: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_AliasSwapInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.alias = source["alias"];
+	        this.collection = source["collection"];
+	        this.confirm = source["confirm"];
+	    }
+	}
+	export class w_Backup {
+	    classes: string[];
+	    completedAt?: string;
+	    id: string;
+	    size?: number;
+	    startedAt: string;
+	    status: string;
+	    backend: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_Backup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.classes = source["classes"];
+	        this.completedAt = source["completedAt"];
+	        this.id = source["id"];
+	        this.size = source["size"];
+	        this.startedAt = source["startedAt"];
+	        this.status = source["status"];
+	        this.backend = source["backend"];
+	    }
+	}
+	export class w_BackupsPermission {
 	    actions: string[];
 	    collection: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_DataPermission(source);
+	        return new w_BackupsPermission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.actions = source["actions"];
+	        this.collection = source["collection"];
+	    }
+	}
+	export class w_BatchObjectError {
+	    index: number;
+	    id?: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_BatchObjectError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.id = source["id"];
+	        this.message = source["message"];
+	    }
+	}
+	export class w_ClusterHealth {
+	    connectionID: number;
+	    healthy: boolean;
+	    checkedAt: number;
+	    latencyMs: number;
+	    error?: string;
+	    version?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ClusterHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.connectionID = source["connectionID"];
+	        this.healthy = source["healthy"];
+	        this.checkedAt = source["checkedAt"];
+	        this.latencyMs = source["latencyMs"];
+	        this.error = source["error"];
+	        this.version = source["version"];
+	    }
+	}
+	export class w_ClusterPermission {
+	    actions: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ClusterPermission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.actions = source["actions"];
+	    }
+	}
+	export class w_ShardingInput {
+	    desiredCount?: number;
+	    virtualPerPhysical?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ShardingInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.desiredCount = source["desiredCount"];
+	        this.virtualPerPhysical = source["virtualPerPhysical"];
+	    }
+	}
+	export class w_MultiTenancyInput {
+	    enabled: boolean;
+	    autoTenantCreation?: boolean;
+	    autoTenantActivation?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_MultiTenancyInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.autoTenantCreation = source["autoTenantCreation"];
+	        this.autoTenantActivation = source["autoTenantActivation"];
+	    }
+	}
+	export class w_InvertedIndexInput {
+	    bm25b?: number;
+	    bm25k1?: number;
+	    stopwordsPreset?: string;
+	    stopwordsAdditions?: string[];
+	    stopwordsRemovals?: string[];
+	    cleanupIntervalSeconds?: number;
+	    indexTimestamps?: boolean;
+	    indexNullState?: boolean;
+	    indexPropertyLength?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_InvertedIndexInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bm25b = source["bm25b"];
+	        this.bm25k1 = source["bm25k1"];
+	        this.stopwordsPreset = source["stopwordsPreset"];
+	        this.stopwordsAdditions = source["stopwordsAdditions"];
+	        this.stopwordsRemovals = source["stopwordsRemovals"];
+	        this.cleanupIntervalSeconds = source["cleanupIntervalSeconds"];
+	        this.indexTimestamps = source["indexTimestamps"];
+	        this.indexNullState = source["indexNullState"];
+	        this.indexPropertyLength = source["indexPropertyLength"];
+	    }
+	}
+	export class w_NamedVectorInput {
+	    vectorizer: Record<string, any>;
+	    vectorIndexType?: string;
+	    vectorIndexConfig?: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_NamedVectorInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.vectorizer = source["vectorizer"];
+	        this.vectorIndexType = source["vectorIndexType"];
+	        this.vectorIndexConfig = source["vectorIndexConfig"];
+	    }
+	}
+	export class w_PropertyInput {
+	    name: string;
+	    description?: string;
+	    dataType: string[];
+	    tokenization?: string;
+	    indexFilterable?: boolean;
+	    indexSearchable?: boolean;
+	    indexRangeFilters?: boolean;
+	    moduleConfig?: Record<string, any>;
+	    nestedProperties?: w_PropertyInput[];
+	
+	    static createFrom(source: any = {}) {
+	        return new w_PropertyInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.dataType = source["dataType"];
+	        this.tokenization = source["tokenization"];
+	        this.indexFilterable = source["indexFilterable"];
+	        this.indexSearchable = source["indexSearchable"];
+	        this.indexRangeFilters = source["indexRangeFilters"];
+	        this.moduleConfig = source["moduleConfig"];
+	        this.nestedProperties = this.convertValues(source["nestedProperties"], w_PropertyInput);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_CollectionInput {
+	    name: string;
+	    description?: string;
+	    properties: w_PropertyInput[];
+	    vectorizer?: string;
+	    moduleConfig?: Record<string, any>;
+	    vectorIndexType?: string;
+	    vectorIndexConfig?: Record<string, any>;
+	    vectorConfig?: Record<string, NamedVectorInput>;
+	    invertedIndex?: w_InvertedIndexInput;
+	    multiTenancy?: w_MultiTenancyInput;
+	    replicationFactor?: number;
+	    asyncReplication?: boolean;
+	    sharding?: w_ShardingInput;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_CollectionInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.properties = this.convertValues(source["properties"], w_PropertyInput);
+	        this.vectorizer = source["vectorizer"];
+	        this.moduleConfig = source["moduleConfig"];
+	        this.vectorIndexType = source["vectorIndexType"];
+	        this.vectorIndexConfig = source["vectorIndexConfig"];
+	        this.vectorConfig = this.convertValues(source["vectorConfig"], w_NamedVectorInput, true);
+	        this.invertedIndex = this.convertValues(source["invertedIndex"], w_InvertedIndexInput);
+	        this.multiTenancy = this.convertValues(source["multiTenancy"], w_MultiTenancyInput);
+	        this.replicationFactor = source["replicationFactor"];
+	        this.asyncReplication = source["asyncReplication"];
+	        this.sharding = this.convertValues(source["sharding"], w_ShardingInput);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_FieldError {
+	    field: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_FieldError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.message = source["message"];
+	    }
+	}
+	export class w_CollectionResult {
+	    collection?: models.w_Class;
+	    errors?: w_FieldError[];
+	
+	    static createFrom(source: any = {}) {
+	        return new w_CollectionResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.collection = this.convertValues(source["collection"], models.w_Class);
+	        this.errors = this.convertValues(source["errors"], w_FieldError);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_CollectionUpdateInput {
+	    description?: string;
+	    invertedIndex?: w_InvertedIndexInput;
+	    vectorIndexConfig?: Record<string, any>;
+	    vectorIndexConfigs?: Record<string, any>;
+	    replicationFactor?: number;
+	    asyncReplication?: boolean;
+	    autoTenantCreation?: boolean;
+	    autoTenantActivation?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_CollectionUpdateInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.description = source["description"];
+	        this.invertedIndex = this.convertValues(source["invertedIndex"], w_InvertedIndexInput);
+	        this.vectorIndexConfig = source["vectorIndexConfig"];
+	        this.vectorIndexConfigs = source["vectorIndexConfigs"];
+	        this.replicationFactor = source["replicationFactor"];
+	        this.asyncReplication = source["asyncReplication"];
+	        this.autoTenantCreation = source["autoTenantCreation"];
+	        this.autoTenantActivation = source["autoTenantActivation"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_CollectionsPermission {
+	    actions: string[];
+	    collection: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_CollectionsPermission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.actions = source["actions"];
+	        this.collection = source["collection"];
+	    }
+	}
+	export class w_CreateBackupInput {
+	    backend: string;
+	    id: string;
+	    include?: string[];
+	    exclude?: string[];
+	    compressionLevel?: string;
+	    cpuPercentage?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_CreateBackupInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.backend = source["backend"];
+	        this.id = source["id"];
+	        this.include = source["include"];
+	        this.exclude = source["exclude"];
+	        this.compressionLevel = source["compressionLevel"];
+	        this.cpuPercentage = source["cpuPercentage"];
+	    }
+	}
+	export class w_DataPermission {
+	    actions: string[];
+	    collection: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_DataPermission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.actions = source["actions"];
+	        this.collection = source["collection"];
+	    }
+	}
+	
+	export class w_Filter {
+	    operator: string;
+	    operands?: w_Filter[];
+	    path?: string[];
+	    value?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_Filter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.operator = source["operator"];
+	        this.operands = this.convertValues(source["operands"], w_Filter);
+	        this.path = source["path"];
+	        this.value = source["value"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_FilteredObjectsInput {
+	    collection: string;
+	    tenant?: string;
+	    filter?: w_Filter;
+	    offset: number;
+	    limit: number;
+	    includeVector?: boolean;
+	    sortById?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_FilteredObjectsInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.collection = source["collection"];
+	        this.tenant = source["tenant"];
+	        this.filter = this.convertValues(source["filter"], w_Filter);
+	        this.offset = source["offset"];
+	        this.limit = source["limit"];
+	        this.includeVector = source["includeVector"];
+	        this.sortById = source["sortById"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_GetCreationStatusInput {
+	    backend: string;
+	    id: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_GetCreationStatusInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.backend = source["backend"];
+	        this.id = source["id"];
+	    }
+	}
+	export class w_GroupPermission {
+	    actions: string[];
+	    group: string;
+	    groupType: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_GroupPermission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.actions = source["actions"];
+	        this.group = source["group"];
+	        this.groupType = source["groupType"];
+	    }
+	}
+	
+	
+	
+	export class w_NodesPermission {
+	    actions: string[];
+	    collection: string;
+	    verbosity: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_NodesPermission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.actions = source["actions"];
+	        this.collection = source["collection"];
+	        this.verbosity = source["verbosity"];
+	    }
+	}
+	export class w_ObjectInput {
+	    collection: string;
+	    id?: string;
+	    tenant?: string;
+	    properties?: Record<string, any>;
+	    vector?: number[];
+	    vectors?: Record<string, Array<number>>;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ObjectInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.collection = source["collection"];
+	        this.id = source["id"];
+	        this.tenant = source["tenant"];
+	        this.properties = source["properties"];
+	        this.vector = source["vector"];
+	        this.vectors = source["vectors"];
+	    }
+	}
+	export class w_ObjectsPageInput {
+	    collection: string;
+	    tenant?: string;
+	    cursor: string;
+	    pageSize: number;
+	    includeVector?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ObjectsPageInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.collection = source["collection"];
+	        this.tenant = source["tenant"];
+	        this.cursor = source["cursor"];
+	        this.pageSize = source["pageSize"];
+	        this.includeVector = source["includeVector"];
+	    }
+	}
+	export class w_TextAggregation {
+	    topOccurrences: w_Occurrence[];
+	
+	    static createFrom(source: any = {}) {
+	        return new w_TextAggregation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.topOccurrences = this.convertValues(source["topOccurrences"], w_Occurrence);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_WeaviateObject {
+	    id: string;
+	    class: string;
+	    lastUpdateTimeUnix?: number;
+	    creationTimeUnix?: number;
+	    tenant?: string;
+	    properties?: any;
+	    vector?: number[];
+	    vectors?: Record<string, any>;
+	    distance?: number;
+	    certainty?: number;
+	    score?: number;
+	    explainScore?: string;
+	    keywordScore?: w_HybridScore;
+	    vectorScore?: w_HybridScore;
+	    rerankScore?: number;
+	    targetDistances?: Record<string, number>;
+	    generated?: string;
+	    generativeError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_WeaviateObject(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.class = source["class"];
+	        this.lastUpdateTimeUnix = source["lastUpdateTimeUnix"];
+	        this.creationTimeUnix = source["creationTimeUnix"];
+	        this.tenant = source["tenant"];
+	        this.properties = source["properties"];
+	        this.vector = source["vector"];
+	        this.vectors = source["vectors"];
+	        this.distance = source["distance"];
+	        this.certainty = source["certainty"];
+	        this.score = source["score"];
+	        this.explainScore = source["explainScore"];
+	        this.keywordScore = this.convertValues(source["keywordScore"], w_HybridScore);
+	        this.vectorScore = this.convertValues(source["vectorScore"], w_HybridScore);
+	        this.rerankScore = source["rerankScore"];
+	        this.targetDistances = source["targetDistances"];
+	        this.generated = source["generated"];
+	        this.generativeError = source["generativeError"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_PaginatedObjectResponse {
+	    Objects: w_WeaviateObject[];
+	    ExecutionTime: string;
+	    TotalResults: number;
+	    GeneratedGrouped: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_PaginatedObjectResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Objects = this.convertValues(source["Objects"], w_WeaviateObject);
+	        this.ExecutionTime = source["ExecutionTime"];
+	        this.TotalResults = source["TotalResults"];
+	        this.GeneratedGrouped = source["GeneratedGrouped"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class w_ReplicateInput {
+	    collection: string;
+	    shard: string;
+	    sourceNode: string;
+	    targetNode: string;
+	    type?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ReplicateInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.collection = source["collection"];
+	        this.shard = source["shard"];
+	        this.sourceNode = source["sourceNode"];
+	        this.targetNode = source["targetNode"];
+	        this.type = source["type"];
+	    }
+	}
+	export class w_ReplicatePermission {
+	    actions: string[];
+	    collection: string;
+	    shard: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ReplicatePermission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.actions = source["actions"];
+	        this.collection = source["collection"];
+	        this.shard = source["shard"];
+	    }
+	}
+	export class w_ReplicationState {
+	    state: string;
+	    errors: string[];
+	    startedAt?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ReplicationState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.state = source["state"];
+	        this.errors = source["errors"];
+	        this.startedAt = source["startedAt"];
+	    }
+	}
+	export class w_ReplicationOperation {
+	    id: string;
+	    collection: string;
+	    shard: string;
+	    sourceNode: string;
+	    targetNode: string;
+	    type: string;
+	    status: w_ReplicationState;
+	    history: w_ReplicationState[];
+	    scheduledForCancel: boolean;
+	    scheduledForDelete: boolean;
+	    uncancelable: boolean;
+	    startedAt?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ReplicationOperation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.collection = source["collection"];
+	        this.shard = source["shard"];
+	        this.sourceNode = source["sourceNode"];
+	        this.targetNode = source["targetNode"];
+	        this.type = source["type"];
+	        this.status = this.convertValues(source["status"], w_ReplicationState);
+	        this.history = this.convertValues(source["history"], w_ReplicationState);
+	        this.scheduledForCancel = source["scheduledForCancel"];
+	        this.scheduledForDelete = source["scheduledForDelete"];
+	        this.uncancelable = source["uncancelable"];
+	        this.startedAt = source["startedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_ReplicationOperationsInput {
+	    collection?: string;
+	    shard?: string;
+	    targetNode?: string;
+	    includeHistory?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ReplicationOperationsInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.collection = source["collection"];
+	        this.shard = source["shard"];
+	        this.targetNode = source["targetNode"];
+	        this.includeHistory = source["includeHistory"];
+	    }
+	}
+	
+	export class w_RestoreBackupInput {
+	    backend: string;
+	    id: string;
+	    include?: string[];
+	    exclude?: string[];
+	    includeRBACAndUsers?: boolean;
+	    overwriteAlias?: boolean;
+	    cpuPercentage?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_RestoreBackupInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.backend = source["backend"];
+	        this.id = source["id"];
+	        this.include = source["include"];
+	        this.exclude = source["exclude"];
+	        this.includeRBACAndUsers = source["includeRBACAndUsers"];
+	        this.overwriteAlias = source["overwriteAlias"];
+	        this.cpuPercentage = source["cpuPercentage"];
+	    }
+	}
+	export class w_UsersPermission {
+	    actions: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new w_UsersPermission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.actions = source["actions"];
+	    }
+	}
+	export class w_TenantsPermission {
+	    actions: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new w_TenantsPermission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.actions = source["actions"];
+	    }
+	}
+	export class w_RolesPermission {
+	    actions: string[];
+	    role: string;
+	    scope: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_RolesPermission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.actions = source["actions"];
+	        this.role = source["role"];
+	        this.scope = source["scope"];
+	    }
+	}
+	export class w_Role {
+	    name: string;
+	    backups?: w_BackupsPermission[];
+	    cluster?: w_ClusterPermission[];
+	    collections?: w_CollectionsPermission[];
+	    data?: w_DataPermission[];
+	    nodes?: w_NodesPermission[];
+	    roles?: w_RolesPermission[];
+	    replicate?: w_ReplicatePermission[];
+	    alias?: w_AliasPermission[];
+	    tenants?: w_TenantsPermission[];
+	    users?: w_UsersPermission[];
+	    groups?: w_GroupPermission[];
+	
+	    static createFrom(source: any = {}) {
+	        return new w_Role(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.backups = this.convertValues(source["backups"], w_BackupsPermission);
+	        this.cluster = this.convertValues(source["cluster"], w_ClusterPermission);
+	        this.collections = this.convertValues(source["collections"], w_CollectionsPermission);
+	        this.data = this.convertValues(source["data"], w_DataPermission);
+	        this.nodes = this.convertValues(source["nodes"], w_NodesPermission);
+	        this.roles = this.convertValues(source["roles"], w_RolesPermission);
+	        this.replicate = this.convertValues(source["replicate"], w_ReplicatePermission);
+	        this.alias = this.convertValues(source["alias"], w_AliasPermission);
+	        this.tenants = this.convertValues(source["tenants"], w_TenantsPermission);
+	        this.users = this.convertValues(source["users"], w_UsersPermission);
+	        this.groups = this.convertValues(source["groups"], w_GroupPermission);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class w_SchemaDiff {
+	    sourceConnectionID: number;
+	    targetConnectionID: number;
+	    changes: w_SchemaChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new w_SchemaDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sourceConnectionID = source["sourceConnectionID"];
+	        this.targetConnectionID = source["targetConnectionID"];
+	        this.changes = this.convertValues(source["changes"], w_SchemaChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_SchemaSyncError {
+	    change: w_SchemaChange;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_SchemaSyncError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.change = this.convertValues(source["change"], w_SchemaChange);
+	        this.message = source["message"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_SchemaSyncResult {
+	    dryRun: boolean;
+	    applied: w_SchemaChange[];
+	    skipped: w_SchemaChange[];
+	    failed: w_SchemaSyncError[];
+	
+	    static createFrom(source: any = {}) {
+	        return new w_SchemaSyncResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dryRun = source["dryRun"];
+	        this.applied = this.convertValues(source["applied"], w_SchemaChange);
+	        this.skipped = this.convertValues(source["skipped"], w_SchemaChange);
+	        this.failed = this.convertValues(source["failed"], w_SchemaSyncError);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_SearchOptions {
+	    Limit: number;
+	    Alpha: number;
+	    FusionType: string;
+	    Distance: number;
+	    Certainty: number;
+	    Filter?: w_Filter;
+	    Generative?: w_GenerativeOptions;
+	    TargetVectors: string[];
+	    TargetCombination: string;
+	    TargetWeights: Record<string, number>;
+	    IncludeSource: boolean;
+	    IncludeVector: boolean;
+	    Rerank?: w_RerankOptions;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_SearchOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Limit = source["Limit"];
+	        this.Alpha = source["Alpha"];
+	        this.FusionType = source["FusionType"];
+	        this.Distance = source["Distance"];
+	        this.Certainty = source["Certainty"];
+	        this.Filter = this.convertValues(source["Filter"], w_Filter);
+	        this.Generative = this.convertValues(source["Generative"], w_GenerativeOptions);
+	        this.TargetVectors = source["TargetVectors"];
+	        this.TargetCombination = source["TargetCombination"];
+	        this.TargetWeights = source["TargetWeights"];
+	        this.IncludeSource = source["IncludeSource"];
+	        this.IncludeVector = source["IncludeVector"];
+	        this.Rerank = this.convertValues(source["Rerank"], w_RerankOptions);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class w_ShardReplica {
+	    node: string;
+	    objectCount: number;
+	    vectorIndexingStatus: string;
+	    vectorQueueLength: number;
+	    loaded: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ShardReplica(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.actions = source["actions"];
-	        this.collection = source["collection"];
+	        this.node = source["node"];
+	        this.objectCount = source["objectCount"];
+	        this.vectorIndexingStatus = source["vectorIndexingStatus"];
+	        this.vectorQueueLength = source["vectorQueueLength"];
+	        this.loaded = source["loaded"];
 	    }
 	}
-	export class w_GetCreationStatusInput {
-	    backend: string;
-	    id: string;
+	export class w_Shard {
+	    name: string;
+	    status?: string;
+	    vectorQueueSize: number;
+	    objectCount: number;
+	    activityStatus?: string;
+	    replicas: w_ShardReplica[];
 	
 	    static createFrom(source: any = {}) {
-	        return new w_GetCreationStatusInput(source);
+	        return new w_Shard(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.backend = source["backend"];
-	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.vectorQueueSize = source["vectorQueueSize"];
+	        this.objectCount = source["objectCount"];
+	        this.activityStatus = source["activityStatus"];
+	        this.replicas = this.convertValues(source["replicas"], w_ShardReplica);
+	        this.error = source["error"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class w_GroupPermission {
-	    actions: string[];
-	    group: string;
-	    groupType: string;
+	export class w_ShardError {
+	    name: string;
+	    message: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_GroupPermission(source);
+	        return new w_ShardError(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.actions = source["actions"];
-	        this.group = source["group"];
-	        this.groupType = source["groupType"];
+	        this.name = source["name"];
+	        this.message = source["message"];
 	    }
 	}
-	export class w_NodesPermission {
-	    actions: string[];
+	
+	export class w_ShardReplicas {
+	    shard: string;
+	    replicas: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new w_ShardReplicas(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.shard = source["shard"];
+	        this.replicas = source["replicas"];
+	    }
+	}
+	
+	export class w_ShardingState {
 	    collection: string;
-	    verbosity: string;
+	    shards: w_ShardReplicas[];
+	    nodes: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new w_NodesPermission(source);
+	        return new w_ShardingState(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.actions = source["actions"];
 	        this.collection = source["collection"];
-	        this.verbosity = source["verbosity"];
+	        this.shards = this.convertValues(source["shards"], w_ShardReplicas);
+	        this.nodes = source["nodes"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class w_WeaviateObject {
-	    id: string;
-	    class: string;
-	    lastUpdateTimeUnix?: number;
-	    creationTimeUnix?: number;
-	    tenant?: string;
-	    properties?: any;
+	export class w_ShardsInput {
+	    collection: string;
+	    tenants?: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new w_WeaviateObject(source);
+	        return new w_ShardsInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.class = source["class"];
-	        this.lastUpdateTimeUnix = source["lastUpdateTimeUnix"];
-	        this.creationTimeUnix = source["creationTimeUnix"];
-	        this.tenant = source["tenant"];
-	        this.properties = source["properties"];
+	        this.collection = source["collection"];
+	        this.tenants = source["tenants"];
 	    }
 	}
-	export class w_PaginatedObjectResponse {
-	    Objects: w_WeaviateObject[];
-	    ExecutionTime: string;
-	    TotalResults: number;
+	export class w_ShardsResult {
+	    shards: string[];
+	    failed: w_ShardError[];
 	
 	    static createFrom(source: any = {}) {
-	        return new w_PaginatedObjectResponse(source);
+	        return new w_ShardsResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.Objects = this.convertValues(source["Objects"], w_WeaviateObject);
-	        this.ExecutionTime = source["ExecutionTime"];
-	        this.TotalResults = source["TotalResults"];
+	        this.shards = source["shards"];
+	        this.failed = this.convertValues(source["failed"], w_ShardError);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -850,118 +2706,92 @@ export namespace weaviate {
 		    return a;
 		}
 	}
-	export class w_ReplicatePermission {
-	    actions: string[];
-	    collection: string;
-	    shard: string;
+	export class w_StatusResponse {
+	    status: string;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_ReplicatePermission(source);
+	        return new w_StatusResponse(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.actions = source["actions"];
-	        this.collection = source["collection"];
-	        this.shard = source["shard"];
+	        this.status = source["status"];
+	        this.error = source["error"];
 	    }
 	}
-	export class w_RestoreBackupInput {
-	    backend: string;
-	    id: string;
-	    include?: string[];
-	    exclude?: string[];
-	    includeRBACAndUsers?: boolean;
-	    overwriteAlias?: boolean;
-	    cpuPercentage?: number;
+	export class w_TenantError {
+	    name: string;
+	    message: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_RestoreBackupInput(source);
+	        return new w_TenantError(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.backend = source["backend"];
-	        this.id = source["id"];
-	        this.include = source["include"];
-	        this.exclude = source["exclude"];
-	        this.includeRBACAndUsers = source["includeRBACAndUsers"];
-	        this.overwriteAlias = source["overwriteAlias"];
-	        this.cpuPercentage = source["cpuPercentage"];
+	        this.name = source["name"];
+	        this.message = source["message"];
 	    }
 	}
-	export class w_UsersPermission {
-	    actions: string[];
+	export class w_TenantInput {
+	    name: string;
+	    activityStatus?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_UsersPermission(source);
+	        return new w_TenantInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.actions = source["actions"];
+	        this.name = source["name"];
+	        this.activityStatus = source["activityStatus"];
 	    }
 	}
-	export class w_TenantsPermission {
-	    actions: string[];
+	export class w_TenantObjectCount {
+	    name: string;
+	    count: number;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_TenantsPermission(source);
+	        return new w_TenantObjectCount(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.actions = source["actions"];
+	        this.name = source["name"];
+	        this.count = source["count"];
+	        this.error = source["error"];
 	    }
 	}
-	export class w_RolesPermission {
-	    actions: string[];
-	    role: string;
-	    scope: string;
+	export class w_TenantSelector {
+	    names?: string[];
+	    pattern?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_RolesPermission(source);
+	        return new w_TenantSelector(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.actions = source["actions"];
-	        this.role = source["role"];
-	        this.scope = source["scope"];
+	        this.names = source["names"];
+	        this.pattern = source["pattern"];
 	    }
 	}
-	export class w_Role {
-	    name: string;
-	    backups?: w_BackupsPermission[];
-	    cluster?: w_ClusterPermission[];
-	    collections?: w_CollectionsPermission[];
-	    data?: w_DataPermission[];
-	    nodes?: w_NodesPermission[];
-	    roles?: w_RolesPermission[];
-	    replicate?: w_ReplicatePermission[];
-	    alias?: w_AliasPermission[];
-	    tenants?: w_TenantsPermission[];
-	    users?: w_UsersPermission[];
-	    groups?: w_GroupPermission[];
+	export class w_TenantsPage {
+	    tenants: w_models.Tenant[];
+	    nextCursor?: string;
+	    total: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_Role(source);
+	        return new w_TenantsPage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.backups = this.convertValues(source["backups"], w_BackupsPermission);
-	        this.cluster = this.convertValues(source["cluster"], w_ClusterPermission);
-	        this.collections = this.convertValues(source["collections"], w_CollectionsPermission);
-	        this.data = this.convertValues(source["data"], w_DataPermission);
-	        this.nodes = this.convertValues(source["nodes"], w_NodesPermission);
-	        this.roles = this.convertValues(source["roles"], w_RolesPermission);
-	        this.replicate = this.convertValues(source["replicate"], w_ReplicatePermission);
-	        this.alias = this.convertValues(source["alias"], w_AliasPermission);
-	        this.tenants = this.convertValues(source["tenants"], w_TenantsPermission);
-	        this.users = this.convertValues(source["users"], w_UsersPermission);
-	        this.groups = this.convertValues(source["groups"], w_GroupPermission);
+	        this.tenants = this.convertValues(source["tenants"], w_models.Tenant);
+	        this.nextCursor = source["nextCursor"];
+	        this.total = source["total"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -982,45 +2812,80 @@ export namespace weaviate {
 		    return a;
 		}
 	}
-	
-	export class w_SearchOptions {
-	    Limit: number;
-	    Alpha: number;
-	    FusionType: string;
-	    Distance: number;
-	    Certainty: number;
+	export class w_TenantsPageInput {
+	    collection: string;
+	    pattern?: string;
+	    activityStatus?: string;
+	    cursor?: string;
+	    limit?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new w_SearchOptions(source);
+	        return new w_TenantsPageInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.Limit = source["Limit"];
-	        this.Alpha = source["Alpha"];
-	        this.FusionType = source["FusionType"];
-	        this.Distance = source["Distance"];
-	        this.Certainty = source["Certainty"];
+	        this.collection = source["collection"];
+	        this.pattern = source["pattern"];
+	        this.activityStatus = source["activityStatus"];
+	        this.cursor = source["cursor"];
+	        this.limit = source["limit"];
 	    }
 	}
-	export class w_StatusResponse {
-	    status: string;
-	    error?: string;
+	
+	export class w_TenantsResult {
+	    tenants: string[];
+	    failed: w_TenantError[];
 	
 	    static createFrom(source: any = {}) {
-	        return new w_StatusResponse(source);
+	        return new w_TenantsResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.status = source["status"];
-	        this.error = source["error"];
+	        this.tenants = source["tenants"];
+	        this.failed = this.convertValues(source["failed"], w_TenantError);
 	    }
-	}
 	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class w_TestConnectionInput {
 	    URI: string;
 	    ApiKey?: string;
+	    AuthType?: string;
+	    Username?: string;
+	    Password?: string;
+	    ClientSecret?: string;
+	    Scopes?: string;
+	    AccessToken?: string;
+	    RefreshToken?: string;
+	    Headers?: w_models.ConnectionHeader[];
+	    CACertFile?: string;
+	    ClientCertFile?: string;
+	    ClientKeyFile?: string;
+	    InsecureSkipVerify?: boolean;
+	    ProxyURL?: string;
+	    SSHHost?: string;
+	    SSHUser?: string;
+	    SSHKeyFile?: string;
+	    GRPCHost?: string;
+	    GRPCPort?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new w_TestConnectionInput(source);
@@ -1030,7 +2895,43 @@ export namespace weaviate {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.URI = source["URI"];
 	        this.ApiKey = source["ApiKey"];
+	        this.AuthType = source["AuthType"];
+	        this.Username = source["Username"];
+	        this.Password = source["Password"];
+	        this.ClientSecret = source["ClientSecret"];
+	        this.Scopes = source["Scopes"];
+	        this.AccessToken = source["AccessToken"];
+	        this.RefreshToken = source["RefreshToken"];
+	        this.Headers = this.convertValues(source["Headers"], w_models.ConnectionHeader);
+	        this.CACertFile = source["CACertFile"];
+	        this.ClientCertFile = source["ClientCertFile"];
+	        this.ClientKeyFile = source["ClientKeyFile"];
+	        this.InsecureSkipVerify = source["InsecureSkipVerify"];
+	        this.ProxyURL = source["ProxyURL"];
+	        this.SSHHost = source["SSHHost"];
+	        this.SSHUser = source["SSHUser"];
+	        this.SSHKeyFile = source["SSHKeyFile"];
+	        this.GRPCHost = source["GRPCHost"];
+	        this.GRPCPort = source["GRPCPort"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class w_UserInfo {
 	    active: boolean;
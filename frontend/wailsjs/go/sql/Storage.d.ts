@@ -2,14 +2,20 @@
 // This file is automatically generated. DO NOT EDIT
 import {models} from '../models';
 
+export function ClearRequestHistory(arg1:number):Promise<void>;
+
 export function GetConnection(arg1:number,arg2:boolean):Promise<models.w_Connection>;
 
 export function GetConnections(arg1:boolean):Promise<Array<models.w_Connection>>;
 
+export function GetRequestHistory(arg1:number,arg2:number):Promise<Array<models.w_RequestHistory>>;
+
 export function RemoveConnection(arg1:number):Promise<void>;
 
 export function SaveConnection(arg1:models.w_Connection):Promise<number>;
 
+export function SaveRequest(arg1:models.w_RequestHistory):Promise<number>;
+
 export function UpdateConnection(arg1:models.w_Connection):Promise<void>;
 
 export function UpdateFavorite(arg1:number,arg2:boolean):Promise<void>;
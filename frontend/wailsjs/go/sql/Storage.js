@@ -2,6 +2,10 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function ClearRequestHistory(arg1) {
+  return window['go']['sql']['Storage']['ClearRequestHistory'](arg1);
+}
+
 export function GetConnection(arg1, arg2) {
   return window['go']['sql']['Storage']['GetConnection'](arg1, arg2);
 }
@@ -10,6 +14,10 @@ export function GetConnections(arg1) {
   return window['go']['sql']['Storage']['GetConnections'](arg1);
 }
 
+export function GetRequestHistory(arg1, arg2) {
+  return window['go']['sql']['Storage']['GetRequestHistory'](arg1, arg2);
+}
+
 export function RemoveConnection(arg1) {
   return window['go']['sql']['Storage']['RemoveConnection'](arg1);
 }
@@ -18,6 +26,10 @@ export function SaveConnection(arg1) {
   return window['go']['sql']['Storage']['SaveConnection'](arg1);
 }
 
+export function SaveRequest(arg1) {
+  return window['go']['sql']['Storage']['SaveRequest'](arg1);
+}
+
 export function UpdateConnection(arg1) {
   return window['go']['sql']['Storage']['UpdateConnection'](arg1);
 }
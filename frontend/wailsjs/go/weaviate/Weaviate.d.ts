@@ -2,39 +2,68 @@
 // This file is automatically generated. DO NOT EDIT
 import {weaviate} from '../models';
 import {models} from '../models';
+import {context} from '../models';
 
 export function ActivateApiKey(arg1:number,arg2:string):Promise<void>;
 
+export function AddProperty(arg1:number,arg2:string,arg3:weaviate.w_PropertyInput):Promise<weaviate.w_CollectionResult>;
+
 export function AddRolePermissions(arg1:number,arg2:string,arg3:weaviate.w_Role):Promise<void>;
 
+export function Aggregate(arg1:number,arg2:weaviate.w_AggregationInput):Promise<weaviate.w_AggregationResult>;
+
 export function AssignRolesToUser(arg1:number,arg2:string,arg3:Array<string>):Promise<void>;
 
 export function BackupModulesEnabled(arg1:number):Promise<Array<string>>;
 
+export function BatchCreateObjects(arg1:number,arg2:models.w_Class,arg3:Array<weaviate.w_ObjectInput>):Promise<Array<weaviate.w_BatchObjectError>>;
+
 export function CancelBackup(arg1:number,arg2:string,arg3:string):Promise<void>;
 
+export function CancelReplicationOperation(arg1:number,arg2:string):Promise<void>;
+
+export function ClusterHealth(arg1:number):Promise<weaviate.w_ClusterHealth>;
+
 export function ClusterStatus(arg1:number):Promise<boolean>;
 
 export function Connect(arg1:number):Promise<void>;
 
+export function CreateAlias(arg1:number,arg2:string,arg3:string):Promise<void>;
+
 export function CreateBackup(arg1:number,arg2:weaviate.w_CreateBackupInput):Promise<void>;
 
+export function CreateCollection(arg1:number,arg2:weaviate.w_CollectionInput):Promise<weaviate.w_CollectionResult>;
+
+export function CreateObject(arg1:number,arg2:weaviate.w_ObjectInput):Promise<weaviate.w_WeaviateObject>;
+
 export function CreateRole(arg1:number,arg2:weaviate.w_Role):Promise<void>;
 
+export function CreateTenants(arg1:number,arg2:string,arg3:Array<weaviate.w_TenantInput>):Promise<weaviate.w_TenantsResult>;
+
 export function CreateUser(arg1:number,arg2:string):Promise<string>;
 
 export function DeactivateApiKey(arg1:number,arg2:string,arg3:boolean):Promise<void>;
 
+export function DeleteAlias(arg1:number,arg2:string):Promise<void>;
+
 export function DeleteCollection(arg1:number,arg2:string):Promise<void>;
 
 export function DeleteObject(arg1:number,arg2:string,arg3:string,arg4:string):Promise<void>;
 
+export function DeleteReplicationOperation(arg1:number,arg2:string):Promise<void>;
+
 export function DeleteRole(arg1:number,arg2:string):Promise<void>;
 
+export function DeleteTenants(arg1:number,arg2:string,arg3:weaviate.w_TenantSelector):Promise<weaviate.w_TenantsResult>;
+
 export function DeleteUser(arg1:number,arg2:string):Promise<void>;
 
+export function DiffSchemas(arg1:number,arg2:number):Promise<weaviate.w_SchemaDiff>;
+
 export function Disconnect(arg1:number):Promise<void>;
 
+export function GetAliases(arg1:number,arg2:string):Promise<Array<weaviate.w_Alias>>;
+
 export function GetCollection(arg1:number,arg2:string):Promise<models.w_Class>;
 
 export function GetCollections(arg1:number):Promise<Array<models.w_Class>>;
@@ -43,13 +72,27 @@ export function GetCreationStatus(arg1:number,arg2:weaviate.w_GetCreationStatusI
 
 export function GetModules(arg1:number):Promise<any>;
 
+export function GetObjectsFiltered(arg1:number,arg2:weaviate.w_FilteredObjectsInput):Promise<weaviate.w_PaginatedObjectResponse>;
+
+export function GetObjectsPage(arg1:number,arg2:weaviate.w_ObjectsPageInput):Promise<weaviate.w_PaginatedObjectResponse>;
+
 export function GetObjectsPaginated(arg1:number,arg2:number,arg3:string,arg4:string,arg5:string):Promise<weaviate.w_PaginatedObjectResponse>;
 
+export function GetReplicationOperations(arg1:number,arg2:weaviate.w_ReplicationOperationsInput):Promise<Array<weaviate.w_ReplicationOperation>>;
+
 export function GetRestoreStatus(arg1:number,arg2:string,arg3:string):Promise<weaviate.w_StatusResponse>;
 
+export function GetShardingState(arg1:number,arg2:string):Promise<weaviate.w_ShardingState>;
+
+export function GetShards(arg1:number,arg2:weaviate.w_ShardsInput):Promise<Array<weaviate.w_Shard>>;
+
 export function GetTenants(arg1:number,arg2:string):Promise<Array<models.w_Tenant>>;
 
-export function GetTotalObjects(arg1:number,arg2:string,arg3:string):Promise<number>;
+export function GetTenantsObjectCount(arg1:number,arg2:string,arg3:Array<string>):Promise<Array<weaviate.w_TenantObjectCount>>;
+
+export function GetTenantsPage(arg1:number,arg2:weaviate.w_TenantsPageInput):Promise<weaviate.w_TenantsPage>;
+
+export function GetTotalObjects(arg1:number,arg2:string,arg3:string,arg4:weaviate.w_Filter):Promise<number>;
 
 export function ListBackups(arg1:number,arg2:Array<string>):Promise<Array<weaviate.w_Backup>>;
 
@@ -59,16 +102,40 @@ export function ListUsers(arg1:number):Promise<Array<weaviate.w_UserInfo>>;
 
 export function NodesStatus(arg1:number):Promise<models.w_NodesStatusResponse>;
 
+export function PatchObject(arg1:number,arg2:weaviate.w_ObjectInput):Promise<weaviate.w_WeaviateObject>;
+
 export function RemoveRolePermissions(arg1:number,arg2:string,arg3:weaviate.w_Role):Promise<void>;
 
+export function ReplaceObject(arg1:number,arg2:weaviate.w_ObjectInput):Promise<weaviate.w_WeaviateObject>;
+
+export function ReplicateShard(arg1:number,arg2:weaviate.w_ReplicateInput):Promise<string>;
+
 export function RestoreBackup(arg1:number,arg2:weaviate.w_RestoreBackupInput):Promise<void>;
 
 export function RevokeRolesFromUser(arg1:number,arg2:string,arg3:Array<string>):Promise<void>;
 
 export function RotateUserApiKey(arg1:number,arg2:string):Promise<string>;
 
+export function RunGraphQL(arg1:number,arg2:weaviate.w_GraphQLInput):Promise<weaviate.w_GraphQLResult>;
+
 export function Search(arg1:number,arg2:string,arg3:string,arg4:string,arg5:string,arg6:weaviate.w_SearchOptions):Promise<weaviate.w_PaginatedObjectResponse>;
 
+export function SendRawRequest(arg1:number,arg2:weaviate.w_RawRequestInput):Promise<weaviate.w_RawResponse>;
+
+export function SetRuntimeContext(arg1:context.w_Context):Promise<void>;
+
+export function Shutdown():Promise<void>;
+
+export function SwapAlias(arg1:number,arg2:weaviate.w_AliasSwapInput):Promise<weaviate.w_AliasSwap>;
+
+export function SyncSchemas(arg1:number,arg2:number,arg3:boolean):Promise<weaviate.w_SchemaSyncResult>;
+
 export function TestConnection(arg1:weaviate.w_TestConnectionInput):Promise<void>;
 
+export function UpdateCollection(arg1:number,arg2:string,arg3:weaviate.w_CollectionUpdateInput):Promise<weaviate.w_CollectionResult>;
+
+export function UpdateShardsStatus(arg1:number,arg2:string,arg3:Array<string>,arg4:string):Promise<weaviate.w_ShardsResult>;
+
+export function UpdateTenantsStatus(arg1:number,arg2:string,arg3:weaviate.w_TenantSelector,arg4:string):Promise<weaviate.w_TenantsResult>;
+
 export function UsersEnabled(arg1:number):Promise<boolean>;
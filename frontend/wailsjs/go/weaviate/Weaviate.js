@@ -6,10 +6,18 @@ export function ActivateApiKey(arg1, arg2) {
   return window['go']['weaviate']['Weaviate']['ActivateApiKey'](arg1, arg2);
 }
 
+export function AddProperty(arg1, arg2, arg3) {
+  return window['go']['weaviate']['Weaviate']['AddProperty'](arg1, arg2, arg3);
+}
+
 export function AddRolePermissions(arg1, arg2, arg3) {
   return window['go']['weaviate']['Weaviate']['AddRolePermissions'](arg1, arg2, arg3);
 }
 
+export function Aggregate(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['Aggregate'](arg1, arg2);
+}
+
 export function AssignRolesToUser(arg1, arg2, arg3) {
   return window['go']['weaviate']['Weaviate']['AssignRolesToUser'](arg1, arg2, arg3);
 }
@@ -18,10 +26,22 @@ export function BackupModulesEnabled(arg1) {
   return window['go']['weaviate']['Weaviate']['BackupModulesEnabled'](arg1);
 }
 
+export function BatchCreateObjects(arg1, arg2, arg3) {
+  return window['go']['weaviate']['Weaviate']['BatchCreateObjects'](arg1, arg2, arg3);
+}
+
 export function CancelBackup(arg1, arg2, arg3) {
   return window['go']['weaviate']['Weaviate']['CancelBackup'](arg1, arg2, arg3);
 }
 
+export function CancelReplicationOperation(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['CancelReplicationOperation'](arg1, arg2);
+}
+
+export function ClusterHealth(arg1) {
+  return window['go']['weaviate']['Weaviate']['ClusterHealth'](arg1);
+}
+
 export function ClusterStatus(arg1) {
   return window['go']['weaviate']['Weaviate']['ClusterStatus'](arg1);
 }
@@ -30,14 +50,30 @@ export function Connect(arg1) {
   return window['go']['weaviate']['Weaviate']['Connect'](arg1);
 }
 
+export function CreateAlias(arg1, arg2, arg3) {
+  return window['go']['weaviate']['Weaviate']['CreateAlias'](arg1, arg2, arg3);
+}
+
 export function CreateBackup(arg1, arg2) {
   return window['go']['weaviate']['Weaviate']['CreateBackup'](arg1, arg2);
 }
 
+export function CreateCollection(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['CreateCollection'](arg1, arg2);
+}
+
+export function CreateObject(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['CreateObject'](arg1, arg2);
+}
+
 export function CreateRole(arg1, arg2) {
   return window['go']['weaviate']['Weaviate']['CreateRole'](arg1, arg2);
 }
 
+export function CreateTenants(arg1, arg2, arg3) {
+  return window['go']['weaviate']['Weaviate']['CreateTenants'](arg1, arg2, arg3);
+}
+
 export function CreateUser(arg1, arg2) {
   return window['go']['weaviate']['Weaviate']['CreateUser'](arg1, arg2);
 }
@@ -46,6 +82,10 @@ export function DeactivateApiKey(arg1, arg2, arg3) {
   return window['go']['weaviate']['Weaviate']['DeactivateApiKey'](arg1, arg2, arg3);
 }
 
+export function DeleteAlias(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['DeleteAlias'](arg1, arg2);
+}
+
 export function DeleteCollection(arg1, arg2) {
   return window['go']['weaviate']['Weaviate']['DeleteCollection'](arg1, arg2);
 }
@@ -54,18 +94,34 @@ export function DeleteObject(arg1, arg2, arg3, arg4) {
   return window['go']['weaviate']['Weaviate']['DeleteObject'](arg1, arg2, arg3, arg4);
 }
 
+export function DeleteReplicationOperation(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['DeleteReplicationOperation'](arg1, arg2);
+}
+
 export function DeleteRole(arg1, arg2) {
   return window['go']['weaviate']['Weaviate']['DeleteRole'](arg1, arg2);
 }
 
+export function DeleteTenants(arg1, arg2, arg3) {
+  return window['go']['weaviate']['Weaviate']['DeleteTenants'](arg1, arg2, arg3);
+}
+
 export function DeleteUser(arg1, arg2) {
   return window['go']['weaviate']['Weaviate']['DeleteUser'](arg1, arg2);
 }
 
+export function DiffSchemas(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['DiffSchemas'](arg1, arg2);
+}
+
 export function Disconnect(arg1) {
   return window['go']['weaviate']['Weaviate']['Disconnect'](arg1);
 }
 
+export function GetAliases(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['GetAliases'](arg1, arg2);
+}
+
 export function GetCollection(arg1, arg2) {
   return window['go']['weaviate']['Weaviate']['GetCollection'](arg1, arg2);
 }
@@ -82,20 +138,48 @@ export function GetModules(arg1) {
   return window['go']['weaviate']['Weaviate']['GetModules'](arg1);
 }
 
+export function GetObjectsFiltered(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['GetObjectsFiltered'](arg1, arg2);
+}
+
+export function GetObjectsPage(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['GetObjectsPage'](arg1, arg2);
+}
+
 export function GetObjectsPaginated(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['weaviate']['Weaviate']['GetObjectsPaginated'](arg1, arg2, arg3, arg4, arg5);
 }
 
+export function GetReplicationOperations(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['GetReplicationOperations'](arg1, arg2);
+}
+
 export function GetRestoreStatus(arg1, arg2, arg3) {
   return window['go']['weaviate']['Weaviate']['GetRestoreStatus'](arg1, arg2, arg3);
 }
 
+export function GetShardingState(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['GetShardingState'](arg1, arg2);
+}
+
+export function GetShards(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['GetShards'](arg1, arg2);
+}
+
 export function GetTenants(arg1, arg2) {
   return window['go']['weaviate']['Weaviate']['GetTenants'](arg1, arg2);
 }
 
-export function GetTotalObjects(arg1, arg2, arg3) {
-  return window['go']['weaviate']['Weaviate']['GetTotalObjects'](arg1, arg2, arg3);
+export function GetTenantsObjectCount(arg1, arg2, arg3) {
+  return window['go']['weaviate']['Weaviate']['GetTenantsObjectCount'](arg1, arg2, arg3);
+}
+
+export function GetTenantsPage(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['GetTenantsPage'](arg1, arg2);
+}
+
+export function GetTotalObjects(arg1, arg2, arg3, arg4) {
+  return window['go']['weaviate']['Weaviate']['GetTotalObjects'](arg1, arg2, arg3, arg4);
 }
 
 export function ListBackups(arg1, arg2) {
@@ -114,10 +198,22 @@ export function NodesStatus(arg1) {
   return window['go']['weaviate']['Weaviate']['NodesStatus'](arg1);
 }
 
+export function PatchObject(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['PatchObject'](arg1, arg2);
+}
+
 export function RemoveRolePermissions(arg1, arg2, arg3) {
   return window['go']['weaviate']['Weaviate']['RemoveRolePermissions'](arg1, arg2, arg3);
 }
 
+export function ReplaceObject(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['ReplaceObject'](arg1, arg2);
+}
+
+export function ReplicateShard(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['ReplicateShard'](arg1, arg2);
+}
+
 export function RestoreBackup(arg1, arg2) {
   return window['go']['weaviate']['Weaviate']['RestoreBackup'](arg1, arg2);
 }
@@ -130,14 +226,50 @@ export function RotateUserApiKey(arg1, arg2) {
   return window['go']['weaviate']['Weaviate']['RotateUserApiKey'](arg1, arg2);
 }
 
+export function RunGraphQL(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['RunGraphQL'](arg1, arg2);
+}
+
 export function Search(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['weaviate']['Weaviate']['Search'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
+export function SendRawRequest(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['SendRawRequest'](arg1, arg2);
+}
+
+export function SetRuntimeContext(arg1) {
+  return window['go']['weaviate']['Weaviate']['SetRuntimeContext'](arg1);
+}
+
+export function Shutdown() {
+  return window['go']['weaviate']['Weaviate']['Shutdown']();
+}
+
+export function SwapAlias(arg1, arg2) {
+  return window['go']['weaviate']['Weaviate']['SwapAlias'](arg1, arg2);
+}
+
+export function SyncSchemas(arg1, arg2, arg3) {
+  return window['go']['weaviate']['Weaviate']['SyncSchemas'](arg1, arg2, arg3);
+}
+
 export function TestConnection(arg1) {
   return window['go']['weaviate']['Weaviate']['TestConnection'](arg1);
 }
 
+export function UpdateCollection(arg1, arg2, arg3) {
+  return window['go']['weaviate']['Weaviate']['UpdateCollection'](arg1, arg2, arg3);
+}
+
+export function UpdateShardsStatus(arg1, arg2, arg3, arg4) {
+  return window['go']['weaviate']['Weaviate']['UpdateShardsStatus'](arg1, arg2, arg3, arg4);
+}
+
+export function UpdateTenantsStatus(arg1, arg2, arg3, arg4) {
+  return window['go']['weaviate']['Weaviate']['UpdateTenantsStatus'](arg1, arg2, arg3, arg4);
+}
+
 export function UsersEnabled(arg1) {
   return window['go']['weaviate']['Weaviate']['UsersEnabled'](arg1);
 }
@@ -0,0 +1,405 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"weaviate-desktop/internal/weaviate"
+
+	wails_runtime "github.com/wailsapp/wails/v2/pkg/runtime"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+const (
+	FormatJSONL   = "jsonl"
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+
+	defaultPageSize = 100
+)
+
+// this is for testing
+var eventsEmit = wails_runtime.EventsEmit
+
+type Client interface {
+	GetCollection(connectionID int64, collection string) (*models.Class, error)
+	GetTotalObjects(
+		connectionID int64,
+		collection, tenant string,
+		filter *weaviate.Filter,
+	) (int64, error)
+	GetObjectsPage(
+		connectionID int64,
+		input weaviate.ObjectsPageInput,
+	) (*weaviate.PaginatedObjectResponse, error)
+	GetObjectsFiltered(
+		connectionID int64,
+		input weaviate.FilteredObjectsInput,
+	) (*weaviate.PaginatedObjectResponse, error)
+}
+
+type Exporter struct {
+	client     Client
+	runtimeCtx context.Context
+
+	mu   sync.Mutex
+	jobs map[string]context.CancelFunc
+}
+
+func New(c Client) *Exporter {
+	return &Exporter{
+		client: c,
+		jobs:   map[string]context.CancelFunc{},
+	}
+}
+
+// SetRuntimeContext sets the wails runtime context so we can emit events
+// to the frontend
+func (e *Exporter) SetRuntimeContext(ctx context.Context) {
+	e.runtimeCtx = ctx
+}
+
+type ExportInput struct {
+	// ID identifies the export for progress events and cancellation
+	ID           string `json:"id"`
+	ConnectionID int64  `json:"connectionID"`
+	Collection   string `json:"collection"`
+	Tenant       string `json:"tenant,omitempty"`
+	// Filter exports only the matching objects
+	Filter *weaviate.Filter `json:"filter,omitempty"`
+	Path   string           `json:"path"`
+	// Format is one of jsonl, csv or parquet. Detected from the file extension when empty.
+	Format string `json:"format,omitempty"`
+	// IncludeVectors exports the vector and the named vectors of each object
+	IncludeVectors bool `json:"includeVectors,omitempty"`
+	PageSize       int  `json:"pageSize,omitempty"`
+	// Resume continues an interrupted export to the same path from its last checkpoint
+	Resume bool `json:"resume,omitempty"`
+}
+
+type ExportProgress struct {
+	ID       string  `json:"id"`
+	Percent  float64 `json:"percent"`
+	Exported int     `json:"exported"`
+	Total    int64   `json:"total"`
+}
+
+type ExportResult struct {
+	Exported int    `json:"exported"`
+	Path     string `json:"path"`
+	Resumed  bool   `json:"resumed"`
+	Canceled bool   `json:"canceled"`
+}
+
+// checkpoint is persisted next to the output after every page so an interrupted
+// export can pick up from the last cursor
+type checkpoint struct {
+	Collection     string           `json:"collection"`
+	Tenant         string           `json:"tenant"`
+	Filter         *weaviate.Filter `json:"filter,omitempty"`
+	Format         string           `json:"format"`
+	IncludeVectors bool             `json:"includeVectors"`
+	// Cursor is the id of the last exported object
+	Cursor   string `json:"cursor"`
+	Exported int    `json:"exported"`
+	// Offset is the size of the output when the checkpoint was taken
+	Offset int64 `json:"offset"`
+}
+
+func checkpointPath(path string) string {
+	return path + ".checkpoint"
+}
+
+// Export walks every object of the collection (or tenant) with the "after" cursor
+// and writes them to the output emitting "export-progress" events while running.
+// It blocks until the export finishes or it's canceled with CancelExport.
+//
+//nolint:gocognit // paging, checkpointing and progress reporting are easier to follow together
+func (e *Exporter) Export(input ExportInput) (*ExportResult, error) {
+	if input.ID == "" {
+		return nil, errors.New("export id is required")
+	}
+	if input.Format == "" {
+		format, err := detectFormat(input.Path)
+		if err != nil {
+			return nil, err
+		}
+		input.Format = format
+	}
+	if input.PageSize <= 0 {
+		input.PageSize = defaultPageSize
+	}
+
+	col, err := e.client.GetCollection(input.ConnectionID, input.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := e.client.GetTotalObjects(
+		input.ConnectionID,
+		input.Collection,
+		input.Tenant,
+		input.Filter,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cp := checkpoint{
+		Collection:     input.Collection,
+		Tenant:         input.Tenant,
+		Filter:         input.Filter,
+		Format:         input.Format,
+		IncludeVectors: input.IncludeVectors,
+	}
+	resumed := false
+	if input.Resume {
+		previous, err := loadCheckpoint(input.Path)
+		if err != nil {
+			return nil, err
+		}
+		if previous != nil {
+			if !sameExport(*previous, cp) {
+				return nil, fmt.Errorf(
+					"checkpoint of %s belongs to a different export, remove it or export to another path",
+					input.Path,
+				)
+			}
+			cp = *previous
+			resumed = true
+		}
+	}
+
+	// registered before opening the output, which truncates the output of a running
+	// export with the same id
+	ctx, err := e.registerJob(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer e.CancelExport(input.ID)
+
+	writer, err := newRecordWriter(input.Path, input.Format, cp.Offset, col)
+	if err != nil {
+		return nil, err
+	}
+	defer writer.Close()
+
+	for ctx.Err() == nil {
+		page, err := e.nextPage(input, cp)
+		if err != nil {
+			return nil, fmt.Errorf("failed exporting after cursor %q: %w", cp.Cursor, err)
+		}
+		if len(page.Objects) == 0 {
+			break
+		}
+
+		for _, o := range page.Objects {
+			if err := writer.Write(o); err != nil {
+				return nil, err
+			}
+		}
+
+		offset, err := writer.Flush()
+		if err != nil {
+			return nil, fmt.Errorf("failed writing export: %w", err)
+		}
+
+		cp.Cursor = page.Objects[len(page.Objects)-1].ID
+		cp.Exported += len(page.Objects)
+		cp.Offset = offset
+		if err := saveCheckpoint(input.Path, cp); err != nil {
+			return nil, err
+		}
+
+		progress := ExportProgress{
+			ID:       input.ID,
+			Exported: cp.Exported,
+			Total:    total,
+		}
+		if total > 0 {
+			progress.Percent = min(float64(cp.Exported)/float64(total)*100, 100)
+		}
+
+		slog.Debug("Export progress", "id", input.ID, "exported", cp.Exported)
+		e.emit("export-progress", progress)
+	}
+
+	result := &ExportResult{
+		Exported: cp.Exported,
+		Path:     input.Path,
+		Resumed:  resumed,
+		Canceled: ctx.Err() != nil,
+	}
+	if result.Canceled {
+		// keep the checkpoint around so the export can be resumed
+		return result, nil
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed closing export: %w", err)
+	}
+	if input.Format == FormatParquet {
+		if err := writeParquet(input.Path, col); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.Remove(checkpointPath(input.Path)); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed removing checkpoint: %w", err)
+	}
+
+	return result, nil
+}
+
+// CancelExport stops a running export. The output and its checkpoint are kept
+// so the export can be resumed later.
+func (e *Exporter) CancelExport(id string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if cancel, ok := e.jobs[id]; ok {
+		cancel()
+		delete(e.jobs, id)
+	}
+}
+
+// nextPage walks unfiltered exports with the "after" cursor, which isn't supported
+// together with filters. Filtered exports are sorted by id and continue with an
+// "id GreaterThan" filter instead, as weaviate limits offsets and they shift when
+// objects change between pages.
+func (e *Exporter) nextPage(input ExportInput, cp checkpoint) (*weaviate.PaginatedObjectResponse, error) {
+	if input.Filter == nil {
+		return e.client.GetObjectsPage(input.ConnectionID, weaviate.ObjectsPageInput{
+			Collection:    input.Collection,
+			Tenant:        input.Tenant,
+			Cursor:        cp.Cursor,
+			PageSize:      input.PageSize,
+			IncludeVector: input.IncludeVectors,
+		})
+	}
+
+	filter := input.Filter
+	if cp.Cursor != "" {
+		filter = &weaviate.Filter{Operator: "And", Operands: []*weaviate.Filter{
+			input.Filter,
+			{Operator: "GreaterThan", Path: []string{"id"}, Value: cp.Cursor},
+		}}
+	}
+
+	return e.client.GetObjectsFiltered(input.ConnectionID, weaviate.FilteredObjectsInput{
+		Collection:    input.Collection,
+		Tenant:        input.Tenant,
+		Filter:        filter,
+		Limit:         input.PageSize,
+		IncludeVector: input.IncludeVectors,
+		SortByID:      true,
+	})
+}
+
+func (e *Exporter) registerJob(id string) (context.Context, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, exists := e.jobs[id]; exists {
+		return nil, fmt.Errorf("export %s is already running", id)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.jobs[id] = cancel
+
+	return ctx, nil
+}
+
+func (e *Exporter) emit(event string, data ...any) {
+	if e.runtimeCtx == nil {
+		return
+	}
+
+	eventsEmit(e.runtimeCtx, event, data...)
+}
+
+func newRecordWriter(path, format string, offset int64, col *models.Class) (recordWriter, error) {
+	switch format {
+	case FormatJSONL:
+		return newJSONLWriter(path, offset)
+	case FormatCSV:
+		return newCSVWriter(path, offset, col)
+	case FormatParquet:
+		return newJSONLWriter(spoolPath(path), offset)
+	default:
+		return nil, fmt.Errorf("unsupported format %s", format)
+	}
+}
+
+// detectFormat returns the format of the file based on its extension
+func detectFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return FormatJSONL, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".parquet":
+		return FormatParquet, nil
+	default:
+		return "", fmt.Errorf(
+			"unable to detect format of %s, supported formats are jsonl, csv and parquet",
+			path,
+		)
+	}
+}
+
+// sameExport reports whether the checkpoint was taken by an export with the same input
+func sameExport(a, b checkpoint) bool {
+	if a.Collection != b.Collection || a.Tenant != b.Tenant ||
+		a.Format != b.Format || a.IncludeVectors != b.IncludeVectors {
+		return false
+	}
+
+	filterA, _ := json.Marshal(a.Filter)
+	filterB, _ := json.Marshal(b.Filter)
+
+	return bytes.Equal(filterA, filterB)
+}
+
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := os.ReadFile(checkpointPath(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed reading checkpoint: %w", err)
+	}
+
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed parsing checkpoint: %w", err)
+	}
+
+	return &cp, nil
+}
+
+func saveCheckpoint(path string, cp checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("failed marshalling checkpoint: %w", err)
+	}
+
+	// write and rename so a crash never leaves a half written checkpoint
+	tmp := checkpointPath(path) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed writing checkpoint: %w", err)
+	}
+
+	if err := os.Rename(tmp, checkpointPath(path)); err != nil {
+		return fmt.Errorf("failed writing checkpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,436 @@
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"weaviate-desktop/internal/weaviate"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+func TestExporter(t *testing.T) {
+	connectionID := int64(1)
+	collection := &models.Class{
+		Class: "TestCollection",
+		Properties: []*models.Property{
+			{Name: "title", DataType: []string{"text"}},
+			{Name: "views", DataType: []string{"int"}},
+			{Name: "tags", DataType: []string{"text[]"}},
+		},
+	}
+	pages := [][]weaviate.WeaviateObject{
+		{
+			{
+				ID: "00000000-0000-0000-0000-000000000001",
+				Properties: map[string]any{
+					"title": "first",
+					"views": float64(1),
+					"tags":  []any{"a", "b"},
+				},
+				Vector: []float32{0.1, 0.2},
+			},
+			{
+				ID:         "00000000-0000-0000-0000-000000000002",
+				Properties: map[string]any{"title": "second, with comma"},
+			},
+		},
+		{
+			{
+				ID:         "00000000-0000-0000-0000-000000000003",
+				Properties: map[string]any{"title": "third", "views": float64(3)},
+				Vectors:    map[string]any{"title_vector": []any{float64(1)}},
+			},
+		},
+	}
+
+	// mockPages sets up the client to return the pages based on the cursor
+	mockPages := func(t *testing.T, includeVector bool) *MockClient {
+		t.Helper()
+
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+		client.EXPECT().
+			GetTotalObjects(connectionID, "TestCollection", "", (*weaviate.Filter)(nil)).
+			Return(int64(3), nil)
+
+		cursor := ""
+		for _, page := range append(pages, []weaviate.WeaviateObject{}) {
+			client.EXPECT().
+				GetObjectsPage(connectionID, weaviate.ObjectsPageInput{
+					Collection:    "TestCollection",
+					Cursor:        cursor,
+					PageSize:      2,
+					IncludeVector: includeVector,
+				}).
+				Return(&weaviate.PaginatedObjectResponse{Objects: page}, nil).
+				Once()
+
+			if len(page) > 0 {
+				cursor = page[len(page)-1].ID
+			}
+		}
+
+		return client
+	}
+
+	t.Run("should export jsonl with vectors walking the cursor", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.jsonl")
+		exporter := New(mockPages(t, true))
+
+		result, err := exporter.Export(ExportInput{
+			ID:             "mock-export",
+			ConnectionID:   connectionID,
+			Collection:     "TestCollection",
+			Path:           path,
+			IncludeVectors: true,
+			PageSize:       2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &ExportResult{Exported: 3, Path: path}, result)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`{"id":"00000000-0000-0000-0000-000000000001","properties":{"tags":["a","b"],"title":"first","views":1},"vector":[0.1,0.2]}
+{"id":"00000000-0000-0000-0000-000000000002","properties":{"title":"second, with comma"}}
+{"id":"00000000-0000-0000-0000-000000000003","properties":{"title":"third","views":3},"vectors":{"title_vector":[1]}}
+`,
+			string(data),
+		)
+		assert.NoFileExists(t, checkpointPath(path))
+	})
+
+	t.Run("should export csv", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.csv")
+		exporter := New(mockPages(t, false))
+
+		result, err := exporter.Export(ExportInput{
+			ID:           "mock-export",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+			PageSize:     2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Exported)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			`id,title,views,tags,vector,vectors
+00000000-0000-0000-0000-000000000001,first,1,"[""a"",""b""]","[0.1,0.2]",
+00000000-0000-0000-0000-000000000002,"second, with comma",,,,
+00000000-0000-0000-0000-000000000003,third,3,,,"{""title_vector"":[1]}"
+`,
+			string(data),
+		)
+	})
+
+	t.Run("should export parquet", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.parquet")
+		exporter := New(mockPages(t, true))
+
+		result, err := exporter.Export(ExportInput{
+			ID:             "mock-export",
+			ConnectionID:   connectionID,
+			Collection:     "TestCollection",
+			Path:           path,
+			IncludeVectors: true,
+			PageSize:       2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Exported)
+		assert.NoFileExists(t, spoolPath(path))
+
+		type row struct {
+			ID     string    `parquet:"id"`
+			Title  *string   `parquet:"title,optional"`
+			Views  *int64    `parquet:"views,optional"`
+			Tags   []string  `parquet:"tags,optional,list"`
+			Vector []float32 `parquet:"vector,optional,list"`
+		}
+
+		rows, err := parquet.ReadFile[row](path)
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000001", rows[0].ID)
+		assert.Equal(t, "first", *rows[0].Title)
+		assert.Equal(t, int64(1), *rows[0].Views)
+		assert.Equal(t, []string{"a", "b"}, rows[0].Tags)
+		assert.Equal(t, []float32{0.1, 0.2}, rows[0].Vector)
+		assert.Nil(t, rows[1].Views)
+	})
+
+	t.Run("should resume interrupted export from the last cursor", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.jsonl")
+		firstLine := `{"id":"00000000-0000-0000-0000-000000000001","properties":{"title":"first"}}` + "\n"
+		// simulate an export interrupted after the first page where a partial
+		// line was written after the checkpoint was taken
+		require.NoError(t, os.WriteFile(path, []byte(firstLine+`{"id":"00000000`), 0o600))
+		require.NoError(t, saveCheckpoint(path, checkpoint{
+			Collection: "TestCollection",
+			Format:     FormatJSONL,
+			Cursor:     "00000000-0000-0000-0000-000000000001",
+			Exported:   1,
+			Offset:     int64(len(firstLine)),
+		}))
+
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+		client.EXPECT().
+			GetTotalObjects(connectionID, "TestCollection", "", (*weaviate.Filter)(nil)).
+			Return(int64(2), nil)
+		client.EXPECT().
+			GetObjectsPage(connectionID, weaviate.ObjectsPageInput{
+				Collection: "TestCollection",
+				Cursor:     "00000000-0000-0000-0000-000000000001",
+				PageSize:   100,
+			}).
+			Return(&weaviate.PaginatedObjectResponse{Objects: []weaviate.WeaviateObject{
+				{ID: pages[1][0].ID, Properties: pages[1][0].Properties},
+			}}, nil).
+			Once()
+		client.EXPECT().
+			GetObjectsPage(connectionID, weaviate.ObjectsPageInput{
+				Collection: "TestCollection",
+				Cursor:     "00000000-0000-0000-0000-000000000003",
+				PageSize:   100,
+			}).
+			Return(&weaviate.PaginatedObjectResponse{}, nil).
+			Once()
+
+		exporter := New(client)
+
+		result, err := exporter.Export(ExportInput{
+			ID:           "mock-export",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+			Resume:       true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &ExportResult{Exported: 2, Path: path, Resumed: true}, result)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			firstLine+`{"id":"00000000-0000-0000-0000-000000000003","properties":{"title":"third","views":3}}`+"\n",
+			string(data),
+		)
+	})
+
+	t.Run("should refuse to resume a checkpoint of a different export", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.jsonl")
+		require.NoError(t, saveCheckpoint(path, checkpoint{
+			Collection: "OtherCollection",
+			Format:     FormatJSONL,
+		}))
+
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+		client.EXPECT().
+			GetTotalObjects(connectionID, "TestCollection", "", (*weaviate.Filter)(nil)).
+			Return(int64(2), nil)
+
+		exporter := New(client)
+
+		result, err := exporter.Export(ExportInput{
+			ID:           "mock-export",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+			Resume:       true,
+		})
+
+		assert.Nil(t, result)
+		assert.EqualError(
+			t,
+			err,
+			"checkpoint of "+path+" belongs to a different export, remove it or export to another path",
+		)
+	})
+
+	t.Run("should export filtered objects past the offset limit walking by id", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.jsonl")
+		filter := &weaviate.Filter{Operator: "Equal", Path: []string{"title"}, Value: "match"}
+
+		// more objects than weaviate returns by offset (QUERY_MAXIMUM_RESULTS)
+		const maximumResults, total = 10_000, 10_050
+		objects := make([]weaviate.WeaviateObject, total)
+		for i := range objects {
+			objects[i] = weaviate.WeaviateObject{
+				ID:         fmt.Sprintf("00000000-0000-0000-0000-%012d", i+1),
+				Properties: map[string]any{"title": "match"},
+			}
+		}
+
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+		client.EXPECT().
+			GetTotalObjects(connectionID, "TestCollection", "", filter).
+			Return(int64(total), nil)
+		client.EXPECT().
+			GetObjectsFiltered(connectionID, mock.Anything).
+			RunAndReturn(func(_ int64, input weaviate.FilteredObjectsInput) (*weaviate.PaginatedObjectResponse, error) {
+				if input.Offset+input.Limit > maximumResults {
+					return nil, errors.New("query maximum results exceeded")
+				}
+				assert.True(t, input.SortByID)
+
+				// the first page is only filtered, the next ones continue after an id
+				after := ""
+				if input.Filter != filter {
+					require.Equal(t, "And", input.Filter.Operator)
+					require.Same(t, filter, input.Filter.Operands[0])
+					require.Equal(t, "GreaterThan", input.Filter.Operands[1].Operator)
+					require.Equal(t, []string{"id"}, input.Filter.Operands[1].Path)
+					after = input.Filter.Operands[1].Value.(string)
+				}
+
+				start := sort.Search(len(objects), func(i int) bool { return objects[i].ID > after })
+				end := min(start+input.Limit, len(objects))
+				return &weaviate.PaginatedObjectResponse{Objects: objects[start:end]}, nil
+			})
+
+		exporter := New(client)
+
+		result, err := exporter.Export(ExportInput{
+			ID:           "mock-export",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Filter:       filter,
+			Path:         path,
+			PageSize:     1000,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &ExportResult{Exported: total, Path: path}, result)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		require.Len(t, lines, total)
+		assert.Equal(t, `{"id":"00000000-0000-0000-0000-000000000001","properties":{"title":"match"}}`, lines[0])
+		assert.Equal(t, `{"id":"00000000-0000-0000-0000-000000010050","properties":{"title":"match"}}`, lines[total-1])
+	})
+
+	t.Run("should not touch the output of a running export", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.jsonl")
+		exporter := New(nil)
+
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+		client.EXPECT().
+			GetTotalObjects(connectionID, "TestCollection", "", (*weaviate.Filter)(nil)).
+			Return(int64(3), nil)
+		client.EXPECT().
+			GetObjectsPage(connectionID, weaviate.ObjectsPageInput{
+				Collection: "TestCollection",
+				PageSize:   2,
+			}).
+			Return(&weaviate.PaginatedObjectResponse{Objects: pages[0]}, nil).
+			Once()
+		client.EXPECT().
+			GetObjectsPage(connectionID, weaviate.ObjectsPageInput{
+				Collection: "TestCollection",
+				Cursor:     "00000000-0000-0000-0000-000000000002",
+				PageSize:   2,
+			}).
+			RunAndReturn(func(int64, weaviate.ObjectsPageInput) (*weaviate.PaginatedObjectResponse, error) {
+				_, err := exporter.Export(ExportInput{
+					ID:           "mock-export",
+					ConnectionID: connectionID,
+					Collection:   "TestCollection",
+					Path:         path,
+					PageSize:     2,
+				})
+				assert.EqualError(t, err, "export mock-export is already running")
+
+				return &weaviate.PaginatedObjectResponse{Objects: []weaviate.WeaviateObject{}}, nil
+			}).
+			Once()
+		exporter.client = client
+
+		result, err := exporter.Export(ExportInput{
+			ID:           "mock-export",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+			PageSize:     2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &ExportResult{Exported: 2, Path: path}, result)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, 2, strings.Count(string(data), "\n"))
+	})
+
+	t.Run("should keep checkpoint when canceled", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.jsonl")
+		exporter := New(nil)
+
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+		client.EXPECT().
+			GetTotalObjects(connectionID, "TestCollection", "", (*weaviate.Filter)(nil)).
+			Return(int64(3), nil)
+		client.EXPECT().
+			GetObjectsPage(connectionID, weaviate.ObjectsPageInput{
+				Collection: "TestCollection",
+				PageSize:   2,
+			}).
+			RunAndReturn(func(int64, weaviate.ObjectsPageInput) (*weaviate.PaginatedObjectResponse, error) {
+				exporter.CancelExport("mock-export")
+				return &weaviate.PaginatedObjectResponse{Objects: pages[0]}, nil
+			}).
+			Once()
+		exporter.client = client
+
+		result, err := exporter.Export(ExportInput{
+			ID:           "mock-export",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+			PageSize:     2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &ExportResult{Exported: 2, Path: path, Canceled: true}, result)
+
+		cp, err := loadCheckpoint(path)
+		require.NoError(t, err)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000002", cp.Cursor)
+		assert.Equal(t, 2, cp.Exported)
+	})
+}
@@ -0,0 +1,371 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"weaviate-desktop/internal/weaviate"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+// recordWriter appends exported objects to the output
+type recordWriter interface {
+	Write(o weaviate.WeaviateObject) error
+	// Flush persists buffered objects and returns the size of the output so far
+	Flush() (int64, error)
+	Close() error
+}
+
+// openOutput opens the file for writing. When offset is positive the file is
+// truncated to it and objects are appended, this drops anything written after
+// the last checkpoint.
+func openOutput(path string, offset int64) (*os.File, error) {
+	flags := os.O_CREATE | os.O_WRONLY
+	if offset <= 0 {
+		flags |= os.O_TRUNC
+	}
+
+	f, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening %s: %w", path, err)
+	}
+
+	if offset > 0 {
+		if err := f.Truncate(offset); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed truncating %s: %w", path, err)
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed seeking %s: %w", path, err)
+		}
+	}
+
+	return f, nil
+}
+
+type jsonlWriter struct {
+	file   *os.File
+	buffer *bufio.Writer
+}
+
+// jsonlObject is the weaviate object format, the same format the importer understands
+type jsonlObject struct {
+	ID         string         `json:"id"`
+	Tenant     string         `json:"tenant,omitempty"`
+	Properties any            `json:"properties"`
+	Vector     []float32      `json:"vector,omitempty"`
+	Vectors    map[string]any `json:"vectors,omitempty"`
+}
+
+func newJSONLWriter(path string, offset int64) (*jsonlWriter, error) {
+	f, err := openOutput(path, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonlWriter{file: f, buffer: bufio.NewWriter(f)}, nil
+}
+
+func (w *jsonlWriter) Write(o weaviate.WeaviateObject) error {
+	data, err := json.Marshal(jsonlObject{
+		ID:         o.ID,
+		Tenant:     o.Tenant,
+		Properties: o.Properties,
+		Vector:     o.Vector,
+		Vectors:    o.Vectors,
+	})
+	if err != nil {
+		return fmt.Errorf("failed marshalling object %s: %w", o.ID, err)
+	}
+
+	if _, err := w.buffer.Write(data); err != nil {
+		return err
+	}
+
+	return w.buffer.WriteByte('\n')
+}
+
+func (w *jsonlWriter) Flush() (int64, error) {
+	return flush(w.file, w.buffer.Flush)
+}
+
+func (w *jsonlWriter) Close() error {
+	return errors.Join(w.buffer.Flush(), w.file.Close())
+}
+
+type csvWriter struct {
+	file    *os.File
+	writer  *csv.Writer
+	columns []string
+}
+
+func newCSVWriter(path string, offset int64, col *models.Class) (*csvWriter, error) {
+	f, err := openOutput(path, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []string{"id"}
+	for _, p := range col.Properties {
+		columns = append(columns, p.Name)
+	}
+	columns = append(columns, "vector", "vectors")
+
+	w := &csvWriter{file: f, writer: csv.NewWriter(f), columns: columns}
+
+	// resumed exports already have the header
+	if offset <= 0 {
+		if err := w.writer.Write(columns); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed writing csv header: %w", err)
+		}
+	}
+
+	return w, nil
+}
+
+func (w *csvWriter) Write(o weaviate.WeaviateObject) error {
+	properties, _ := o.Properties.(map[string]any)
+	row := make([]string, len(w.columns))
+
+	for i, column := range w.columns {
+		var value any
+		switch {
+		case i == 0:
+			value = o.ID
+		case i == len(w.columns)-2:
+			if len(o.Vector) > 0 {
+				value = o.Vector
+			}
+		case i == len(w.columns)-1:
+			if len(o.Vectors) > 0 {
+				value = o.Vectors
+			}
+		default:
+			value = properties[column]
+		}
+
+		cell, err := csvCell(value)
+		if err != nil {
+			return fmt.Errorf("failed encoding %s of object %s: %w", column, o.ID, err)
+		}
+		row[i] = cell
+	}
+
+	return w.writer.Write(row)
+}
+
+func (w *csvWriter) Flush() (int64, error) {
+	return flush(w.file, func() error {
+		w.writer.Flush()
+		return w.writer.Error()
+	})
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return errors.Join(w.writer.Error(), w.file.Close())
+}
+
+// csvCell encodes scalar values as is and everything else as json
+func csvCell(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		data, err := json.Marshal(v)
+		return string(data), err
+	}
+}
+
+func flush(f *os.File, flushFn func() error) (int64, error) {
+	if err := flushFn(); err != nil {
+		return 0, err
+	}
+	if err := f.Sync(); err != nil {
+		return 0, err
+	}
+
+	return f.Seek(0, io.SeekCurrent)
+}
+
+// spoolPath is where parquet exports are staged as jsonl, parquet files can't be
+// appended to so they are only written once the export completes
+func spoolPath(path string) string {
+	return path + ".partial.jsonl"
+}
+
+// writeParquet converts the spooled jsonl objects to a parquet file
+func writeParquet(path string, col *models.Class) error {
+	spool, err := os.Open(spoolPath(path))
+	if err != nil {
+		return fmt.Errorf("failed opening spooled export: %w", err)
+	}
+	defer spool.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed creating %s: %w", path, err)
+	}
+	defer f.Close()
+
+	writer := parquet.NewGenericWriter[map[string]any](f, parquetSchema(col))
+
+	decoder := json.NewDecoder(bufio.NewReader(spool))
+	decoder.UseNumber()
+
+	for {
+		var o jsonlObject
+		if err := decoder.Decode(&o); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("failed reading spooled export: %w", err)
+		}
+
+		row, err := parquetRow(col, o)
+		if err != nil {
+			return err
+		}
+
+		if _, err := writer.Write([]map[string]any{row}); err != nil {
+			return fmt.Errorf("failed writing object %s: %w", o.ID, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed closing parquet writer: %w", err)
+	}
+	// the spool is only removed once the parquet file is completely written
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed closing %s: %w", path, err)
+	}
+	spool.Close()
+
+	return os.Remove(spoolPath(path))
+}
+
+func parquetSchema(col *models.Class) *parquet.Schema {
+	group := parquet.Group{
+		"id":      parquet.String(),
+		"vector":  parquet.Optional(parquet.List(parquet.Leaf(parquet.FloatType))),
+		"vectors": parquet.Optional(parquet.String()),
+	}
+
+	for _, p := range col.Properties {
+		group[p.Name] = parquet.Optional(parquetNode(p.DataType))
+	}
+
+	return parquet.NewSchema(col.Class, group)
+}
+
+func parquetNode(dataType []string) parquet.Node {
+	if len(dataType) == 0 {
+		return parquet.String()
+	}
+
+	elementType, isArray := strings.CutSuffix(dataType[0], "[]")
+
+	var node parquet.Node
+	switch elementType {
+	case "int":
+		node = parquet.Int(64)
+	case "number":
+		node = parquet.Leaf(parquet.DoubleType)
+	case "boolean":
+		node = parquet.Leaf(parquet.BooleanType)
+	default:
+		// text, dates, uuids and complex values (stored as json)
+		node = parquet.String()
+	}
+
+	if isArray && elementType != "object" {
+		return parquet.List(node)
+	}
+
+	return node
+}
+
+func parquetRow(col *models.Class, o jsonlObject) (map[string]any, error) {
+	row := map[string]any{"id": o.ID}
+
+	if len(o.Vector) > 0 {
+		vector := make([]any, len(o.Vector))
+		for i, v := range o.Vector {
+			vector[i] = v
+		}
+		row["vector"] = vector
+	}
+	if len(o.Vectors) > 0 {
+		data, err := json.Marshal(o.Vectors)
+		if err != nil {
+			return nil, fmt.Errorf("failed encoding vectors of object %s: %w", o.ID, err)
+		}
+		row["vectors"] = string(data)
+	}
+
+	properties, _ := o.Properties.(map[string]any)
+	for _, p := range col.Properties {
+		value, ok := properties[p.Name]
+		if !ok || value == nil {
+			continue
+		}
+
+		converted, err := parquetValue(p.DataType, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed converting %s of object %s: %w", p.Name, o.ID, err)
+		}
+		row[p.Name] = converted
+	}
+
+	return row, nil
+}
+
+func parquetValue(dataType []string, value any) (any, error) {
+	elementType, isArray := strings.CutSuffix(dataType[0], "[]")
+
+	if items, ok := value.([]any); ok && isArray && elementType != "object" {
+		converted := make([]any, len(items))
+		for i, item := range items {
+			v, err := parquetValue([]string{elementType}, item)
+			if err != nil {
+				return nil, err
+			}
+			converted[i] = v
+		}
+		return converted, nil
+	}
+
+	switch elementType {
+	case "int":
+		if n, ok := value.(json.Number); ok {
+			return n.Int64()
+		}
+	case "number":
+		if n, ok := value.(json.Number); ok {
+			return n.Float64()
+		}
+	case "boolean", "text", "string", "date", "uuid", "blob":
+		return value, nil
+	}
+
+	// complex values are stored as json
+	data, err := json.Marshal(value)
+	return string(data), err
+}
@@ -1,18 +1,306 @@
 package http_util
 
 import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"time"
+
+	"golang.org/x/net/proxy"
 )
 
+// TLSOptions configures the TLS connection to a server, the files are PEM encoded
+type TLSOptions struct {
+	// CAFile is a bundle of certificates trusted on top of the system certificates
+	CAFile string
+	// CertFile and KeyFile are the client certificate used for mutual TLS
+	CertFile string
+	KeyFile  string
+	// InsecureSkipVerify disables the verification of the server certificate.
+	// INSECURE: the connection can be intercepted, only use it for testing.
+	InsecureSkipVerify bool
+}
+
+// GetClient returns a client with its own transport so the shared
+// http.DefaultTransport and http.DefaultClient aren't modified
 func GetClient(timeout time.Duration) *http.Client {
-	cl := http.DefaultClient
-	tr := http.DefaultTransport.(*http.Transport)
-	cl.Transport = tr
+	return &http.Client{
+		Transport: newTransport(),
+		Timeout:   timeout,
+	}
+}
+
+// ClientOptions configures the transport of a client, the zero value is a plain client
+type ClientOptions struct {
+	TLS TLSOptions
+	// ProxyURL is a HTTP CONNECT (http, https) or SOCKS5 (socks5, socks5h) proxy
+	ProxyURL string
+	// DialContext replaces the dialer of the transport, e.g. to dial through an SSH tunnel
+	DialContext DialFunc
+}
+
+// GetClientWithOptions returns a client with its own transport using the options
+func GetClientWithOptions(timeout time.Duration, opts ClientOptions) (*http.Client, error) {
+	cfg, err := opts.TLS.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed configuring TLS: %w", err)
+	}
+
+	tr := newTransport()
+	tr.TLSClientConfig = cfg
+	if opts.DialContext != nil {
+		// the proxy of the environment doesn't apply to the connections of the dialer
+		tr.Proxy = nil
+		tr.DialContext = opts.DialContext
+	}
+
+	if opts.ProxyURL != "" {
+		if opts.DialContext != nil {
+			return nil, errors.New("a proxy can't be used together with a custom dialer")
+		}
+		if err := setProxy(tr, opts.ProxyURL); err != nil {
+			return nil, fmt.Errorf("failed configuring proxy: %w", err)
+		}
+	}
+
+	return &http.Client{
+		Transport: tr,
+		Timeout:   timeout,
+	}, nil
+}
+
+// setProxy routes the connections of the transport through the proxy, HTTP proxies
+// are handled by the transport, SOCKS5 proxies dial the connections themselves
+func setProxy(tr *http.Transport, proxyURL string) error {
+	u, err := parseProxyURL(proxyURL)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme == "http" || u.Scheme == "https" {
+		tr.Proxy = http.ProxyURL(u)
+		return nil
+	}
+
+	dial, err := socks5Dialer(u)
+	if err != nil {
+		return err
+	}
+	tr.Proxy = nil
+	tr.DialContext = dial
+
+	return nil
+}
+
+// DialFunc dials a connection to the address, like net.Dialer.DialContext
+type DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// Dialer returns the dialer of the options for the connections that aren't made by an
+// http.Transport, e.g. gRPC. HTTP proxies are dialed with a CONNECT request. It's
+// nil when the options don't need a dialer.
+func Dialer(opts ClientOptions) (DialFunc, error) {
+	if opts.ProxyURL == "" {
+		return opts.DialContext, nil
+	}
+	if opts.DialContext != nil {
+		return nil, errors.New("a proxy can't be used together with a custom dialer")
+	}
+
+	u, err := parseProxyURL(opts.ProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed configuring proxy: %w", err)
+	}
+	if u.Scheme == "http" || u.Scheme == "https" {
+		return connectDialer(u), nil
+	}
+
+	dial, err := socks5Dialer(u)
+	if err != nil {
+		return nil, fmt.Errorf("failed configuring proxy: %w", err)
+	}
+
+	return dial, nil
+}
+
+func parseProxyURL(proxyURL string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy url: %w", err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy url %s: host is required", u.Redacted())
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return u, nil
+	default:
+		return nil, fmt.Errorf(
+			"unsupported proxy scheme %q, expected http, https, socks5 or socks5h",
+			u.Scheme,
+		)
+	}
+}
+
+func socks5Dialer(u *url.URL) (DialFunc, error) {
+	dialer, err := proxy.FromURL(u, newDialer())
+	if err != nil {
+		return nil, err
+	}
+	contextDialer, ok := dialer.(proxy.ContextDialer)
+	if !ok {
+		return nil, errors.New("SOCKS5 proxy dialer doesn't support contexts")
+	}
+
+	return contextDialer.DialContext, nil
+}
+
+// connectDialer tunnels the connections through the HTTP proxy with CONNECT requests,
+// the connection to a https proxy is encrypted on its own
+func connectDialer(u *url.URL) DialFunc {
+	proxyAddr := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+		proxyAddr = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := newDialer().DialContext(ctx, network, proxyAddr)
+		if err != nil {
+			return nil, fmt.Errorf("failed connecting to proxy %s: %w", proxyAddr, err)
+		}
+
+		conn, err = connect(ctx, conn, u, addr)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		return conn, nil
+	}
+}
+
+// connect sends the CONNECT request for the address, the connection is returned once
+// the proxy accepted it
+func connect(ctx context.Context, conn net.Conn, u *url.URL, addr string) (net.Conn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)          //nolint:errcheck
+		defer conn.SetDeadline(time.Time{}) //nolint:errcheck
+	}
+
+	if u.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName: u.Hostname(),
+			MinVersion: tls.VersionTLS12,
+		})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return conn, fmt.Errorf("failed TLS handshake with proxy %s: %w", u.Host, err)
+		}
+		conn = tlsConn
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		credentials := base64.StdEncoding.EncodeToString(
+			[]byte(u.User.Username() + ":" + password),
+		)
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return conn, fmt.Errorf("failed sending CONNECT request to proxy %s: %w", u.Host, err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return conn, fmt.Errorf("failed reading CONNECT response of proxy %s: %w", u.Host, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return conn, fmt.Errorf("proxy %s refused connecting to %s: %s", u.Host, addr, resp.Status)
+	}
+
+	// the proxy may have sent the first bytes of the server along with its response
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+
+	return conn, nil
+}
+
+// bufferedConn reads the bytes buffered while reading the CONNECT response first
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+func newDialer() *net.Dialer {
+	return &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+}
+
+// Config builds the TLS configuration, the CA bundle and the client certificate are
+// read from disk
+func (o TLSOptions) Config() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.InsecureSkipVerify, //nolint:gosec // explicitly enabled per connection
+	}
+
+	if o.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		data, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading CA bundle: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in CA bundle %s", o.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if (o.CertFile == "") != (o.KeyFile == "") {
+		return nil, errors.New("both the client certificate and key are required")
+	}
+	if o.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
 
+func newTransport() *http.Transport {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
 	tr.MaxIdleConnsPerHost = 10
 	tr.MaxIdleConns = 100
-	cl.Timeout = timeout
 
-	return cl
+	return tr
 }
@@ -0,0 +1,311 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"weaviate-desktop/internal/weaviate"
+
+	wails_runtime "github.com/wailsapp/wails/v2/pkg/runtime"
+	"github.com/weaviate/weaviate/entities/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultBatchSize   = 100
+	defaultConcurrency = 2
+	// maxReportedErrors caps the object errors kept in the result, the rest are only counted
+	maxReportedErrors = 1000
+)
+
+// this is for testing
+var eventsEmit = wails_runtime.EventsEmit
+
+type Client interface {
+	GetCollection(connectionID int64, collection string) (*models.Class, error)
+	BatchCreateObjects(
+		connectionID int64,
+		col *models.Class,
+		objects []weaviate.ObjectInput,
+	) ([]weaviate.BatchObjectError, error)
+}
+
+type Importer struct {
+	client     Client
+	runtimeCtx context.Context
+
+	mu   sync.Mutex
+	jobs map[string]context.CancelFunc
+}
+
+func New(c Client) *Importer {
+	return &Importer{
+		client: c,
+		jobs:   map[string]context.CancelFunc{},
+	}
+}
+
+// SetRuntimeContext sets the wails runtime context so we can emit events
+// to the frontend
+func (i *Importer) SetRuntimeContext(ctx context.Context) {
+	i.runtimeCtx = ctx
+}
+
+type ImportInput struct {
+	// ID identifies the import for progress events and cancellation
+	ID           string `json:"id"`
+	ConnectionID int64  `json:"connectionID"`
+	Collection   string `json:"collection"`
+	Tenant       string `json:"tenant,omitempty"`
+	Path         string `json:"path"`
+	// Format is one of jsonl, csv or parquet. Detected from the file extension when empty.
+	Format string `json:"format,omitempty"`
+	// Mapping maps source columns to collection properties. When empty columns
+	// are matched to properties with the same name.
+	Mapping map[string]string `json:"mapping,omitempty"`
+	// IDColumn is the column holding the object uuid (default "id")
+	IDColumn string `json:"idColumn,omitempty"`
+	// VectorColumn is the column holding the object vector (default "vector")
+	VectorColumn string `json:"vectorColumn,omitempty"`
+	BatchSize    int    `json:"batchSize,omitempty"`
+	Concurrency  int    `json:"concurrency,omitempty"`
+}
+
+type ObjectError struct {
+	// Row is the position of the record in the source file, starting from 0
+	Row     int    `json:"row"`
+	ID      string `json:"id,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportProgress struct {
+	ID        string  `json:"id"`
+	Percent   float64 `json:"percent"`
+	Processed int     `json:"processed"`
+	Failed    int     `json:"failed"`
+}
+
+type ImportResult struct {
+	Imported       int           `json:"imported"`
+	Failed         int           `json:"failed"`
+	Errors         []ObjectError `json:"errors"`
+	IgnoredColumns []string      `json:"ignoredColumns"`
+	Canceled       bool          `json:"canceled"`
+}
+
+type batch struct {
+	// offset is the row of the first object of the batch
+	offset  int
+	objects []weaviate.ObjectInput
+	// rows maps the position of the object in the batch to the row in the source
+	rows []int
+	// progress of the reader when the batch was assembled
+	progress float64
+}
+
+// Import streams the file into the collection through the batch API emitting
+// "import-progress" events while running. It blocks until the import finishes
+// or it's canceled with CancelImport. When a batch fails the result is returned with
+// the error, counting the objects of the batches imported before.
+//
+//nolint:gocognit // reader, workers and progress reporting are easier to follow together
+func (i *Importer) Import(input ImportInput) (*ImportResult, error) {
+	if input.ID == "" {
+		return nil, errors.New("import id is required")
+	}
+
+	if input.Format == "" {
+		format, err := detectFormat(input.Path)
+		if err != nil {
+			return nil, err
+		}
+		input.Format = format
+	}
+	if input.BatchSize <= 0 {
+		input.BatchSize = defaultBatchSize
+	}
+	if input.Concurrency <= 0 {
+		input.Concurrency = defaultConcurrency
+	}
+
+	col, err := i.client.GetCollection(input.ConnectionID, input.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := newMapper(col, input)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := newRecordReader(input.Path, input.Format)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	ctx, err := i.registerJob(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer i.CancelImport(input.ID)
+
+	result := &ImportResult{Errors: []ObjectError{}}
+	var mu sync.Mutex
+	processed := 0
+	// percent only grows, the workers finish their batches in any order
+	percent := 0.0
+
+	addErrors := func(errs ...ObjectError) {
+		result.Failed += len(errs)
+		for _, e := range errs {
+			if len(result.Errors) < maxReportedErrors {
+				result.Errors = append(result.Errors, e)
+			}
+		}
+	}
+
+	batches := make(chan batch)
+	errg, errgCtx := errgroup.WithContext(ctx)
+
+	for range input.Concurrency {
+		errg.Go(func() error {
+			for b := range batches {
+				// don't send batches assembled before the import got canceled
+				if errgCtx.Err() != nil {
+					return nil
+				}
+
+				batchErrors, err := i.client.BatchCreateObjects(input.ConnectionID, col, b.objects)
+				if err != nil {
+					return fmt.Errorf(
+						"failed importing rows %d-%d: %w",
+						b.offset,
+						b.offset+len(b.objects)-1,
+						err,
+					)
+				}
+
+				mu.Lock()
+				for _, e := range batchErrors {
+					addErrors(ObjectError{Row: b.rows[e.Index], ID: e.ID, Message: e.Message})
+				}
+				result.Imported += len(b.objects) - len(batchErrors)
+				processed += len(b.objects)
+				percent = max(percent, b.progress)
+				progress := ImportProgress{
+					ID:        input.ID,
+					Percent:   percent,
+					Processed: processed,
+					Failed:    result.Failed,
+				}
+
+				// emitted while locked so the events arrive in order
+				slog.Debug("Import progress", "id", input.ID, "processed", progress.Processed)
+				i.emit("import-progress", progress)
+				mu.Unlock()
+			}
+
+			return nil
+		})
+	}
+
+	errg.Go(func() error {
+		defer close(batches)
+
+		current := batch{}
+		for row := 0; ; row++ {
+			record, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed reading row %d: %w", row, err)
+			}
+
+			object, err := m.toObject(record)
+			if err != nil {
+				mu.Lock()
+				addErrors(ObjectError{Row: row, ID: object.ID, Message: err.Error()})
+				processed++
+				mu.Unlock()
+				continue
+			}
+
+			if len(current.objects) == 0 {
+				current.offset = row
+			}
+			current.objects = append(current.objects, object)
+			current.rows = append(current.rows, row)
+
+			if len(current.objects) == input.BatchSize {
+				current.progress = reader.Progress()
+				select {
+				case batches <- current:
+				case <-errgCtx.Done():
+					return nil
+				}
+				current = batch{}
+			}
+		}
+
+		if len(current.objects) > 0 {
+			current.progress = reader.Progress()
+			select {
+			case batches <- current:
+			case <-errgCtx.Done():
+			}
+		}
+
+		return nil
+	})
+
+	err = errg.Wait()
+
+	for column := range m.ignored {
+		result.IgnoredColumns = append(result.IgnoredColumns, column)
+	}
+	slices.Sort(result.IgnoredColumns)
+	result.Canceled = ctx.Err() != nil
+
+	// the objects of the batches sent before the error are imported
+	return result, err
+}
+
+// CancelImport stops a running import. Batches already sent to weaviate are not rolled back.
+func (i *Importer) CancelImport(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if cancel, ok := i.jobs[id]; ok {
+		cancel()
+		delete(i.jobs, id)
+	}
+}
+
+func (i *Importer) registerJob(id string) (context.Context, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, exists := i.jobs[id]; exists {
+		return nil, fmt.Errorf("import %s is already running", id)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	i.jobs[id] = cancel
+
+	return ctx, nil
+}
+
+func (i *Importer) emit(event string, data ...any) {
+	if i.runtimeCtx == nil {
+		return
+	}
+
+	eventsEmit(i.runtimeCtx, event, data...)
+}
@@ -0,0 +1,399 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/weaviate"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+func TestImporter(t *testing.T) {
+	connectionID := int64(1)
+	collection := &models.Class{
+		Class: "TestCollection",
+		Properties: []*models.Property{
+			{Name: "title", DataType: []string{"text"}},
+			{Name: "views", DataType: []string{"int"}},
+			{Name: "rating", DataType: []string{"number"}},
+			{Name: "tags", DataType: []string{"text[]"}},
+		},
+	}
+
+	writeFile := func(t *testing.T, name, content string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		return path
+	}
+
+	// collectObjects returns a mock client recording all the objects it receives
+	collectObjects := func(t *testing.T) (*MockClient, *[]weaviate.ObjectInput) {
+		t.Helper()
+
+		var (
+			mu      sync.Mutex
+			objects []weaviate.ObjectInput
+		)
+
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+		client.EXPECT().
+			BatchCreateObjects(connectionID, collection, mock.Anything).
+			RunAndReturn(func(_ int64, _ *models.Class, batch []weaviate.ObjectInput) ([]weaviate.BatchObjectError, error) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				objects = append(objects, batch...)
+				return nil, nil
+			})
+
+		return client, &objects
+	}
+
+	t.Run("should import csv converting values and applying mapping", func(t *testing.T) {
+		path := writeFile(
+			t,
+			"objects.csv",
+			"id,name,views,rating,tags,embedding,extra\n"+
+				`0e4ec2d6-1d6e-4b0e-8a3f-6b0f1a0d5c11,first,10,4.5,"[""a"",""b""]","[0.1,0.2]",ignored`+"\n",
+		)
+
+		client, objects := collectObjects(t)
+		importer := New(client)
+
+		result, err := importer.Import(ImportInput{
+			ID:           "mock-import",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Tenant:       "mock-tenant",
+			Path:         path,
+			Mapping: map[string]string{
+				"name":   "title",
+				"views":  "views",
+				"rating": "rating",
+				"tags":   "tags",
+			},
+			VectorColumn: "embedding",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &ImportResult{
+			Imported:       1,
+			Errors:         []ObjectError{},
+			IgnoredColumns: []string{"extra"},
+		}, result)
+		assert.Equal(t, []weaviate.ObjectInput{
+			{
+				Collection: "TestCollection",
+				ID:         "0e4ec2d6-1d6e-4b0e-8a3f-6b0f1a0d5c11",
+				Tenant:     "mock-tenant",
+				Properties: map[string]any{
+					"title":  "first",
+					"views":  int64(10),
+					"rating": 4.5,
+					"tags":   []any{"a", "b"},
+				},
+				Vector: []float32{0.1, 0.2},
+			},
+		}, *objects)
+	})
+
+	t.Run("should import jsonl in weaviate object format", func(t *testing.T) {
+		path := writeFile(
+			t,
+			"objects.jsonl",
+			`{"id":"0e4ec2d6-1d6e-4b0e-8a3f-6b0f1a0d5c11","properties":{"title":"first","views":1},"vectors":{"title_vector":[1,2]}}`+"\n"+
+				`{"title":"second","rating":0.5}`+"\n",
+		)
+
+		client, objects := collectObjects(t)
+		importer := New(client)
+
+		result, err := importer.Import(ImportInput{
+			ID:           "mock-import",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+		assert.ElementsMatch(t, []weaviate.ObjectInput{
+			{
+				Collection: "TestCollection",
+				ID:         "0e4ec2d6-1d6e-4b0e-8a3f-6b0f1a0d5c11",
+				Properties: map[string]any{"title": "first", "views": int64(1)},
+				Vectors:    map[string][]float32{"title_vector": {1, 2}},
+			},
+			{
+				Collection: "TestCollection",
+				Properties: map[string]any{"title": "second", "rating": 0.5},
+			},
+		}, *objects)
+	})
+
+	t.Run("should skip missing values", func(t *testing.T) {
+		path := writeFile(t, "objects.jsonl", `{"id":null,"title":"first","views":null,"vector":null}`+"\n")
+
+		client, objects := collectObjects(t)
+		importer := New(client)
+
+		result, err := importer.Import(ImportInput{
+			ID:           "mock-import",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+			VectorColumn: "vector",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &ImportResult{Imported: 1, Errors: []ObjectError{}}, result)
+		assert.Equal(t, []weaviate.ObjectInput{
+			{
+				Collection: "TestCollection",
+				Properties: map[string]any{"title": "first"},
+			},
+		}, *objects)
+	})
+
+	t.Run("should import parquet", func(t *testing.T) {
+		type row struct {
+			Title  string    `parquet:"title"`
+			Views  int64     `parquet:"views"`
+			Vector []float32 `parquet:"vector,list"`
+		}
+
+		path := filepath.Join(t.TempDir(), "objects.parquet")
+		require.NoError(t, parquet.WriteFile(path, []row{
+			{Title: "first", Views: 1, Vector: []float32{0.5}},
+			{Title: "second", Views: 2, Vector: []float32{1}},
+		}))
+
+		client, objects := collectObjects(t)
+		importer := New(client)
+
+		result, err := importer.Import(ImportInput{
+			ID:           "mock-import",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+			BatchSize:    1,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+		assert.ElementsMatch(t, []weaviate.ObjectInput{
+			{
+				Collection: "TestCollection",
+				Properties: map[string]any{"title": "first", "views": int64(1)},
+				Vector:     []float32{0.5},
+			},
+			{
+				Collection: "TestCollection",
+				Properties: map[string]any{"title": "second", "views": int64(2)},
+				Vector:     []float32{1},
+			},
+		}, *objects)
+	})
+
+	t.Run("should report conversion and batch errors with the source row", func(t *testing.T) {
+		path := writeFile(t, "objects.csv", "title,views\nfirst,1\nsecond,two\nthird,3\n")
+
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+		client.EXPECT().
+			BatchCreateObjects(connectionID, collection, mock.Anything).
+			Return([]weaviate.BatchObjectError{{Index: 1, Message: "mock-error"}}, nil)
+
+		importer := New(client)
+
+		result, err := importer.Import(ImportInput{
+			ID:           "mock-import",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Imported)
+		assert.Equal(t, 2, result.Failed)
+		assert.ElementsMatch(t, []ObjectError{
+			{Row: 1, Message: `column "views": expected an integer: strconv.ParseInt: parsing "two": invalid syntax`},
+			{Row: 2, Message: "mock-error"},
+		}, result.Errors)
+	})
+
+	t.Run("should return the imported objects with the error of a batch", func(t *testing.T) {
+		path := writeFile(t, "objects.csv", "title\nfirst\nsecond\nthird\n")
+
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+		client.EXPECT().
+			BatchCreateObjects(connectionID, collection, mock.Anything).
+			Return(nil, nil).
+			Once()
+		client.EXPECT().
+			BatchCreateObjects(connectionID, collection, mock.Anything).
+			Return(nil, errors.New("mock error")).
+			Once()
+
+		importer := New(client)
+
+		result, err := importer.Import(ImportInput{
+			ID:           "mock-import",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+			BatchSize:    1,
+			Concurrency:  1,
+		})
+
+		assert.EqualError(t, err, "failed importing rows 1-1: mock error")
+		require.NotNil(t, result)
+		assert.Equal(t, 1, result.Imported)
+	})
+
+	t.Run("should emit growing progress with concurrent batches", func(t *testing.T) {
+		rows := 20
+		// long rows so the progress of the reader grows with the batches
+		content := "title\n" + strings.Repeat(strings.Repeat("a", 5000)+"\n", rows)
+		path := writeFile(t, "objects.csv", content)
+
+		var mu sync.Mutex
+		events := []ImportProgress{}
+		emit := eventsEmit
+		eventsEmit = func(_ context.Context, event string, data ...any) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			assert.Equal(t, "import-progress", event)
+			events = append(events, data[0].(ImportProgress))
+		}
+		t.Cleanup(func() { eventsEmit = emit })
+
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+		sent := atomic.Int64{}
+		client.EXPECT().
+			BatchCreateObjects(connectionID, collection, mock.Anything).
+			RunAndReturn(func(int64, *models.Class, []weaviate.ObjectInput) ([]weaviate.BatchObjectError, error) {
+				// the earlier batches finish last
+				time.Sleep(time.Duration(rows-int(sent.Add(1))) * time.Millisecond)
+				return nil, nil
+			})
+
+		importer := New(client)
+		importer.SetRuntimeContext(context.Background())
+
+		_, err := importer.Import(ImportInput{
+			ID:           "mock-import",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+			BatchSize:    1,
+			Concurrency:  4,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, events, rows)
+		for i := 1; i < len(events); i++ {
+			assert.GreaterOrEqual(t, events[i].Percent, events[i-1].Percent)
+			assert.Greater(t, events[i].Processed, events[i-1].Processed)
+		}
+		assert.Equal(t, 100.0, events[len(events)-1].Percent)
+	})
+
+	t.Run("should stop importing when canceled", func(t *testing.T) {
+		path := writeFile(t, "objects.csv", "title\na\nb\nc\nd\ne\n")
+
+		importer := New(nil)
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+		client.EXPECT().
+			BatchCreateObjects(connectionID, collection, mock.Anything).
+			RunAndReturn(func(int64, *models.Class, []weaviate.ObjectInput) ([]weaviate.BatchObjectError, error) {
+				importer.CancelImport("mock-import")
+				return nil, nil
+			}).
+			Once()
+		importer.client = client
+
+		result, err := importer.Import(ImportInput{
+			ID:           "mock-import",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         path,
+			BatchSize:    1,
+			Concurrency:  1,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.Canceled)
+		assert.Equal(t, 1, result.Imported)
+	})
+
+	t.Run("should return error for unknown format", func(t *testing.T) {
+		importer := New(NewMockClient(t))
+
+		result, err := importer.Import(ImportInput{
+			ID:   "mock-import",
+			Path: "objects.xml",
+		})
+
+		assert.Nil(t, result)
+		assert.EqualError(
+			t,
+			err,
+			"unable to detect format of objects.xml, supported formats are jsonl, csv and parquet",
+		)
+	})
+
+	t.Run("should return error if mapping targets unknown property", func(t *testing.T) {
+		client := NewMockClient(t)
+		client.EXPECT().
+			GetCollection(connectionID, "TestCollection").
+			Return(collection, nil)
+
+		importer := New(client)
+
+		result, err := importer.Import(ImportInput{
+			ID:           "mock-import",
+			ConnectionID: connectionID,
+			Collection:   "TestCollection",
+			Path:         "objects.csv",
+			Mapping:      map[string]string{"name": "name"},
+		})
+
+		assert.Nil(t, result)
+		assert.EqualError(
+			t,
+			err,
+			`column "name" is mapped to property "name" which does not exist in TestCollection`,
+		)
+	})
+}
@@ -0,0 +1,315 @@
+package importer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"weaviate-desktop/internal/weaviate"
+
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+// mapper converts the records of a source file to weaviate objects based on
+// the collection schema
+type mapper struct {
+	collection   string
+	tenant       string
+	properties   map[string]*models.Property
+	mapping      map[string]string
+	idColumn     string
+	vectorColumn string
+	// vectorsColumn holds named vectors as an object keyed by the vector name
+	vectorsColumn string
+	// ignored keeps track of the columns that didn't match any property
+	ignored map[string]struct{}
+}
+
+func newMapper(col *models.Class, input ImportInput) (*mapper, error) {
+	properties := make(map[string]*models.Property, len(col.Properties))
+	for _, p := range col.Properties {
+		properties[p.Name] = p
+	}
+
+	for column, property := range input.Mapping {
+		if _, ok := properties[property]; !ok {
+			return nil, fmt.Errorf(
+				"column %q is mapped to property %q which does not exist in %s",
+				column,
+				property,
+				col.Class,
+			)
+		}
+	}
+
+	m := &mapper{
+		collection:    col.Class,
+		tenant:        input.Tenant,
+		properties:    properties,
+		mapping:       input.Mapping,
+		idColumn:      input.IDColumn,
+		vectorColumn:  input.VectorColumn,
+		vectorsColumn: "vectors",
+		ignored:       map[string]struct{}{},
+	}
+
+	if m.idColumn == "" {
+		m.idColumn = "id"
+	}
+	if m.vectorColumn == "" {
+		m.vectorColumn = "vector"
+	}
+
+	return m, nil
+}
+
+// propertyFor returns the property a column is mapped to
+func (m *mapper) propertyFor(column string) (*models.Property, bool) {
+	if len(m.mapping) > 0 {
+		name, ok := m.mapping[column]
+		if !ok {
+			return nil, false
+		}
+		return m.properties[name], true
+	}
+
+	p, ok := m.properties[column]
+	return p, ok
+}
+
+func (m *mapper) toObject(record map[string]any) (weaviate.ObjectInput, error) {
+	record = m.flattenObjectRecord(record)
+
+	object := weaviate.ObjectInput{
+		Collection: m.collection,
+		Tenant:     m.tenant,
+		Properties: make(map[string]any, len(record)),
+	}
+
+	for column, value := range record {
+		// missing values, e.g. null in JSON or optional parquet columns
+		if value == nil {
+			continue
+		}
+
+		switch column {
+		case m.idColumn:
+			id, ok := value.(string)
+			if !ok {
+				return object, fmt.Errorf("column %q expects a uuid string", column)
+			}
+			object.ID = id
+			continue
+		case m.vectorColumn:
+			vector, err := toVector(value)
+			if err != nil {
+				return object, fmt.Errorf("column %q: %w", column, err)
+			}
+			object.Vector = vector
+			continue
+		case m.vectorsColumn:
+			vectors, err := toNamedVectors(value)
+			if err != nil {
+				return object, fmt.Errorf("column %q: %w", column, err)
+			}
+			object.Vectors = vectors
+			continue
+		}
+
+		prop, ok := m.propertyFor(column)
+		if !ok {
+			m.ignored[column] = struct{}{}
+			continue
+		}
+
+		converted, err := convertValue(prop.DataType, value)
+		if err != nil {
+			return object, fmt.Errorf("column %q: %w", column, err)
+		}
+		object.Properties[prop.Name] = converted
+	}
+
+	return object, nil
+}
+
+// flattenObjectRecord supports records in the weaviate object format
+// {"id": "...", "properties": {...}, "vector": [...]} as produced by the exporter
+func (m *mapper) flattenObjectRecord(record map[string]any) map[string]any {
+	if _, isProperty := m.properties["properties"]; isProperty {
+		return record
+	}
+
+	properties, ok := record["properties"].(map[string]any)
+	if !ok {
+		return record
+	}
+
+	flat := make(map[string]any, len(properties)+3)
+	for k, v := range properties {
+		flat[k] = v
+	}
+	for _, column := range []string{m.idColumn, m.vectorColumn, m.vectorsColumn} {
+		if v, ok := record[column]; ok {
+			flat[column] = v
+		}
+	}
+
+	return flat
+}
+
+//nolint:gocognit // flat switch over weaviate data types
+func convertValue(dataType []string, value any) (any, error) {
+	value = normalize(value)
+	if len(dataType) == 0 || value == nil {
+		return value, nil
+	}
+
+	t := dataType[0]
+	s, isString := value.(string)
+
+	// cross references and nested values are expected to be json when coming from a csv
+	if isString && (strings.HasSuffix(t, "[]") || t == "object" || t == "geoCoordinates" ||
+		t == "phoneNumber" || (t[0] >= 'A' && t[0] <= 'Z')) {
+		var decoded any
+		decoder := json.NewDecoder(strings.NewReader(s))
+		decoder.UseNumber()
+		if err := decoder.Decode(&decoded); err != nil {
+			return nil, fmt.Errorf("expected json value for %s: %w", t, err)
+		}
+		value = normalize(decoded)
+		isString = false
+	}
+
+	if elementType, isArray := strings.CutSuffix(t, "[]"); isArray {
+		items, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an array for %s", t)
+		}
+		for i, item := range items {
+			converted, err := convertValue([]string{elementType}, item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = converted
+		}
+		return items, nil
+	}
+
+	if !isString {
+		return value, nil
+	}
+
+	switch t {
+	case "int":
+		i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer: %w", err)
+		}
+		return i, nil
+	case "number":
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number: %w", err)
+		}
+		return f, nil
+	case "boolean":
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("expected a boolean: %w", err)
+		}
+		return b, nil
+	default:
+		return s, nil
+	}
+}
+
+// normalize converts values decoded from the different formats to the types
+// expected by weaviate
+func normalize(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []any:
+		for i := range v {
+			v[i] = normalize(v[i])
+		}
+		return v
+	case map[string]any:
+		for k := range v {
+			v[k] = normalize(v[k])
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func toVector(value any) ([]float32, error) {
+	if s, ok := value.(string); ok {
+		var vector []float32
+		if err := json.Unmarshal([]byte(s), &vector); err != nil {
+			return nil, fmt.Errorf("expected a json array of floats: %w", err)
+		}
+		return vector, nil
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an array of floats, got %T", value)
+	}
+
+	vector := make([]float32, len(items))
+	for i, item := range items {
+		switch f := normalize(item).(type) {
+		case float64:
+			vector[i] = float32(f)
+		case float32:
+			vector[i] = f
+		case int64:
+			vector[i] = float32(f)
+		case int32:
+			vector[i] = float32(f)
+		default:
+			return nil, fmt.Errorf("expected an array of floats, got %T at index %d", item, i)
+		}
+	}
+
+	return vector, nil
+}
+
+func toNamedVectors(value any) (map[string][]float32, error) {
+	if s, ok := value.(string); ok {
+		var decoded map[string]any
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			return nil, fmt.Errorf("expected a json object of named vectors: %w", err)
+		}
+		value = decoded
+	}
+
+	named, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an object of named vectors, got %T", value)
+	}
+
+	vectors := make(map[string][]float32, len(named))
+	for name, v := range named {
+		vector, err := toVector(v)
+		if err != nil {
+			return nil, fmt.Errorf("named vector %q: %w", name, err)
+		}
+		vectors[name] = vector
+	}
+
+	return vectors, nil
+}
@@ -0,0 +1,233 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+const (
+	FormatJSONL   = "jsonl"
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+// recordReader streams records out of a source file
+type recordReader interface {
+	// Next returns the next record or io.EOF when the source is exhausted
+	Next() (map[string]any, error)
+	// Progress returns the percentage of the source read so far
+	Progress() float64
+	Close() error
+}
+
+// detectFormat returns the format of the file based on its extension
+func detectFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return FormatJSONL, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".parquet":
+		return FormatParquet, nil
+	default:
+		return "", fmt.Errorf(
+			"unable to detect format of %s, supported formats are jsonl, csv and parquet",
+			path,
+		)
+	}
+}
+
+func newRecordReader(path, format string) (recordReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening %s: %w", path, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed reading file info %s: %w", path, err)
+	}
+
+	var r recordReader
+	switch format {
+	case FormatJSONL:
+		r = newJSONLReader(f, info.Size())
+	case FormatCSV:
+		r, err = newCSVReader(f, info.Size())
+	case FormatParquet:
+		r, err = newParquetReader(f, info.Size())
+	default:
+		err = fmt.Errorf("unsupported format %s", format)
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// countingReader keeps track of the bytes read from the underlying reader
+type countingReader struct {
+	io.Reader
+	read int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.read += int64(n)
+	return n, err
+}
+
+type jsonlReader struct {
+	file    *os.File
+	decoder *json.Decoder
+	size    int64
+}
+
+func newJSONLReader(f *os.File, size int64) *jsonlReader {
+	decoder := json.NewDecoder(bufio.NewReader(f))
+	// keep integers intact, they are converted based on the property data type
+	decoder.UseNumber()
+
+	return &jsonlReader{
+		file:    f,
+		decoder: decoder,
+		size:    size,
+	}
+}
+
+func (r *jsonlReader) Next() (map[string]any, error) {
+	var record map[string]any
+	if err := r.decoder.Decode(&record); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed decoding json line: %w", err)
+	}
+
+	return record, nil
+}
+
+func (r *jsonlReader) Progress() float64 {
+	return percentage(r.decoder.InputOffset(), r.size)
+}
+
+func (r *jsonlReader) Close() error {
+	return r.file.Close()
+}
+
+type csvReader struct {
+	file    *os.File
+	counter *countingReader
+	reader  *csv.Reader
+	header  []string
+	size    int64
+}
+
+func newCSVReader(f *os.File, size int64) (*csvReader, error) {
+	counter := &countingReader{Reader: f}
+	reader := csv.NewReader(counter)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed reading csv header: %w", err)
+	}
+
+	return &csvReader{
+		file:    f,
+		counter: counter,
+		reader:  reader,
+		// copy since the reader reuses the record slice
+		header: append([]string(nil), header...),
+		size:   size,
+	}, nil
+}
+
+func (r *csvReader) Next() (map[string]any, error) {
+	row, err := r.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed reading csv row: %w", err)
+	}
+
+	record := make(map[string]any, len(r.header))
+	for i, column := range r.header {
+		// empty cells are treated as missing values
+		if i < len(row) && row[i] != "" {
+			record[column] = row[i]
+		}
+	}
+
+	return record, nil
+}
+
+func (r *csvReader) Progress() float64 {
+	return percentage(r.counter.read, r.size)
+}
+
+func (r *csvReader) Close() error {
+	return r.file.Close()
+}
+
+type parquetReader struct {
+	file   *os.File
+	reader *parquet.Reader
+	total  int64
+	read   int64
+}
+
+func newParquetReader(f *os.File, size int64) (*parquetReader, error) {
+	pf, err := parquet.OpenFile(f, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening parquet file: %w", err)
+	}
+
+	return &parquetReader{
+		file:   f,
+		reader: parquet.NewReader(pf),
+		total:  pf.NumRows(),
+	}, nil
+}
+
+func (r *parquetReader) Next() (map[string]any, error) {
+	record := map[string]any{}
+	if err := r.reader.Read(&record); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed reading parquet row: %w", err)
+	}
+	r.read++
+
+	return record, nil
+}
+
+func (r *parquetReader) Progress() float64 {
+	return percentage(r.read, r.total)
+}
+
+func (r *parquetReader) Close() error {
+	return errors.Join(r.reader.Close(), r.file.Close())
+}
+
+func percentage(done, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+
+	return float64(done) / float64(total) * 100
+}
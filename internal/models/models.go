@@ -1,5 +1,17 @@
 package models
 
+// the authentication methods of a connection, an empty auth type authenticates with
+// the api key when it's set
+const (
+	AuthAPIKey = "api_key"
+	// AuthPassword is the OIDC resource owner password flow
+	AuthPassword = "password"
+	// AuthClientCredentials is the OIDC client credentials flow
+	AuthClientCredentials = "client_credentials"
+	// AuthBearerToken authenticates with an OIDC access token, refreshed with the refresh token when set
+	AuthBearerToken = "bearer_token"
+)
+
 type Connection struct {
 	ID       int64   `db:"id"       json:"id"`
 	URI      string  `db:"uri"      json:"uri"`
@@ -7,4 +19,59 @@ type Connection struct {
 	Favorite bool    `db:"favorite" json:"favorite"`
 	ApiKey   *string `db:"api_key"  json:"api_key"`
 	Color    string  `db:"color"    json:"color"`
+
+	AuthType     string  `db:"auth_type"     json:"auth_type,omitempty"`
+	Username     *string `db:"username"      json:"username"`
+	Password     *string `db:"password"      json:"password"`
+	ClientSecret *string `db:"client_secret" json:"client_secret"`
+	// Scopes are the OIDC scopes separated by spaces
+	Scopes       *string `db:"scopes"        json:"scopes"`
+	AccessToken  *string `db:"access_token"  json:"access_token"`
+	RefreshToken *string `db:"refresh_token" json:"refresh_token"`
+
+	// CACertFile, ClientCertFile and ClientKeyFile are paths to PEM encoded files
+	CACertFile     *string `db:"ca_cert_file"     json:"ca_cert_file"`
+	ClientCertFile *string `db:"client_cert_file" json:"client_cert_file"`
+	ClientKeyFile  *string `db:"client_key_file"  json:"client_key_file"`
+	// InsecureSkipVerify disables the verification of the server certificate, INSECURE
+	InsecureSkipVerify bool `db:"insecure_skip_verify" json:"insecure_skip_verify"`
+
+	// ProxyURL is a HTTP CONNECT (http, https) or SOCKS5 (socks5, socks5h) proxy,
+	// it may contain the proxy credentials
+	ProxyURL *string `db:"proxy_url" json:"proxy_url"`
+	// SSHHost is the host[:port] of the bastion weaviate is reached through
+	SSHHost *string `db:"ssh_host" json:"ssh_host"`
+	SSHUser *string `db:"ssh_user" json:"ssh_user"`
+	// SSHKeyFile is the private key of the SSH user, the SSH agent is used when empty
+	SSHKeyFile *string `db:"ssh_key_file" json:"ssh_key_file"`
+
+	// GRPCHost and GRPCPort enable the gRPC API for search, object fetching and batching,
+	// the host defaults to the host of the URI and the port to 50051
+	GRPCHost *string `db:"grpc_host" json:"grpc_host"`
+	GRPCPort *int64  `db:"grpc_port" json:"grpc_port"`
+
+	// Headers are sent with every request, e.g. the api keys of the vectorizer modules
+	Headers []ConnectionHeader `db:"-" json:"headers,omitempty"`
+}
+
+type ConnectionHeader struct {
+	Name  string `db:"name"  json:"name"`
+	Value string `db:"value" json:"value"`
+}
+
+// RequestHistory is a raw REST request sent to the connection, the response body
+// isn't kept
+type RequestHistory struct {
+	ID           int64  `db:"id"            json:"id"`
+	ConnectionID int64  `db:"connection_id" json:"connection_id"`
+	Method       string `db:"method"        json:"method"`
+	// Path is the path under /v1 with the encoded query, e.g. /schema?consistency=true
+	Path string `db:"path" json:"path"`
+	// Body is stored encrypted, it may carry credentials
+	Body string `db:"body" json:"body"`
+	// Status is 0 when the request failed without a response, Error has the reason
+	Status     int    `db:"status"      json:"status"`
+	Error      string `db:"error"       json:"error"`
+	DurationMs int64  `db:"duration_ms" json:"duration_ms"`
+	CreatedAt  int64  `db:"created_at"  json:"created_at"`
 }
@@ -0,0 +1,17 @@
+-- migrate:up
+ALTER TABLE "connections" ADD COLUMN "auth_type" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "connections" ADD COLUMN "username" TEXT;
+ALTER TABLE "connections" ADD COLUMN "password" TEXT;
+ALTER TABLE "connections" ADD COLUMN "client_secret" TEXT;
+ALTER TABLE "connections" ADD COLUMN "scopes" TEXT;
+ALTER TABLE "connections" ADD COLUMN "access_token" TEXT;
+ALTER TABLE "connections" ADD COLUMN "refresh_token" TEXT;
+
+-- migrate:down
+ALTER TABLE "connections" DROP COLUMN "refresh_token";
+ALTER TABLE "connections" DROP COLUMN "access_token";
+ALTER TABLE "connections" DROP COLUMN "scopes";
+ALTER TABLE "connections" DROP COLUMN "client_secret";
+ALTER TABLE "connections" DROP COLUMN "password";
+ALTER TABLE "connections" DROP COLUMN "username";
+ALTER TABLE "connections" DROP COLUMN "auth_type";
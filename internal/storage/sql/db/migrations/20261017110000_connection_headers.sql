@@ -0,0 +1,13 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS "connection_headers" (
+	"id"	INTEGER,
+	"connection_id"	INTEGER NOT NULL,
+	"name"	TEXT NOT NULL,
+	"value"	TEXT NOT NULL,
+	PRIMARY KEY("id" AUTOINCREMENT),
+	FOREIGN KEY("connection_id") REFERENCES "connections"("id") ON DELETE CASCADE,
+	UNIQUE("connection_id", "name")
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS "connection_headers";
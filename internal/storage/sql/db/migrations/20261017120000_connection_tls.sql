@@ -0,0 +1,11 @@
+-- migrate:up
+ALTER TABLE "connections" ADD COLUMN "ca_cert_file" TEXT;
+ALTER TABLE "connections" ADD COLUMN "client_cert_file" TEXT;
+ALTER TABLE "connections" ADD COLUMN "client_key_file" TEXT;
+ALTER TABLE "connections" ADD COLUMN "insecure_skip_verify" BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- migrate:down
+ALTER TABLE "connections" DROP COLUMN "insecure_skip_verify";
+ALTER TABLE "connections" DROP COLUMN "client_key_file";
+ALTER TABLE "connections" DROP COLUMN "client_cert_file";
+ALTER TABLE "connections" DROP COLUMN "ca_cert_file";
@@ -0,0 +1,11 @@
+-- migrate:up
+ALTER TABLE "connections" ADD COLUMN "proxy_url" TEXT;
+ALTER TABLE "connections" ADD COLUMN "ssh_host" TEXT;
+ALTER TABLE "connections" ADD COLUMN "ssh_user" TEXT;
+ALTER TABLE "connections" ADD COLUMN "ssh_key_file" TEXT;
+
+-- migrate:down
+ALTER TABLE "connections" DROP COLUMN "ssh_key_file";
+ALTER TABLE "connections" DROP COLUMN "ssh_user";
+ALTER TABLE "connections" DROP COLUMN "ssh_host";
+ALTER TABLE "connections" DROP COLUMN "proxy_url";
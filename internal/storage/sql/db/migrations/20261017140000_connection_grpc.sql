@@ -0,0 +1,7 @@
+-- migrate:up
+ALTER TABLE "connections" ADD COLUMN "grpc_host" TEXT;
+ALTER TABLE "connections" ADD COLUMN "grpc_port" INTEGER;
+
+-- migrate:down
+ALTER TABLE "connections" DROP COLUMN "grpc_port";
+ALTER TABLE "connections" DROP COLUMN "grpc_host";
@@ -0,0 +1,19 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS "request_history" (
+	"id"	INTEGER,
+	"connection_id"	INTEGER NOT NULL,
+	"method"	TEXT NOT NULL,
+	"path"	TEXT NOT NULL,
+	"body"	TEXT NOT NULL DEFAULT '',
+	"status"	INTEGER NOT NULL DEFAULT 0,
+	"error"	TEXT NOT NULL DEFAULT '',
+	"duration_ms"	INTEGER NOT NULL DEFAULT 0,
+	"created_at"	INTEGER NOT NULL,
+	PRIMARY KEY("id" AUTOINCREMENT),
+	FOREIGN KEY("connection_id") REFERENCES "connections"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "request_history_connection_id" ON "request_history" ("connection_id", "created_at");
+
+-- migrate:down
+DROP INDEX IF EXISTS "request_history_connection_id";
+DROP TABLE IF EXISTS "request_history";
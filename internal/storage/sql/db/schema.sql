@@ -4,10 +4,39 @@ CREATE TABLE IF NOT EXISTS "connections" (
 	"uri"	TEXT NOT NULL,
 	"favorite"	BOOLEAN DEFAULT FALSE,
 	"api_key"	TEXT,
-	"color"	TEXT,
+	"color"	TEXT, "auth_type" TEXT NOT NULL DEFAULT '', "username" TEXT, "password" TEXT, "client_secret" TEXT, "scopes" TEXT, "access_token" TEXT, "refresh_token" TEXT, "ca_cert_file" TEXT, "client_cert_file" TEXT, "client_key_file" TEXT, "insecure_skip_verify" BOOLEAN NOT NULL DEFAULT FALSE, "proxy_url" TEXT, "ssh_host" TEXT, "ssh_user" TEXT, "ssh_key_file" TEXT, "grpc_host" TEXT, "grpc_port" INTEGER,
 	PRIMARY KEY("id" AUTOINCREMENT)
 );
+CREATE TABLE IF NOT EXISTS "connection_headers" (
+	"id"	INTEGER,
+	"connection_id"	INTEGER NOT NULL,
+	"name"	TEXT NOT NULL,
+	"value"	TEXT NOT NULL,
+	PRIMARY KEY("id" AUTOINCREMENT),
+	FOREIGN KEY("connection_id") REFERENCES "connections"("id") ON DELETE CASCADE,
+	UNIQUE("connection_id", "name")
+);
+CREATE TABLE IF NOT EXISTS "request_history" (
+	"id"	INTEGER,
+	"connection_id"	INTEGER NOT NULL,
+	"method"	TEXT NOT NULL,
+	"path"	TEXT NOT NULL,
+	"body"	TEXT NOT NULL DEFAULT '',
+	"status"	INTEGER NOT NULL DEFAULT 0,
+	"error"	TEXT NOT NULL DEFAULT '',
+	"duration_ms"	INTEGER NOT NULL DEFAULT 0,
+	"created_at"	INTEGER NOT NULL,
+	PRIMARY KEY("id" AUTOINCREMENT),
+	FOREIGN KEY("connection_id") REFERENCES "connections"("id") ON DELETE CASCADE
+);
+CREATE INDEX "request_history_connection_id" ON "request_history" ("connection_id", "created_at");
 CREATE TABLE IF NOT EXISTS "schema_migrations" (version varchar(128) primary key);
 -- Dbmate schema migrations
 INSERT INTO "schema_migrations" (version) VALUES
-  ('20250705103958');
+  ('20250705103958'),
+  ('20261017093000'),
+  ('20261017110000'),
+  ('20261017120000'),
+  ('20261017130000'),
+  ('20261017140000'),
+  ('20261017150000');
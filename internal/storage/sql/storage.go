@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"weaviate-desktop/internal/models"
@@ -99,18 +100,27 @@ func (s *Storage) GetConnections(decrypt bool) ([]models.Connection, error) {
 		return nil, fmt.Errorf("failed getting connections: %w", err)
 	}
 
-	for i := range connections {
-		if connections[i].ApiKey != nil {
-			fn := s.encr.DecryptSecret
-			if decrypt {
-				fn = s.encr.Decrypt
-			}
+	var headers []struct {
+		ConnectionID int64 `db:"connection_id"`
+		models.ConnectionHeader
+	}
+	err := s.db.SelectContext(
+		ctx,
+		&headers,
+		"SELECT connection_id, name, value FROM connection_headers ORDER BY name",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting connection headers: %w", err)
+	}
 
-			decrypted, err := fn(*connections[i].ApiKey)
-			if err != nil {
-				return nil, fmt.Errorf("failed decrypting api key: %w", err)
+	for i := range connections {
+		for _, h := range headers {
+			if h.ConnectionID == connections[i].ID {
+				connections[i].Headers = append(connections[i].Headers, h.ConnectionHeader)
 			}
-			connections[i].ApiKey = &decrypted
+		}
+		if err := s.decryptSecrets(&connections[i], decrypt); err != nil {
+			return nil, err
 		}
 	}
 
@@ -121,47 +131,78 @@ func (s *Storage) SaveConnection(c models.Connection) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if c.ApiKey != nil {
-		encrypted, err := s.encr.Encrypt(*c.ApiKey)
-		if err != nil {
-			return 0, fmt.Errorf("failed encrypting api key: %w", err)
-		}
+	if err := s.encryptSecrets(&c); err != nil {
+		return 0, err
+	}
 
-		c.ApiKey = &encrypted
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed starting transaction: %w", err)
 	}
+	defer tx.Rollback() //nolint:errcheck
 
 	q := `
-		INSERT INTO connections (name, uri, api_key, color, favorite)
-		VALUES (:name, :uri, :api_key, :color, :favorite)
+		INSERT INTO connections (
+			name, uri, api_key, color, favorite,
+			auth_type, username, password, client_secret, scopes, access_token, refresh_token,
+			ca_cert_file, client_cert_file, client_key_file, insecure_skip_verify,
+			proxy_url, ssh_host, ssh_user, ssh_key_file, grpc_host, grpc_port
+		)
+		VALUES (
+			:name, :uri, :api_key, :color, :favorite,
+			:auth_type, :username, :password, :client_secret, :scopes, :access_token, :refresh_token,
+			:ca_cert_file, :client_cert_file, :client_key_file, :insecure_skip_verify,
+			:proxy_url, :ssh_host, :ssh_user, :ssh_key_file, :grpc_host, :grpc_port
+		)
 		RETURNING id;
 	`
 
-	result, err := s.db.NamedExecContext(ctx, q, c)
+	result, err := tx.NamedExecContext(ctx, q, c)
+	if err != nil {
+		return 0, fmt.Errorf("failed inserting connection: %w", err)
+	}
+	id, err := result.LastInsertId()
 	if err != nil {
 		return 0, fmt.Errorf("failed inserting connection: %w", err)
 	}
 
-	return result.LastInsertId()
+	if err := saveHeaders(ctx, tx, id, c.Headers); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed committing connection: %w", err)
+	}
+
+	return id, nil
 }
 
 func (s *Storage) UpdateConnection(c models.Connection) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if c.ApiKey != nil {
-		encrypted, err := s.encr.Encrypt(*c.ApiKey)
-		if err != nil {
-			return fmt.Errorf("failed encrypting api key: %w", err)
-		}
-		c.ApiKey = &encrypted
+	if err := s.encryptSecrets(&c); err != nil {
+		return err
 	}
 
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
 	q := `
 		UPDATE connections
-		SET name = :name, uri = :uri, api_key = :api_key, color = :color, favorite = :favorite
+		SET name = :name, uri = :uri, api_key = :api_key, color = :color, favorite = :favorite,
+			auth_type = :auth_type, username = :username, password = :password,
+			client_secret = :client_secret, scopes = :scopes,
+			access_token = :access_token, refresh_token = :refresh_token,
+			ca_cert_file = :ca_cert_file, client_cert_file = :client_cert_file,
+			client_key_file = :client_key_file, insecure_skip_verify = :insecure_skip_verify,
+			proxy_url = :proxy_url, ssh_host = :ssh_host, ssh_user = :ssh_user,
+			ssh_key_file = :ssh_key_file, grpc_host = :grpc_host, grpc_port = :grpc_port
 		WHERE id = :id;
 	`
-	result, err := s.db.NamedExecContext(ctx, q, c)
+	result, err := tx.NamedExecContext(ctx, q, c)
 	if err != nil {
 		return fmt.Errorf("failed updating connection: %w", err)
 	}
@@ -173,6 +214,13 @@ func (s *Storage) UpdateConnection(c models.Connection) error {
 		return fmt.Errorf("connection with id %d not found", c.ID)
 	}
 
+	if err := saveHeaders(ctx, tx, c.ID, c.Headers); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing connection: %w", err)
+	}
+
 	return nil
 }
 
@@ -180,7 +228,21 @@ func (s *Storage) RemoveConnection(id int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	result, err := s.db.ExecContext(ctx, "DELETE FROM connections WHERE id = ?", id)
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// sqlite doesn't enforce the foreign keys unless enabled on the connection
+	if _, err := tx.ExecContext(ctx, "DELETE FROM connection_headers WHERE connection_id = ?", id); err != nil {
+		return fmt.Errorf("failed deleting connection headers: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM request_history WHERE connection_id = ?", id); err != nil {
+		return fmt.Errorf("failed deleting request history: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM connections WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("failed deleting connection: %w", err)
 	}
@@ -193,6 +255,10 @@ func (s *Storage) RemoveConnection(id int64) error {
 		return fmt.Errorf("connection with id %d not found", id)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed committing connection removal: %w", err)
+	}
+
 	return nil
 }
 
@@ -221,6 +287,38 @@ func (s *Storage) UpdateFavorite(id int64, favorite bool) error {
 	return nil
 }
 
+// UpdateRefreshToken stores the refresh token the OIDC provider rotated, encrypted like
+// the other secrets of the connection
+func (s *Storage) UpdateRefreshToken(id int64, refreshToken string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	encrypted, err := s.encr.Encrypt(refreshToken)
+	if err != nil {
+		return fmt.Errorf("failed encrypting refresh token: %w", err)
+	}
+
+	result, err := s.db.ExecContext(
+		ctx,
+		"UPDATE connections SET refresh_token = ? WHERE id = ?",
+		encrypted,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed updating refresh token: %w", err)
+	}
+
+	rowsUpdated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed updating refresh token: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return fmt.Errorf("connection with id %d not found", id)
+	}
+
+	return nil
+}
+
 func (s *Storage) GetConnection(id int64, decrypt bool) (*models.Connection, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -230,18 +328,225 @@ func (s *Storage) GetConnection(id int64, decrypt bool) (*models.Connection, err
 		return nil, fmt.Errorf("failed getting connection: %w", err)
 	}
 
-	if connection.ApiKey != nil {
-		fn := s.encr.DecryptSecret
-		if decrypt {
-			fn = s.encr.Decrypt
+	err := s.db.SelectContext(
+		ctx,
+		&connection.Headers,
+		"SELECT name, value FROM connection_headers WHERE connection_id = ? ORDER BY name",
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting connection headers: %w", err)
+	}
+
+	if err := s.decryptSecrets(&connection, decrypt); err != nil {
+		return nil, err
+	}
+
+	return &connection, nil
+}
+
+// requestHistoryLimit is the amount of requests kept in the history of a connection
+const requestHistoryLimit = 100
+
+// SaveRequest records a raw REST request sent to the connection, its body is encrypted
+// as it may carry credentials. The oldest requests past the limit of the history are
+// removed.
+func (s *Storage) SaveRequest(r models.RequestHistory) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if r.Body != "" {
+		encrypted, err := s.encr.Encrypt(r.Body)
+		if err != nil {
+			return 0, fmt.Errorf("failed encrypting request body: %w", err)
 		}
+		r.Body = encrypted
+	}
 
-		decrypted, err := fn(*connection.ApiKey)
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed starting transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	q := `
+		INSERT INTO request_history (
+			connection_id, method, path, body, status, error, duration_ms, created_at
+		)
+		VALUES (
+			:connection_id, :method, :path, :body, :status, :error, :duration_ms, :created_at
+		)
+		RETURNING id;
+	`
+
+	result, err := tx.NamedExecContext(ctx, q, r)
+	if err != nil {
+		return 0, fmt.Errorf("failed inserting request history: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed inserting request history: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`DELETE FROM request_history WHERE connection_id = ? AND id NOT IN (
+			SELECT id FROM request_history WHERE connection_id = ? ORDER BY created_at DESC, id DESC LIMIT ?
+		)`,
+		r.ConnectionID,
+		r.ConnectionID,
+		requestHistoryLimit,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed pruning request history: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed committing request history: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetRequestHistory returns the most recent requests sent to the connection first
+func (s *Storage) GetRequestHistory(connectionID int64, limit int) ([]models.RequestHistory, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = requestHistoryLimit
+	}
+
+	history := []models.RequestHistory{}
+	err := s.db.SelectContext(
+		ctx,
+		&history,
+		"SELECT * FROM request_history WHERE connection_id = ? ORDER BY created_at DESC, id DESC LIMIT ?",
+		connectionID,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting request history: %w", err)
+	}
+
+	for i := range history {
+		if history[i].Body == "" {
+			continue
+		}
+
+		decrypted, err := s.encr.Decrypt(history[i].Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed decrypting api key: %w", err)
+			return nil, fmt.Errorf("failed decrypting body of request %d: %w", history[i].ID, err)
 		}
-		connection.ApiKey = &decrypted
+		history[i].Body = decrypted
 	}
 
-	return &connection, nil
+	return history, nil
+}
+
+func (s *Storage) ClearRequestHistory(connectionID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM request_history WHERE connection_id = ?", connectionID); err != nil {
+		return fmt.Errorf("failed clearing request history: %w", err)
+	}
+
+	return nil
+}
+
+// saveHeaders replaces the headers of the connection, their values are already encrypted
+func saveHeaders(ctx context.Context, tx *sqlx.Tx, connectionID int64, headers []models.ConnectionHeader) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM connection_headers WHERE connection_id = ?", connectionID); err != nil {
+		return fmt.Errorf("failed deleting connection headers: %w", err)
+	}
+
+	for _, h := range headers {
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT INTO connection_headers (connection_id, name, value) VALUES (?, ?, ?)",
+			connectionID,
+			h.Name,
+			h.Value,
+		)
+		if err != nil {
+			return fmt.Errorf("failed inserting connection header %s: %w", h.Name, err)
+		}
+	}
+
+	return nil
+}
+
+type secret struct {
+	name  string
+	value **string
+}
+
+// secrets are the fields of the connection stored encrypted besides the header values
+func secrets(c *models.Connection) []secret {
+	return []secret{
+		{"api key", &c.ApiKey},
+		{"password", &c.Password},
+		{"client secret", &c.ClientSecret},
+		{"access token", &c.AccessToken},
+		{"refresh token", &c.RefreshToken},
+		// the proxy URL may contain the proxy credentials
+		{"proxy url", &c.ProxyURL},
+	}
+}
+
+func (s *Storage) encryptSecrets(c *models.Connection) error {
+	for _, secret := range secrets(c) {
+		if *secret.value == nil {
+			continue
+		}
+
+		encrypted, err := s.encr.Encrypt(**secret.value)
+		if err != nil {
+			return fmt.Errorf("failed encrypting %s: %w", secret.name, err)
+		}
+		*secret.value = &encrypted
+	}
+
+	// the headers are shared with the caller
+	c.Headers = slices.Clone(c.Headers)
+	for i, h := range c.Headers {
+		encrypted, err := s.encr.Encrypt(h.Value)
+		if err != nil {
+			return fmt.Errorf("failed encrypting header %s: %w", h.Name, err)
+		}
+		c.Headers[i].Value = encrypted
+	}
+
+	return nil
+}
+
+// decryptSecrets decrypts the secrets of the connection, they're obscured unless decrypt is set
+func (s *Storage) decryptSecrets(c *models.Connection, decrypt bool) error {
+	fn := s.encr.DecryptSecret
+	if decrypt {
+		fn = s.encr.Decrypt
+	}
+
+	for _, secret := range secrets(c) {
+		if *secret.value == nil {
+			continue
+		}
+
+		decrypted, err := fn(**secret.value)
+		if err != nil {
+			return fmt.Errorf("failed decrypting %s: %w", secret.name, err)
+		}
+		*secret.value = &decrypted
+	}
+
+	for i, h := range c.Headers {
+		decrypted, err := fn(h.Value)
+		if err != nil {
+			return fmt.Errorf("failed decrypting header %s: %w", h.Name, err)
+		}
+		c.Headers[i].Value = decrypted
+	}
+
+	return nil
 }
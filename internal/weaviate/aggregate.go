@@ -0,0 +1,418 @@
+package weaviate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/filters"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+// the statistics of the properties, named after the data types they aggregate
+const (
+	AggregationNumeric = "numeric"
+	AggregationText    = "text"
+	AggregationBoolean = "boolean"
+	AggregationDate    = "date"
+)
+
+type AggregationInput struct {
+	Collection string  `json:"collection"`
+	Tenant     string  `json:"tenant,omitempty"`
+	Filter     *Filter `json:"filter,omitempty"`
+	// Properties are aggregated with the statistics of their data type
+	Properties []string `json:"properties,omitempty"`
+	// TopOccurrences limits the most frequent values of text properties (default 5)
+	TopOccurrences int `json:"topOccurrences,omitempty"`
+	// GroupBy aggregates the objects per value of the property, Limit limits the groups
+	GroupBy string `json:"groupBy,omitempty"`
+	Limit   int    `json:"limit,omitempty"`
+	// SearchType "nearText" | "nearVector" aggregates the results of the search for
+	// Query, which are bounded by ObjectLimit, Distance or Certainty
+	SearchType  string  `json:"searchType,omitempty"`
+	Query       string  `json:"query,omitempty"`
+	ObjectLimit int     `json:"objectLimit,omitempty"`
+	Distance    float32 `json:"distance,omitempty"`
+	Certainty   float32 `json:"certainty,omitempty"`
+}
+
+type AggregationResult struct {
+	// Groups has a single group without GroupBy
+	Groups        []AggregationGroup `json:"groups"`
+	ExecutionTime string             `json:"executionTime"`
+}
+
+type AggregationGroup struct {
+	// GroupedBy is nil without GroupBy
+	GroupedBy  *GroupedBy            `json:"groupedBy,omitempty"`
+	Count      int64                 `json:"count"`
+	Properties []PropertyAggregation `json:"properties"`
+}
+
+type GroupedBy struct {
+	Path  []string `json:"path"`
+	Value string   `json:"value"`
+}
+
+// PropertyAggregation has the statistics of the property, only the statistics of
+// its kind are set
+type PropertyAggregation struct {
+	Property string `json:"property"`
+	// Kind is one of the Aggregation* kinds
+	Kind    string              `json:"kind"`
+	Count   int64               `json:"count"`
+	Numeric *NumericAggregation `json:"numeric,omitempty"`
+	Text    *TextAggregation    `json:"text,omitempty"`
+	Boolean *BooleanAggregation `json:"boolean,omitempty"`
+	Date    *DateAggregation    `json:"date,omitempty"`
+}
+
+// NumericAggregation statistics are nil when no object has a value
+type NumericAggregation struct {
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+	Mean    *float64 `json:"mean,omitempty"`
+	Median  *float64 `json:"median,omitempty"`
+	Mode    *float64 `json:"mode,omitempty"`
+	Sum     *float64 `json:"sum,omitempty"`
+}
+
+type TextAggregation struct {
+	TopOccurrences []Occurrence `json:"topOccurrences"`
+}
+
+type Occurrence struct {
+	Value  string `json:"value"`
+	Occurs int64  `json:"occurs"`
+}
+
+type BooleanAggregation struct {
+	TotalTrue       int64   `json:"totalTrue"`
+	TotalFalse      int64   `json:"totalFalse"`
+	PercentageTrue  float64 `json:"percentageTrue"`
+	PercentageFalse float64 `json:"percentageFalse"`
+}
+
+// DateAggregation statistics are RFC 3339 dates, nil when no object has a value
+type DateAggregation struct {
+	Minimum *string `json:"minimum,omitempty"`
+	Maximum *string `json:"maximum,omitempty"`
+	Median  *string `json:"median,omitempty"`
+	Mode    *string `json:"mode,omitempty"`
+}
+
+// aggregationKinds maps the data types, without the array suffix, to the statistics
+// they're aggregated with
+var aggregationKinds = map[string]string{
+	"int":     AggregationNumeric,
+	"number":  AggregationNumeric,
+	"text":    AggregationText,
+	"string":  AggregationText,
+	"boolean": AggregationBoolean,
+	"date":    AggregationDate,
+}
+
+// Aggregate aggregates the objects of the collection (or tenant) matching the filter,
+// the results of a near search can be aggregated instead of every object
+func (w *Weaviate) Aggregate(connectionID int64, input AggregationInput) (*AggregationResult, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return c.aggregate(ctx, input)
+}
+
+func (c *WClient) aggregate(ctx context.Context, input AggregationInput) (*AggregationResult, error) {
+	now := time.Now()
+
+	schemas := collectionSchemas(ctx, c)
+	kinds := map[string]string{}
+	var where *filters.WhereBuilder
+	if input.Filter != nil || len(input.Properties) > 0 || input.GroupBy != "" {
+		col, err := schemas(input.Collection)
+		if err != nil {
+			return nil, err
+		}
+		if where, err = buildWhere(input.Filter, col, schemas); err != nil {
+			return nil, err
+		}
+		if kinds, err = aggregationPropertyKinds(col, input); err != nil {
+			return nil, err
+		}
+	}
+
+	agg := c.w.GraphQL().Aggregate().
+		WithClassName(input.Collection).
+		WithFields(aggregationFields(input, kinds)...)
+
+	if input.Tenant != "" {
+		agg = agg.WithTenant(input.Tenant)
+	}
+	if where != nil {
+		agg = agg.WithWhere(where)
+	}
+	if input.GroupBy != "" {
+		agg = agg.WithGroupBy(input.GroupBy)
+		if input.Limit > 0 {
+			agg = agg.WithLimit(input.Limit)
+		}
+	}
+	agg, err := withAggregationSearch(agg, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := agg.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed aggregating %s: %w", input.Collection, err)
+	}
+	if len(result.Errors) != 0 {
+		err := strings.Builder{}
+
+		for _, e := range result.Errors {
+			err.WriteString(e.Message)
+			err.WriteString(",")
+		}
+
+		return nil, errors.New(err.String())
+	}
+
+	aggregate, _ := result.Data["Aggregate"].(map[string]any)
+	groups, ok := aggregate[input.Collection].([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected aggregation response for %s", input.Collection)
+	}
+
+	res := &AggregationResult{Groups: make([]AggregationGroup, 0, len(groups))}
+	for _, g := range groups {
+		group, ok := g.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected aggregation response for %s", input.Collection)
+		}
+		res.Groups = append(res.Groups, toAggregationGroup(group, input.Properties, kinds))
+	}
+	res.ExecutionTime = time.Since(now).String()
+
+	return res, nil
+}
+
+// aggregationPropertyKinds returns the statistics the properties are aggregated with,
+// the properties have to exist and have an aggregatable data type
+func aggregationPropertyKinds(col *models.Class, input AggregationInput) (map[string]string, error) {
+	dataTypes := make(map[string]string, len(col.Properties))
+	for _, p := range col.Properties {
+		if len(p.DataType) > 0 {
+			dataTypes[p.Name] = p.DataType[0]
+		}
+	}
+
+	if input.GroupBy != "" {
+		if _, ok := dataTypes[input.GroupBy]; !ok {
+			return nil, fmt.Errorf("property %q does not exist in %s", input.GroupBy, col.Class)
+		}
+	}
+
+	kinds := make(map[string]string, len(input.Properties))
+	for _, name := range input.Properties {
+		dataType, ok := dataTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("property %q does not exist in %s", name, col.Class)
+		}
+		kind, ok := aggregationKinds[strings.TrimSuffix(dataType, "[]")]
+		if !ok {
+			return nil, fmt.Errorf("property %q of type %s can't be aggregated", name, dataType)
+		}
+		kinds[name] = kind
+	}
+
+	return kinds, nil
+}
+
+func aggregationFields(input AggregationInput, kinds map[string]string) []graphql.Field {
+	fields := []graphql.Field{{Name: "meta", Fields: []graphql.Field{{Name: "count"}}}}
+	if input.GroupBy != "" {
+		fields = append(fields, graphql.Field{
+			Name:   "groupedBy",
+			Fields: []graphql.Field{{Name: "path"}, {Name: "value"}},
+		})
+	}
+
+	for _, name := range input.Properties {
+		var stats []string
+		switch kinds[name] {
+		case AggregationNumeric:
+			stats = []string{"count", "minimum", "maximum", "mean", "median", "mode", "sum"}
+		case AggregationText:
+			topOccurrences := input.TopOccurrences
+			if topOccurrences <= 0 {
+				topOccurrences = 5
+			}
+			stats = []string{
+				"count",
+				fmt.Sprintf("topOccurrences(limit: %d){value occurs}", topOccurrences),
+			}
+		case AggregationBoolean:
+			stats = []string{"count", "totalTrue", "totalFalse", "percentageTrue", "percentageFalse"}
+		case AggregationDate:
+			stats = []string{"count", "minimum", "maximum", "median", "mode"}
+		}
+
+		field := graphql.Field{Name: name}
+		for _, stat := range stats {
+			field.Fields = append(field.Fields, graphql.Field{Name: stat})
+		}
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// withAggregationSearch aggregates the results of the near search of the input
+func withAggregationSearch(
+	agg *graphql.AggregateBuilder,
+	input AggregationInput,
+) (*graphql.AggregateBuilder, error) {
+	if input.SearchType == "" {
+		return agg, nil
+	}
+	if input.ObjectLimit <= 0 && input.Distance <= 0 && input.Certainty <= 0 {
+		return nil, errors.New("objectLimit, distance or certainty is required to aggregate search results")
+	}
+	if input.ObjectLimit > 0 {
+		agg = agg.WithObjectLimit(input.ObjectLimit)
+	}
+
+	switch input.SearchType {
+	case "nearText":
+		nt := (&graphql.NearTextArgumentBuilder{}).WithConcepts([]string{input.Query})
+		if input.Distance > 0 {
+			nt = nt.WithDistance(input.Distance)
+		}
+		if input.Certainty > 0 {
+			nt = nt.WithCertainty(input.Certainty)
+		}
+		return agg.WithNearText(nt), nil
+	case "nearVector":
+		vector, perTarget, err := parseVectorQuery(input.Query)
+		if err != nil {
+			return nil, err
+		}
+		nv := (&graphql.NearVectorArgumentBuilder{}).WithVector(vector)
+		if perTarget != nil {
+			nv = nv.WithVectorPerTarget(perTarget).
+				WithTargetVectors(slices.Sorted(maps.Keys(perTarget))...)
+		}
+		if input.Distance > 0 {
+			nv = nv.WithDistance(input.Distance)
+		}
+		if input.Certainty > 0 {
+			nv = nv.WithCertainty(input.Certainty)
+		}
+		return agg.WithNearVector(nv), nil
+	default:
+		return nil, fmt.Errorf("unsupported aggregation search type %q", input.SearchType)
+	}
+}
+
+func toAggregationGroup(group map[string]any, props []string, kinds map[string]string) AggregationGroup {
+	meta, _ := group["meta"].(map[string]any)
+	res := AggregationGroup{
+		Count:      aggregationInt(meta["count"]),
+		Properties: make([]PropertyAggregation, 0, len(props)),
+	}
+
+	if groupedBy, ok := group["groupedBy"].(map[string]any); ok {
+		res.GroupedBy = &GroupedBy{Value: fmt.Sprint(groupedBy["value"])}
+		path, _ := groupedBy["path"].([]any)
+		for _, p := range path {
+			if s, ok := p.(string); ok {
+				res.GroupedBy.Path = append(res.GroupedBy.Path, s)
+			}
+		}
+	}
+
+	for _, name := range props {
+		stats, _ := group[name].(map[string]any)
+		agg := PropertyAggregation{
+			Property: name,
+			Kind:     kinds[name],
+			Count:    aggregationInt(stats["count"]),
+		}
+
+		switch agg.Kind {
+		case AggregationNumeric:
+			agg.Numeric = &NumericAggregation{
+				Minimum: aggregationFloat(stats["minimum"]),
+				Maximum: aggregationFloat(stats["maximum"]),
+				Mean:    aggregationFloat(stats["mean"]),
+				Median:  aggregationFloat(stats["median"]),
+				Mode:    aggregationFloat(stats["mode"]),
+				Sum:     aggregationFloat(stats["sum"]),
+			}
+		case AggregationText:
+			agg.Text = &TextAggregation{TopOccurrences: []Occurrence{}}
+			occurrences, _ := stats["topOccurrences"].([]any)
+			for _, o := range occurrences {
+				occurrence, _ := o.(map[string]any)
+				agg.Text.TopOccurrences = append(agg.Text.TopOccurrences, Occurrence{
+					Value:  fmt.Sprint(occurrence["value"]),
+					Occurs: aggregationInt(occurrence["occurs"]),
+				})
+			}
+		case AggregationBoolean:
+			agg.Boolean = &BooleanAggregation{
+				TotalTrue:       aggregationInt(stats["totalTrue"]),
+				TotalFalse:      aggregationInt(stats["totalFalse"]),
+				PercentageTrue:  aggregationNumber(stats["percentageTrue"]),
+				PercentageFalse: aggregationNumber(stats["percentageFalse"]),
+			}
+		case AggregationDate:
+			agg.Date = &DateAggregation{
+				Minimum: aggregationString(stats["minimum"]),
+				Maximum: aggregationString(stats["maximum"]),
+				Median:  aggregationString(stats["median"]),
+				Mode:    aggregationString(stats["mode"]),
+			}
+		}
+
+		res.Properties = append(res.Properties, agg)
+	}
+
+	return res
+}
+
+func aggregationNumber(v any) float64 {
+	f, _ := toFloat(v)
+	return f
+}
+
+func aggregationInt(v any) int64 {
+	return int64(aggregationNumber(v))
+}
+
+// aggregationFloat is nil for null statistics, e.g. the mean of no values
+func aggregationFloat(v any) *float64 {
+	f, ok := toFloat(v)
+	if !ok {
+		return nil
+	}
+	return &f
+}
+
+func aggregationString(v any) *string {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,210 @@
+package weaviate
+
+import (
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestAggregate(t *testing.T) {
+	connectionID := int64(1)
+	class := &weaviate_models.Class{
+		Class: "Article",
+		Properties: []*weaviate_models.Property{
+			{Name: "title", DataType: []string{"text"}},
+			{Name: "views", DataType: []string{"int"}},
+			{Name: "published", DataType: []string{"boolean"}},
+			{Name: "publishedAt", DataType: []string{"date"}},
+			{Name: "category", DataType: []string{"text"}},
+			{Name: "ref", DataType: []string{"uuid"}},
+		},
+	}
+
+	search := searchTest{
+		connectionID: connectionID,
+		classes:      []*weaviate_models.Class{class},
+	}
+
+	t.Run("should aggregate property statistics by their data type", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{"data": {"Aggregate": {"Article": [{
+			"meta": {"count": 3},
+			"views": {
+				"count": 3, "minimum": 1, "maximum": 10, "mean": 5,
+				"median": 4, "mode": 1, "sum": 15
+			},
+			"title": {"count": 3, "topOccurrences": [{"value": "weaviate", "occurs": 2}]},
+			"published": {
+				"count": 3, "totalTrue": 2, "totalFalse": 1,
+				"percentageTrue": 0.66, "percentageFalse": 0.33
+			},
+			"publishedAt": {
+				"count": 0, "minimum": null, "maximum": null, "median": null, "mode": null
+			}
+		}]}}}`, &queries, nil)
+
+		res, err := weaviate.Aggregate(connectionID, AggregationInput{
+			Collection:     "Article",
+			Tenant:         "tenant",
+			Filter:         &Filter{Operator: "GreaterThan", Path: []string{"views"}, Value: float64(0)},
+			Properties:     []string{"views", "title", "published", "publishedAt"},
+			TopOccurrences: 3,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], `Article(tenant: "tenant", where:{operator: GreaterThan path: ["views"] valueInt: 0})`)
+		assert.Contains(t, queries[0], "views{count minimum maximum mean median mode sum}")
+		assert.Contains(t, queries[0], "title{count topOccurrences(limit: 3){value occurs}}")
+		assert.Contains(t, queries[0], "published{count totalTrue totalFalse percentageTrue percentageFalse}")
+		assert.Contains(t, queries[0], "publishedAt{count minimum maximum median mode}")
+
+		assert.Equal(t, []AggregationGroup{{
+			Count: 3,
+			Properties: []PropertyAggregation{
+				{
+					Property: "views",
+					Kind:     AggregationNumeric,
+					Count:    3,
+					Numeric: &NumericAggregation{
+						Minimum: utils.Pointer(1.0),
+						Maximum: utils.Pointer(10.0),
+						Mean:    utils.Pointer(5.0),
+						Median:  utils.Pointer(4.0),
+						Mode:    utils.Pointer(1.0),
+						Sum:     utils.Pointer(15.0),
+					},
+				},
+				{
+					Property: "title",
+					Kind:     AggregationText,
+					Count:    3,
+					Text: &TextAggregation{
+						TopOccurrences: []Occurrence{{Value: "weaviate", Occurs: 2}},
+					},
+				},
+				{
+					Property: "published",
+					Kind:     AggregationBoolean,
+					Count:    3,
+					Boolean: &BooleanAggregation{
+						TotalTrue:       2,
+						TotalFalse:      1,
+						PercentageTrue:  0.66,
+						PercentageFalse: 0.33,
+					},
+				},
+				{
+					Property: "publishedAt",
+					Kind:     AggregationDate,
+					Date:     &DateAggregation{},
+				},
+			},
+		}}, res.Groups)
+		assert.NotEmpty(t, res.ExecutionTime)
+	})
+
+	t.Run("should group aggregation of near text results", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{"data": {"Aggregate": {"Article": [
+			{"meta": {"count": 2}, "groupedBy": {"path": ["category"], "value": "news"}},
+			{"meta": {"count": 1}, "groupedBy": {"path": ["category"], "value": "blog"}}
+		]}}}`, &queries, nil)
+
+		res, err := weaviate.Aggregate(connectionID, AggregationInput{
+			Collection:  "Article",
+			GroupBy:     "category",
+			Limit:       2,
+			SearchType:  "nearText",
+			Query:       "vector databases",
+			ObjectLimit: 50,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], `groupBy: "category"`)
+		assert.Contains(t, queries[0], `nearText:{concepts: ["vector databases"]}`)
+		assert.Contains(t, queries[0], "objectLimit: 50")
+		assert.Contains(t, queries[0], "limit: 2")
+		assert.Equal(t, []AggregationGroup{
+			{
+				GroupedBy:  &GroupedBy{Path: []string{"category"}, Value: "news"},
+				Count:      2,
+				Properties: []PropertyAggregation{},
+			},
+			{
+				GroupedBy:  &GroupedBy{Path: []string{"category"}, Value: "blog"},
+				Count:      1,
+				Properties: []PropertyAggregation{},
+			},
+		}, res.Groups)
+	})
+
+	t.Run("should not query if input is invalid", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			input    AggregationInput
+			expected string
+		}{
+			{
+				name:     "unknown property",
+				input:    AggregationInput{Collection: "Article", Properties: []string{"likes"}},
+				expected: `property "likes" does not exist in Article`,
+			},
+			{
+				name:     "unknown group by property",
+				input:    AggregationInput{Collection: "Article", GroupBy: "author"},
+				expected: `property "author" does not exist in Article`,
+			},
+			{
+				name:     "property that can't be aggregated",
+				input:    AggregationInput{Collection: "Article", Properties: []string{"ref"}},
+				expected: `property "ref" of type uuid can't be aggregated`,
+			},
+			{
+				name:     "unbounded search",
+				input:    AggregationInput{Collection: "Article", SearchType: "nearVector", Query: "[1, 0]"},
+				expected: "objectLimit, distance or certainty is required to aggregate search results",
+			},
+			{
+				name: "unsupported search",
+				input: AggregationInput{
+					Collection:  "Article",
+					SearchType:  "bm25",
+					Query:       "weaviate",
+					ObjectLimit: 10,
+				},
+				expected: `unsupported aggregation search type "bm25"`,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				queries := []string{}
+				weaviate := newSearchTestWeaviate(t, search, `{}`, &queries, nil)
+
+				res, err := weaviate.Aggregate(connectionID, tc.input)
+
+				assert.Nil(t, res)
+				assert.EqualError(t, err, tc.expected)
+				assert.Empty(t, queries)
+			})
+		}
+	})
+
+	t.Run("should return error if connection doesn't exist", func(t *testing.T) {
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+
+		res, err := weaviate.Aggregate(connectionID, AggregationInput{Collection: "Article"})
+
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "connection doesn't exist 1")
+	})
+}
@@ -0,0 +1,213 @@
+package weaviate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/alias"
+)
+
+type Alias struct {
+	Alias string `json:"alias"`
+	// Collection is the collection the alias points at
+	Collection string `json:"collection"`
+}
+
+type AliasSwapInput struct {
+	Alias string `json:"alias"`
+	// Collection is the collection the alias is repointed at
+	Collection string `json:"collection"`
+	// Confirm swaps the alias, otherwise the swap is only checked
+	Confirm bool `json:"confirm"`
+}
+
+type AliasSwap struct {
+	Alias string `json:"alias"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	// Incompatible are the differences that would break queries through the alias
+	Incompatible []SchemaChange `json:"incompatible"`
+	// Changes are the remaining differences between the collections
+	Changes []SchemaChange `json:"changes"`
+	Swapped bool           `json:"swapped"`
+}
+
+func (s AliasSwap) Compatible() bool {
+	return len(s.Incompatible) == 0
+}
+
+// GetAliases lists the aliases sorted by name, only the aliases of the collection
+// are listed when it isn't empty
+func (w *Weaviate) GetAliases(connectionID int64, collection string) ([]Alias, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := c.w.Alias().Getter().WithClassName(collection).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving aliases: %w", err)
+	}
+
+	aliases := make([]Alias, 0, len(res))
+	for _, a := range res {
+		aliases = append(aliases, Alias{Alias: a.Alias, Collection: a.Class})
+	}
+	slices.SortFunc(aliases, func(a, b Alias) int {
+		return strings.Compare(a.Alias, b.Alias)
+	})
+
+	return aliases, nil
+}
+
+func (w *Weaviate) CreateAlias(connectionID int64, name, collection string) error {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	if name == "" {
+		return errors.New("alias name is required")
+	}
+	if collection == "" {
+		return errors.New("alias collection is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := c.w.Alias().AliasCreator().
+		WithAlias(&alias.Alias{Alias: name, Class: collection}).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed creating alias %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (w *Weaviate) DeleteAlias(connectionID int64, name string) error {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := c.w.Alias().AliasDeleter().WithAliasName(name).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed deleting alias %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// SwapAlias repoints the alias at another collection, e.g. after reindexing into a
+// new collection. The collections are compared first and the swap is refused when
+// the new collection is missing properties, changes their data types or changes the
+// vectorizer, as queries through the alias would break. Without confirmation only the
+// comparison is returned so it can be reviewed before swapping.
+func (w *Weaviate) SwapAlias(connectionID int64, input AliasSwapInput) (*AliasSwap, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	current, err := c.w.Alias().AliasGetter().WithAliasName(input.Alias).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving alias %s: %w", input.Alias, err)
+	}
+	if current.Class == input.Collection {
+		return nil, fmt.Errorf("alias %s already points at %s", input.Alias, input.Collection)
+	}
+
+	from, err := w.GetCollection(connectionID, current.Class)
+	if err != nil {
+		return nil, err
+	}
+	to, err := w.GetCollection(connectionID, input.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	swap := &AliasSwap{
+		Alias:        input.Alias,
+		From:         current.Class,
+		To:           input.Collection,
+		Incompatible: []SchemaChange{},
+		Changes:      []SchemaChange{},
+	}
+
+	// changes are reported on the collection the alias points at
+	changes := diffProperties(current.Class, from.Properties, to.Properties)
+	settings, err := diffClassSettings(current.Class, from, to)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, settings...)
+	slices.SortFunc(changes, func(a, b SchemaChange) int {
+		return cmp.Or(
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Property, b.Property),
+			cmp.Compare(a.Field, b.Field),
+		)
+	})
+
+	for _, change := range changes {
+		if breaksAlias(change) {
+			swap.Incompatible = append(swap.Incompatible, change)
+			continue
+		}
+		swap.Changes = append(swap.Changes, change)
+	}
+
+	if !input.Confirm {
+		return swap, nil
+	}
+	if !swap.Compatible() {
+		return nil, fmt.Errorf(
+			"collection %s isn't compatible with %s, the alias %s wasn't swapped",
+			input.Collection,
+			current.Class,
+			input.Alias,
+		)
+	}
+
+	err = c.w.Alias().AliasUpdater().
+		WithAlias(&alias.Alias{Alias: input.Alias, Class: input.Collection}).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed swapping alias %s: %w", input.Alias, err)
+	}
+	swap.Swapped = true
+
+	return swap, nil
+}
+
+// breaksAlias reports whether queries that work through the alias could fail
+// after it's pointed at the new collection
+func breaksAlias(change SchemaChange) bool {
+	switch change.Kind {
+	case ChangeMissingProperty:
+		return true
+	case ChangeProperty:
+		return change.Field == "dataType" || change.Field == "nestedProperties"
+	case ChangeSetting:
+		return change.Field == "vectorizer" ||
+			strings.HasPrefix(change.Field, "vectorConfig.") && strings.Contains(change.Field, ".vectorizer")
+	default:
+		return false
+	}
+}
@@ -0,0 +1,276 @@
+package weaviate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestAliases(t *testing.T) {
+	connectionID := int64(1)
+	collections := map[string]*weaviate_models.Class{
+		"ArticleV1": {
+			Class:      "ArticleV1",
+			Vectorizer: "text2vec-openai",
+			Properties: []*weaviate_models.Property{
+				{Name: "title", DataType: []string{"text"}},
+				{Name: "views", DataType: []string{"int"}},
+			},
+			VectorIndexConfig: map[string]any{"ef": 64},
+		},
+		"ArticleV2": {
+			Class:      "ArticleV2",
+			Vectorizer: "text2vec-openai",
+			Properties: []*weaviate_models.Property{
+				{Name: "title", DataType: []string{"text"}},
+				{Name: "views", DataType: []string{"int"}},
+				{Name: "summary", DataType: []string{"text"}},
+			},
+			VectorIndexConfig: map[string]any{"ef": 128},
+		},
+		"ArticleBroken": {
+			Class:      "ArticleBroken",
+			Vectorizer: "text2vec-cohere",
+			Properties: []*weaviate_models.Property{
+				{Name: "title", DataType: []string{"text"}},
+				{Name: "views", DataType: []string{"text"}},
+			},
+		},
+	}
+
+	// newWeaviate serves the collections and the Article alias, recording the alias changes
+	newWeaviate := func(t *testing.T, requests *[]string) *Weaviate {
+		t.Helper()
+
+		var mu sync.Mutex
+		mockServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case r.URL.Path == "/v1/meta":
+				w.Write([]byte(`{"version": "1.32.0"}`))
+			case strings.HasPrefix(r.URL.Path, "/v1/schema/"):
+				class, ok := collections[strings.TrimPrefix(r.URL.Path, "/v1/schema/")]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				json.NewEncoder(w).Encode(class)
+			case r.URL.Path == "/v1/aliases" && r.Method == http.MethodGet:
+				if r.URL.Query().Get("class") == "ArticleV1" {
+					w.Write([]byte(`{"aliases":[{"alias":"Article","class":"ArticleV1"}]}`))
+					return
+				}
+				w.Write([]byte(`{"aliases":[
+					{"alias":"Product","class":"ProductV3"},
+					{"alias":"Article","class":"ArticleV1"}
+				]}`))
+			case r.URL.Path == "/v1/aliases/Article" && r.Method == http.MethodGet:
+				w.Write([]byte(`{"alias":"Article","class":"ArticleV1"}`))
+			case r.URL.Path == "/v1/aliases/Missing" && r.Method == http.MethodGet:
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				*requests = append(*requests, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+
+				if r.Method == http.MethodDelete {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.Write(body)
+			}
+		}))
+		t.Cleanup(mockServer.Close)
+
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+		client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+		require.NoError(t, err)
+		weaviate.clients.add(connectionID, client)
+
+		return weaviate
+	}
+
+	t.Run("GetAliases", func(t *testing.T) {
+		t.Run("should list aliases sorted by name", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			aliases, err := weaviate.GetAliases(connectionID, "")
+
+			require.NoError(t, err)
+			assert.Equal(t, []Alias{
+				{Alias: "Article", Collection: "ArticleV1"},
+				{Alias: "Product", Collection: "ProductV3"},
+			}, aliases)
+		})
+
+		t.Run("should list aliases of collection", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			aliases, err := weaviate.GetAliases(connectionID, "ArticleV1")
+
+			require.NoError(t, err)
+			assert.Equal(t, []Alias{{Alias: "Article", Collection: "ArticleV1"}}, aliases)
+		})
+	})
+
+	t.Run("CreateAlias", func(t *testing.T) {
+		t.Run("should create alias", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			err := weaviate.CreateAlias(connectionID, "Blog", "ArticleV2")
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{`POST /v1/aliases {"alias":"Blog","class":"ArticleV2"}`}, requests)
+		})
+
+		t.Run("should return error without collection", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			err := weaviate.CreateAlias(connectionID, "Blog", "")
+
+			assert.EqualError(t, err, "alias collection is required")
+			assert.Empty(t, requests)
+		})
+	})
+
+	t.Run("DeleteAlias", func(t *testing.T) {
+		t.Run("should delete alias", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			err := weaviate.DeleteAlias(connectionID, "Article")
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"DELETE /v1/aliases/Article "}, requests)
+		})
+	})
+
+	t.Run("SwapAlias", func(t *testing.T) {
+		t.Run("should only compare the collections without confirmation", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			swap, err := weaviate.SwapAlias(connectionID, AliasSwapInput{
+				Alias:      "Article",
+				Collection: "ArticleV2",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, &AliasSwap{
+				Alias:        "Article",
+				From:         "ArticleV1",
+				To:           "ArticleV2",
+				Incompatible: []SchemaChange{},
+				Changes: []SchemaChange{
+					{
+						Kind:       ChangeSetting,
+						Collection: "ArticleV1",
+						Field:      "vectorIndexConfig.ef",
+						Source:     float64(64),
+						Target:     float64(128),
+					},
+					{
+						Kind:       ChangeExtraProperty,
+						Collection: "ArticleV1",
+						Property:   "summary",
+						Target:     []string{"text"},
+					},
+				},
+			}, swap)
+			assert.True(t, swap.Compatible())
+			assert.Empty(t, requests)
+		})
+
+		t.Run("should swap compatible collection when confirmed", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			swap, err := weaviate.SwapAlias(connectionID, AliasSwapInput{
+				Alias:      "Article",
+				Collection: "ArticleV2",
+				Confirm:    true,
+			})
+
+			require.NoError(t, err)
+			assert.True(t, swap.Swapped)
+			assert.Equal(t, []string{`PUT /v1/aliases/Article {"class":"ArticleV2"}`}, requests)
+		})
+
+		t.Run("should refuse to swap incompatible collection", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			preview, err := weaviate.SwapAlias(connectionID, AliasSwapInput{
+				Alias:      "Article",
+				Collection: "ArticleBroken",
+			})
+
+			require.NoError(t, err)
+			assert.False(t, preview.Compatible())
+			fields := []string{}
+			for _, c := range preview.Incompatible {
+				fields = append(fields, c.Kind+" "+c.Property+c.Field)
+			}
+			assert.ElementsMatch(t, []string{
+				"changedProperty viewsdataType",
+				"changedSetting vectorizer",
+			}, fields)
+
+			swap, err := weaviate.SwapAlias(connectionID, AliasSwapInput{
+				Alias:      "Article",
+				Collection: "ArticleBroken",
+				Confirm:    true,
+			})
+
+			assert.Nil(t, swap)
+			assert.EqualError(
+				t,
+				err,
+				"collection ArticleBroken isn't compatible with ArticleV1, the alias Article wasn't swapped",
+			)
+			assert.Empty(t, requests)
+		})
+
+		t.Run("should return error if alias points at the collection", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			swap, err := weaviate.SwapAlias(connectionID, AliasSwapInput{
+				Alias:      "Article",
+				Collection: "ArticleV1",
+				Confirm:    true,
+			})
+
+			assert.Nil(t, swap)
+			assert.EqualError(t, err, "alias Article already points at ArticleV1")
+		})
+
+		t.Run("should return error if alias doesn't exist", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			swap, err := weaviate.SwapAlias(connectionID, AliasSwapInput{
+				Alias:      "Missing",
+				Collection: "ArticleV2",
+			})
+
+			assert.Nil(t, swap)
+			assert.ErrorContains(t, err, "failed retrieving alias Missing")
+		})
+	})
+}
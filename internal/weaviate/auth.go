@@ -0,0 +1,278 @@
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"weaviate-desktop/internal/models"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// authenticate resolves the authentication of the connection. API keys are sent as
+// a header, the OIDC flows return a client whose token source refreshes the access
+// token on its own so it's shared by the go client and the requests sent directly.
+// The OIDC provider is reached through the http client of the connection as well, a
+// refresh token rotated by the provider is passed to saveRefreshToken when it's set.
+func authenticate(
+	c *models.Connection,
+	u *url.URL,
+	httpClient *http.Client,
+	saveRefreshToken func(refreshToken string),
+) (*WClient, error) {
+	client := &WClient{
+		uri:     strings.TrimSuffix(c.URI, "/"),
+		http:    httpClient,
+		headers: map[string]string{},
+	}
+
+	switch authType(c) {
+	case "":
+		return client, nil
+	case models.AuthAPIKey:
+		if value(c.ApiKey) == "" {
+			return nil, fmt.Errorf("api key is required")
+		}
+		client.headers["Authorization"] = fmt.Sprintf("Bearer %s", *c.ApiKey)
+		// weaviate cloud expects the api key on its own header as well, as the go client does
+		if weaviateCloud(u.Host) {
+			client.headers["X-Weaviate-Api-Key"] = *c.ApiKey
+			client.headers["X-Weaviate-Cluster-Url"] = fmt.Sprintf("https://%s", u.Host)
+		}
+		return client, nil
+	case models.AuthPassword:
+		if value(c.Username) == "" || value(c.Password) == "" {
+			return nil, fmt.Errorf("username and password are required")
+		}
+	case models.AuthClientCredentials:
+		if value(c.ClientSecret) == "" {
+			return nil, fmt.Errorf("client secret is required")
+		}
+	case models.AuthBearerToken:
+		if value(c.AccessToken) == "" {
+			return nil, fmt.Errorf("access token is required")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported auth type %s", c.AuthType)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	provider, err := discoverOIDC(ctx, client.uri, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed authenticating with OIDC: %w", err)
+	}
+	// weaviate is running without authentication
+	if provider == nil {
+		return client, nil
+	}
+
+	tokens, err := provider.tokenSource(ctx, httpClient, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed authenticating with OIDC: %w", err)
+	}
+	if saveRefreshToken != nil && authType(c) == models.AuthBearerToken {
+		tokens = &rotatedTokenSource{
+			source:       tokens,
+			refreshToken: value(c.RefreshToken),
+			save:         saveRefreshToken,
+		}
+	}
+
+	client.tokens = tokens
+	client.http = &http.Client{
+		Transport: &oauth2.Transport{Source: tokens, Base: httpClient.Transport},
+		Timeout:   httpClient.Timeout,
+	}
+
+	return client, nil
+}
+
+// oidcProvider is the OIDC provider weaviate is configured with
+type oidcProvider struct {
+	clientID string
+	// scopes are requested by weaviate besides the scopes of the connection
+	scopes   []string
+	tokenURL string
+}
+
+// discoverOIDC fetches the OIDC configuration of weaviate and the token endpoint of
+// its provider, nil when weaviate is running without authentication
+func discoverOIDC(ctx context.Context, uri string, httpClient *http.Client) (*oidcProvider, error) {
+	var config struct {
+		Href     string   `json:"href"`
+		ClientID string   `json:"clientId"`
+		Scopes   []string `json:"scopes"`
+	}
+	status, err := getJSON(ctx, httpClient, uri+"/v1/.well-known/openid-configuration", &config)
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving the OIDC configuration of weaviate: %w", err)
+	}
+
+	var endpoints struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if _, err := getJSON(ctx, httpClient, config.Href, &endpoints); err != nil {
+		return nil, fmt.Errorf("failed retrieving the OIDC configuration of %s: %w", config.Href, err)
+	}
+	if endpoints.TokenEndpoint == "" {
+		return nil, fmt.Errorf("OIDC configuration of %s has no token_endpoint", config.Href)
+	}
+
+	return &oidcProvider{
+		clientID: config.ClientID,
+		scopes:   config.Scopes,
+		tokenURL: endpoints.TokenEndpoint,
+	}, nil
+}
+
+// tokenSource requests the tokens of the connection. The token requests go through the
+// http client of the connection, e.g. with its TLS options, proxy or SSH tunnel, like
+// the requests to weaviate. ctx only bounds the first token of the password flow.
+func (p *oidcProvider) tokenSource(
+	ctx context.Context,
+	httpClient *http.Client,
+	c *models.Connection,
+) (oauth2.TokenSource, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	// the token source outlives the authentication, its refreshes aren't bounded by ctx
+	tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+	scopes := strings.Fields(value(c.Scopes))
+
+	switch authType(c) {
+	case models.AuthPassword:
+		// the refresh token is only issued with offline access
+		if len(scopes) == 0 {
+			scopes = []string{"offline_access"}
+		}
+		config := oauth2.Config{
+			ClientID: p.clientID,
+			Endpoint: oauth2.Endpoint{TokenURL: p.tokenURL},
+			Scopes:   append(scopes, p.scopes...),
+		}
+		token, err := config.PasswordCredentialsToken(ctx, *c.Username, *c.Password)
+		if err != nil {
+			return nil, err
+		}
+		return config.TokenSource(tokenCtx, token), nil
+	case models.AuthClientCredentials:
+		// azure requires the default scope of the client
+		if len(scopes) == 0 && strings.HasPrefix(p.tokenURL, "https://login.microsoftonline.com") {
+			scopes = []string{p.clientID + "/.default"}
+		}
+		config := clientcredentials.Config{
+			ClientID:     p.clientID,
+			ClientSecret: *c.ClientSecret,
+			TokenURL:     p.tokenURL,
+			Scopes:       append(scopes, p.scopes...),
+		}
+		return config.TokenSource(tokenCtx), nil
+	default:
+		token := &oauth2.Token{AccessToken: *c.AccessToken}
+		if value(c.RefreshToken) == "" {
+			return oauth2.StaticTokenSource(token), nil
+		}
+		// the expiry of the access token isn't known, it's refreshed on the first request
+		token.RefreshToken = *c.RefreshToken
+		token.Expiry = time.Now()
+		config := oauth2.Config{
+			ClientID: p.clientID,
+			Endpoint: oauth2.Endpoint{TokenURL: p.tokenURL},
+		}
+		return config.TokenSource(tokenCtx, token), nil
+	}
+}
+
+// rotatedTokenSource saves the refresh token when the OIDC provider rotates it, the
+// previous one is no longer valid when the connection is opened again
+type rotatedTokenSource struct {
+	source oauth2.TokenSource
+	save   func(refreshToken string)
+
+	mu           sync.Mutex
+	refreshToken string
+}
+
+func (s *rotatedTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if token.RefreshToken != "" && token.RefreshToken != s.refreshToken {
+		s.refreshToken = token.RefreshToken
+		s.save(token.RefreshToken)
+	}
+
+	return token, nil
+}
+
+// saveRefreshToken stores the rotated refresh token of the connection, the token of the
+// open connection stays valid when storing it fails
+func (w *Weaviate) saveRefreshToken(connectionID int64, refreshToken string) {
+	if err := w.storage.UpdateRefreshToken(connectionID, refreshToken); err != nil {
+		slog.Warn(
+			"failed saving rotated refresh token",
+			slog.Int64("connectionID", connectionID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// getJSON decodes the response of a GET request into v and returns its status code
+func getJSON(ctx context.Context, httpClient *http.Client, url string, v any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return res.StatusCode, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return res.StatusCode, json.NewDecoder(res.Body).Decode(v)
+}
+
+// authType is the auth type of the connection, connections without an auth type
+// created before OIDC was supported authenticate with their api key
+func authType(c *models.Connection) string {
+	if c.AuthType == "" && c.ApiKey != nil && *c.ApiKey != "" {
+		return models.AuthAPIKey
+	}
+
+	return c.AuthType
+}
+
+func weaviateCloud(host string) bool {
+	host = strings.ToLower(host)
+	return strings.Contains(host, "weaviate.io") ||
+		strings.Contains(host, "semi.technology") ||
+		strings.Contains(host, "weaviate.cloud")
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,214 @@
+package weaviate
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/models"
+	"weaviate-desktop/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthentication(t *testing.T) {
+	connectionID := int64(1)
+
+	// newServer serves weaviate with an OIDC provider issuing a new access and refresh
+	// token on every token request, recording the authorization header of each weaviate
+	// request. The TLS server is returned with the path of its CA bundle.
+	newServer := func(t *testing.T, expiresIn int, authorizations *[]string, useTLS bool) (*httptest.Server, string) {
+		t.Helper()
+
+		var mu sync.Mutex
+		tokens := 0
+
+		var server *httptest.Server
+		server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch r.URL.Path {
+			case "/v1/.well-known/openid-configuration":
+				w.Write([]byte(`{"href":"` + server.URL + `/oidc","clientId":"mock-client"}`))
+			case "/oidc":
+				w.Write([]byte(`{"token_endpoint":"` + server.URL + `/token"}`))
+			case "/token":
+				require.NoError(t, r.ParseForm())
+				assert.Contains(
+					t,
+					[]string{"client_credentials", "password", "refresh_token"},
+					r.Form.Get("grant_type"),
+				)
+
+				tokens++
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(
+					w,
+					`{"access_token":"token-%d","refresh_token":"refresh-%d","token_type":"Bearer","expires_in":%d}`,
+					tokens,
+					tokens,
+					expiresIn,
+				)
+			default:
+				*authorizations = append(*authorizations, r.URL.Path+" "+r.Header.Get("Authorization"))
+				switch r.URL.Path {
+				case "/v1/meta":
+					w.Write([]byte(`{"version": "1.30.0"}`))
+				case "/v1/objects":
+					w.Write([]byte(`{"totalResults": 0, "objects": []}`))
+				default:
+					w.Write([]byte(`[]`))
+				}
+			}
+		}))
+		if !useTLS {
+			server.Start()
+			t.Cleanup(server.Close)
+			return server, ""
+		}
+		server.StartTLS()
+		t.Cleanup(server.Close)
+
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+
+		return server, caFile
+	}
+
+	t.Run("should use the client credentials token for every request", func(t *testing.T) {
+		authorizations := []string{}
+		server, _ := newServer(t, 3600, &authorizations, false)
+
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+		client, err := weaviate.getClientFromConnection(&models.Connection{
+			URI:          server.URL,
+			AuthType:     models.AuthClientCredentials,
+			ClientSecret: utils.Pointer("mock-secret"),
+		})
+		require.NoError(t, err)
+		weaviate.clients.add(connectionID, client)
+
+		_, err = weaviate.GetObjectsPage(connectionID, ObjectsPageInput{Collection: "Article", PageSize: 10})
+		require.NoError(t, err)
+		_, err = weaviate.GetReplicationOperations(connectionID, ReplicationOperationsInput{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"/v1/meta Bearer token-1",
+			"/v1/objects Bearer token-1",
+			"/v1/replication/replicate/list Bearer token-1",
+		}, authorizations)
+	})
+
+	t.Run("should refresh expired tokens", func(t *testing.T) {
+		authorizations := []string{}
+		// tokens expiring within the expiry delta of oauth2 are refreshed on every request
+		server, _ := newServer(t, 1, &authorizations, false)
+
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+		client, err := weaviate.getClientFromConnection(&models.Connection{
+			URI:      server.URL,
+			AuthType: models.AuthPassword,
+			Username: utils.Pointer("mock-user"),
+			Password: utils.Pointer("mock-password"),
+		})
+		require.NoError(t, err)
+		weaviate.clients.add(connectionID, client)
+
+		_, err = weaviate.GetObjectsPage(connectionID, ObjectsPageInput{Collection: "Article", PageSize: 10})
+		require.NoError(t, err)
+
+		require.Len(t, authorizations, 2)
+		assert.NotEqual(t, "/v1/meta Bearer token-1", authorizations[0])
+		assert.NotEqual(t, "/v1/objects Bearer token-1", authorizations[1])
+		assert.NotEqual(
+			t,
+			strings.TrimPrefix(authorizations[0], "/v1/meta "),
+			strings.TrimPrefix(authorizations[1], "/v1/objects "),
+		)
+	})
+
+	t.Run("should request the tokens through the transport of the connection", func(t *testing.T) {
+		authorizations := []string{}
+		// the OIDC provider is only trusted with the CA bundle of the connection
+		server, caFile := newServer(t, 3600, &authorizations, true)
+
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+		client, err := weaviate.getClientFromConnection(&models.Connection{
+			URI:          server.URL,
+			AuthType:     models.AuthClientCredentials,
+			ClientSecret: utils.Pointer("mock-secret"),
+			CACertFile:   utils.Pointer(caFile),
+		})
+		require.NoError(t, err)
+		weaviate.clients.add(connectionID, client)
+
+		_, err = weaviate.GetObjectsPage(connectionID, ObjectsPageInput{Collection: "Article", PageSize: 10})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"/v1/meta Bearer token-1",
+			"/v1/objects Bearer token-1",
+		}, authorizations)
+	})
+
+	t.Run("should save the rotated refresh token", func(t *testing.T) {
+		authorizations := []string{}
+		server, _ := newServer(t, 3600, &authorizations, false)
+
+		storage := NewMockStorage(t)
+		storage.EXPECT().UpdateRefreshToken(connectionID, "refresh-1").Return(nil).Once()
+
+		weaviate := New(storage, Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+		client, err := weaviate.getClientFromConnection(&models.Connection{
+			ID:           connectionID,
+			URI:          server.URL,
+			AuthType:     models.AuthBearerToken,
+			AccessToken:  utils.Pointer("mock-access"),
+			RefreshToken: utils.Pointer("mock-refresh"),
+		})
+		require.NoError(t, err)
+		weaviate.clients.add(connectionID, client)
+
+		_, err = weaviate.GetObjectsPage(connectionID, ObjectsPageInput{Collection: "Article", PageSize: 10})
+		require.NoError(t, err)
+
+		// the token of unknown expiry is refreshed once, the rotated refresh token is kept
+		assert.Equal(t, []string{
+			"/v1/meta Bearer token-1",
+			"/v1/objects Bearer token-1",
+		}, authorizations)
+	})
+
+	t.Run("should return error if secret is missing", func(t *testing.T) {
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+
+		client, err := weaviate.getClientFromConnection(&models.Connection{
+			URI:      "http://localhost:8080",
+			AuthType: models.AuthClientCredentials,
+		})
+
+		assert.Nil(t, client)
+		assert.EqualError(t, err, "client secret is required")
+	})
+}
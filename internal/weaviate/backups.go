@@ -11,7 +11,7 @@ import (
 )
 
 func (w *Weaviate) BackupModulesEnabled(connectionID int64) ([]string, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -54,7 +54,7 @@ type Backup struct {
 }
 
 func (w *Weaviate) ListBackups(connectionID int64, backends []string) ([]Backup, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -106,7 +106,7 @@ type CreateBackupInput struct {
 }
 
 func (w *Weaviate) CreateBackup(connectionID int64, input CreateBackupInput) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -161,7 +161,7 @@ func (w *Weaviate) GetCreationStatus(
 	connectionID int64,
 	input GetCreationStatusInput,
 ) (string, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return "", fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -182,7 +182,7 @@ func (w *Weaviate) GetCreationStatus(
 }
 
 func (w *Weaviate) CancelBackup(connectionID int64, backend, id string) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -212,7 +212,7 @@ type RestoreBackupInput struct {
 }
 
 func (w *Weaviate) RestoreBackup(connectionID int64, input RestoreBackupInput) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -265,7 +265,7 @@ func (w *Weaviate) GetRestoreStatus(
 	connectionID int64,
 	backend, id string,
 ) (StatusResponse, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return StatusResponse{}, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
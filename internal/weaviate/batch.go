@@ -0,0 +1,113 @@
+package weaviate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+// BatchObjectError reports an object of a batch that failed to be created.
+type BatchObjectError struct {
+	// Index is the position of the object in the batch
+	Index   int    `json:"index"`
+	ID      string `json:"id,omitempty"`
+	Message string `json:"message"`
+}
+
+// BatchCreateObjects creates the objects of the collection through the batch API.
+// Objects are validated against the schema of the collection first, which callers
+// sending many batches retrieve once. Objects failing validation are reported as
+// errors and are not sent to weaviate.
+func (w *Weaviate) BatchCreateObjects(
+	connectionID int64,
+	col *models.Class,
+	objects []ObjectInput,
+) ([]BatchObjectError, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	// NOTE: large batches on busy clusters can take a while
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	batchErrors := []BatchObjectError{}
+	payload := make([]*models.Object, 0, len(objects))
+	// indexes maps the position in the payload to the position in objects
+	indexes := make([]int, 0, len(objects))
+
+	for i, o := range objects {
+		err := validateObjectInput(col, o)
+		if o.Collection != col.Class {
+			err = fmt.Errorf("object belongs to %s instead of %s", o.Collection, col.Class)
+		}
+		if err != nil {
+			batchErrors = append(batchErrors, BatchObjectError{
+				Index:   i,
+				ID:      o.ID,
+				Message: err.Error(),
+			})
+			continue
+		}
+
+		object := &models.Object{
+			Class:      o.Collection,
+			Tenant:     o.Tenant,
+			Properties: o.Properties,
+		}
+		if o.ID != "" {
+			object.ID = strfmt.UUID(o.ID)
+		}
+		if len(o.Vector) > 0 {
+			object.Vector = o.Vector
+		}
+		if len(o.Vectors) > 0 {
+			object.Vectors = toModelVectors(o.Vectors)
+		}
+
+		payload = append(payload, object)
+		indexes = append(indexes, i)
+	}
+
+	if len(payload) == 0 {
+		return batchErrors, nil
+	}
+
+	var res []models.ObjectsGetResponse
+	var err error
+	if c.grpc != nil {
+		res, err = c.grpc.batchObjects(ctx, payload)
+	} else {
+		res, err = c.w.Batch().ObjectsBatcher().WithObjects(payload...).Do(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed batch creating objects: %w", err)
+	}
+
+	for i, r := range res {
+		if i >= len(indexes) || r.Result == nil || r.Result.Errors == nil {
+			continue
+		}
+		if len(r.Result.Errors.Error) == 0 {
+			continue
+		}
+
+		messages := make([]string, 0, len(r.Result.Errors.Error))
+		for _, e := range r.Result.Errors.Error {
+			messages = append(messages, e.Message)
+		}
+
+		batchErrors = append(batchErrors, BatchObjectError{
+			Index:   indexes[i],
+			ID:      r.ID.String(),
+			Message: strings.Join(messages, ", "),
+		})
+	}
+
+	return batchErrors, nil
+}
@@ -2,14 +2,21 @@ package weaviate
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/fault"
 	"github.com/weaviate/weaviate/entities/models"
 )
 
 func (w *Weaviate) GetCollection(connectionID int64, collection string) (*models.Class, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -26,7 +33,7 @@ func (w *Weaviate) GetCollection(connectionID int64, collection string) (*models
 }
 
 func (w *Weaviate) DeleteCollection(connectionID int64, collection string) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -40,3 +47,601 @@ func (w *Weaviate) DeleteCollection(connectionID int64, collection string) error
 
 	return nil
 }
+
+// PropertyInput describes a property, or a nested property when part of NestedProperties
+type PropertyInput struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	DataType    []string `json:"dataType"`
+	// Tokenization applies to text properties: word, lowercase, whitespace, field, trigram...
+	Tokenization      string `json:"tokenization,omitempty"`
+	IndexFilterable   *bool  `json:"indexFilterable,omitempty"`
+	IndexSearchable   *bool  `json:"indexSearchable,omitempty"`
+	IndexRangeFilters *bool  `json:"indexRangeFilters,omitempty"`
+	// ModuleConfig is keyed by module, e.g. {"text2vec-openai": {"skip": true}}
+	ModuleConfig     map[string]any  `json:"moduleConfig,omitempty"`
+	NestedProperties []PropertyInput `json:"nestedProperties,omitempty"`
+}
+
+// NamedVectorInput configures one of the named vectors of a collection
+type NamedVectorInput struct {
+	// Vectorizer is keyed by module, e.g. {"text2vec-openai": {"model": "..."}} or {"none": {}}
+	Vectorizer        map[string]any `json:"vectorizer"`
+	VectorIndexType   string         `json:"vectorIndexType,omitempty"`
+	VectorIndexConfig map[string]any `json:"vectorIndexConfig,omitempty"`
+}
+
+type InvertedIndexInput struct {
+	Bm25B                  *float32 `json:"bm25b,omitempty"`
+	Bm25K1                 *float32 `json:"bm25k1,omitempty"`
+	StopwordsPreset        string   `json:"stopwordsPreset,omitempty"`
+	StopwordsAdditions     []string `json:"stopwordsAdditions,omitempty"`
+	StopwordsRemovals      []string `json:"stopwordsRemovals,omitempty"`
+	CleanupIntervalSeconds int64    `json:"cleanupIntervalSeconds,omitempty"`
+	// the following can only be set when the collection is created
+	IndexTimestamps     bool `json:"indexTimestamps,omitempty"`
+	IndexNullState      bool `json:"indexNullState,omitempty"`
+	IndexPropertyLength bool `json:"indexPropertyLength,omitempty"`
+}
+
+type MultiTenancyInput struct {
+	Enabled              bool `json:"enabled"`
+	AutoTenantCreation   bool `json:"autoTenantCreation,omitempty"`
+	AutoTenantActivation bool `json:"autoTenantActivation,omitempty"`
+}
+
+type ShardingInput struct {
+	DesiredCount       int64 `json:"desiredCount,omitempty"`
+	VirtualPerPhysical int64 `json:"virtualPerPhysical,omitempty"`
+}
+
+type CollectionInput struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Properties  []PropertyInput `json:"properties"`
+	// Vectorizer, ModuleConfig and VectorIndex* configure the single (unnamed) vector,
+	// use VectorConfig for named vectors
+	Vectorizer        string                      `json:"vectorizer,omitempty"`
+	ModuleConfig      map[string]any              `json:"moduleConfig,omitempty"`
+	VectorIndexType   string                      `json:"vectorIndexType,omitempty"`
+	VectorIndexConfig map[string]any              `json:"vectorIndexConfig,omitempty"`
+	VectorConfig      map[string]NamedVectorInput `json:"vectorConfig,omitempty"`
+	InvertedIndex     *InvertedIndexInput         `json:"invertedIndex,omitempty"`
+	MultiTenancy      *MultiTenancyInput          `json:"multiTenancy,omitempty"`
+	ReplicationFactor int64                       `json:"replicationFactor,omitempty"`
+	AsyncReplication  bool                        `json:"asyncReplication,omitempty"`
+	Sharding          *ShardingInput              `json:"sharding,omitempty"`
+}
+
+// CollectionUpdateInput holds the settings that can be changed after a collection
+// is created, nil values are left untouched
+type CollectionUpdateInput struct {
+	Description   *string             `json:"description,omitempty"`
+	InvertedIndex *InvertedIndexInput `json:"invertedIndex,omitempty"`
+	// VectorIndexConfig is merged into the current config, e.g. {"ef": 128}
+	VectorIndexConfig map[string]any `json:"vectorIndexConfig,omitempty"`
+	// VectorIndexConfigs is merged into the config of each named vector
+	VectorIndexConfigs   map[string]map[string]any `json:"vectorIndexConfigs,omitempty"`
+	ReplicationFactor    *int64                    `json:"replicationFactor,omitempty"`
+	AsyncReplication     *bool                     `json:"asyncReplication,omitempty"`
+	AutoTenantCreation   *bool                     `json:"autoTenantCreation,omitempty"`
+	AutoTenantActivation *bool                     `json:"autoTenantActivation,omitempty"`
+}
+
+// FieldError is a validation error of a single field of the input
+type FieldError struct {
+	// Field is the path of the invalid field, e.g. properties[1].tokenization.
+	// It's empty when the error applies to the whole collection.
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// CollectionResult holds the collection as stored by weaviate or the validation errors
+// of the input, either the local ones or the ones returned by the server
+type CollectionResult struct {
+	Collection *models.Class `json:"collection,omitempty"`
+	Errors     []FieldError  `json:"errors,omitempty"`
+}
+
+func (w *Weaviate) CreateCollection(connectionID int64, input CollectionInput) (*CollectionResult, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	if errs := validateCollectionInput(input); len(errs) > 0 {
+		return &CollectionResult{Errors: errs}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := c.w.Schema().ClassCreator().WithClass(toClass(input)).Do(ctx)
+	if errs := toFieldErrors(err, input.Properties); len(errs) > 0 {
+		return &CollectionResult{Errors: errs}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed creating collection %s: %w", input.Name, err)
+	}
+
+	return w.collectionResult(ctx, c, input.Name)
+}
+
+func (w *Weaviate) AddProperty(
+	connectionID int64,
+	collection string,
+	input PropertyInput,
+) (*CollectionResult, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	if errs := validatePropertyInputs([]PropertyInput{input}, "properties"); len(errs) > 0 {
+		return &CollectionResult{Errors: errs}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := c.w.Schema().PropertyCreator().
+		WithClassName(collection).
+		WithProperty(toProperty(input)).
+		Do(ctx)
+	if errs := toFieldErrors(err, []PropertyInput{input}); len(errs) > 0 {
+		return &CollectionResult{Errors: errs}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed adding property %s to %s: %w", input.Name, collection, err)
+	}
+
+	return w.collectionResult(ctx, c, collection)
+}
+
+// UpdateCollection applies the mutable settings to the current collection config,
+// weaviate rejects changes to settings that are immutable
+func (w *Weaviate) UpdateCollection(
+	connectionID int64,
+	collection string,
+	input CollectionUpdateInput,
+) (*CollectionResult, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	class, err := c.w.Schema().ClassGetter().WithClassName(collection).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving collection %s: %w", collection, err)
+	}
+
+	if errs := applyCollectionUpdate(class, input); len(errs) > 0 {
+		return &CollectionResult{Errors: errs}, nil
+	}
+
+	err = c.w.Schema().ClassUpdater().WithClass(class).Do(ctx)
+	if errs := toFieldErrors(err, nil); len(errs) > 0 {
+		return &CollectionResult{Errors: errs}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed updating collection %s: %w", collection, err)
+	}
+
+	return w.collectionResult(ctx, c, collection)
+}
+
+// collectionResult retrieves the collection so the result includes the defaults set by weaviate
+func (w *Weaviate) collectionResult(
+	ctx context.Context,
+	c *WClient,
+	collection string,
+) (*CollectionResult, error) {
+	col, err := c.w.Schema().ClassGetter().WithClassName(collection).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving collection %s: %w", collection, err)
+	}
+
+	return &CollectionResult{Collection: col}, nil
+}
+
+var (
+	collectionNameRegex = regexp.MustCompile(`^[A-Z][_0-9A-Za-z]*$`)
+	propertyNameRegex   = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+	// matches the property weaviate complains about, e.g. property 'title': ...
+	propertyErrorRegex = regexp.MustCompile(`propert(?:y|ies)\s+['"]?([_A-Za-z][_0-9A-Za-z]*)['"]?`)
+
+	primitiveDataTypes = []string{
+		"text", "uuid", "date", "int", "number", "boolean", "blob",
+		"geoCoordinates", "phoneNumber", "object",
+	}
+	tokenizations = []string{
+		"word", "lowercase", "whitespace", "field", "trigram", "gse", "kagome_ja", "kagome_kr",
+	}
+)
+
+func validateCollectionInput(input CollectionInput) []FieldError {
+	var errs []FieldError
+
+	if !collectionNameRegex.MatchString(input.Name) {
+		errs = append(errs, FieldError{
+			Field:   "name",
+			Message: "must start with an uppercase letter and contain only letters, numbers and underscores",
+		})
+	}
+
+	errs = append(errs, validatePropertyInputs(input.Properties, "properties")...)
+
+	for name, v := range input.VectorConfig {
+		if !propertyNameRegex.MatchString(name) {
+			errs = append(errs, FieldError{
+				Field:   "vectorConfig." + name,
+				Message: "must contain only letters, numbers and underscores",
+			})
+		}
+		if len(v.Vectorizer) != 1 {
+			errs = append(errs, FieldError{
+				Field:   "vectorConfig." + name + ".vectorizer",
+				Message: `expects exactly one module, use {"none": {}} to bring your own vectors`,
+			})
+		}
+	}
+
+	if input.ReplicationFactor < 0 {
+		errs = append(errs, FieldError{Field: "replicationFactor", Message: "must be positive"})
+	}
+
+	if input.MultiTenancy != nil && input.MultiTenancy.Enabled && input.Sharding != nil {
+		errs = append(errs, FieldError{
+			Field:   "sharding",
+			Message: "can't be configured for multi-tenant collections",
+		})
+	}
+
+	return errs
+}
+
+//nolint:gocognit // validates every field of the property
+func validatePropertyInputs(props []PropertyInput, parent string) []FieldError {
+	var errs []FieldError
+	names := make(map[string]struct{}, len(props))
+
+	for i, p := range props {
+		field := fmt.Sprintf("%s[%d]", parent, i)
+
+		switch {
+		case !propertyNameRegex.MatchString(p.Name):
+			errs = append(errs, FieldError{
+				Field:   field + ".name",
+				Message: "must contain only letters, numbers and underscores",
+			})
+		default:
+			// property names are case insensitive in weaviate
+			if _, duplicate := names[strings.ToLower(p.Name)]; duplicate {
+				errs = append(errs, FieldError{
+					Field:   field + ".name",
+					Message: fmt.Sprintf("property %s is defined more than once", p.Name),
+				})
+			}
+			names[strings.ToLower(p.Name)] = struct{}{}
+		}
+
+		if len(p.DataType) == 0 {
+			errs = append(errs, FieldError{Field: field + ".dataType", Message: "is required"})
+			continue
+		}
+
+		dataType, _ := strings.CutSuffix(p.DataType[0], "[]")
+		isReference := isCrossReference(dataType)
+		if !isReference && !slices.Contains(primitiveDataTypes, dataType) {
+			errs = append(errs, FieldError{
+				Field:   field + ".dataType",
+				Message: fmt.Sprintf("unknown data type %s", p.DataType[0]),
+			})
+		}
+
+		if p.Tokenization != "" {
+			switch {
+			case dataType != "text":
+				errs = append(errs, FieldError{
+					Field:   field + ".tokenization",
+					Message: "can only be set on text properties",
+				})
+			case !slices.Contains(tokenizations, p.Tokenization):
+				errs = append(errs, FieldError{
+					Field: field + ".tokenization",
+					Message: fmt.Sprintf(
+						"unknown tokenization %s, expected one of %s",
+						p.Tokenization,
+						strings.Join(tokenizations, ", "),
+					),
+				})
+			}
+		}
+
+		if dataType == "object" {
+			if len(p.NestedProperties) == 0 {
+				errs = append(errs, FieldError{
+					Field:   field + ".nestedProperties",
+					Message: "object properties require at least one nested property",
+				})
+			}
+			errs = append(errs, validatePropertyInputs(p.NestedProperties, field+".nestedProperties")...)
+		} else if len(p.NestedProperties) > 0 {
+			errs = append(errs, FieldError{
+				Field:   field + ".nestedProperties",
+				Message: "can only be set on object properties",
+			})
+		}
+	}
+
+	return errs
+}
+
+func toClass(input CollectionInput) *models.Class {
+	class := &models.Class{
+		Class:           input.Name,
+		Description:     input.Description,
+		Properties:      make([]*models.Property, 0, len(input.Properties)),
+		Vectorizer:      input.Vectorizer,
+		VectorIndexType: input.VectorIndexType,
+	}
+
+	for _, p := range input.Properties {
+		class.Properties = append(class.Properties, toProperty(p))
+	}
+
+	// assigning nil maps would send null instead of omitting the fields
+	if len(input.ModuleConfig) > 0 {
+		class.ModuleConfig = input.ModuleConfig
+	}
+	if len(input.VectorIndexConfig) > 0 {
+		class.VectorIndexConfig = input.VectorIndexConfig
+	}
+
+	if len(input.VectorConfig) > 0 {
+		class.VectorConfig = make(map[string]models.VectorConfig, len(input.VectorConfig))
+		for name, v := range input.VectorConfig {
+			config := models.VectorConfig{
+				Vectorizer:      v.Vectorizer,
+				VectorIndexType: v.VectorIndexType,
+			}
+			if len(v.VectorIndexConfig) > 0 {
+				config.VectorIndexConfig = v.VectorIndexConfig
+			}
+			class.VectorConfig[name] = config
+		}
+	}
+
+	if input.InvertedIndex != nil {
+		class.InvertedIndexConfig = &models.InvertedIndexConfig{
+			IndexTimestamps:     input.InvertedIndex.IndexTimestamps,
+			IndexNullState:      input.InvertedIndex.IndexNullState,
+			IndexPropertyLength: input.InvertedIndex.IndexPropertyLength,
+		}
+		applyInvertedIndex(class.InvertedIndexConfig, input.InvertedIndex)
+	}
+
+	if input.MultiTenancy != nil {
+		class.MultiTenancyConfig = &models.MultiTenancyConfig{
+			Enabled:              input.MultiTenancy.Enabled,
+			AutoTenantCreation:   input.MultiTenancy.AutoTenantCreation,
+			AutoTenantActivation: input.MultiTenancy.AutoTenantActivation,
+		}
+	}
+
+	if input.ReplicationFactor > 0 || input.AsyncReplication {
+		class.ReplicationConfig = &models.ReplicationConfig{
+			Factor:       input.ReplicationFactor,
+			AsyncEnabled: input.AsyncReplication,
+		}
+	}
+
+	if input.Sharding != nil {
+		sharding := map[string]any{}
+		if input.Sharding.DesiredCount > 0 {
+			sharding["desiredCount"] = input.Sharding.DesiredCount
+		}
+		if input.Sharding.VirtualPerPhysical > 0 {
+			sharding["virtualPerPhysical"] = input.Sharding.VirtualPerPhysical
+		}
+		class.ShardingConfig = sharding
+	}
+
+	return class
+}
+
+func toProperty(input PropertyInput) *models.Property {
+	p := &models.Property{
+		Name:              input.Name,
+		Description:       input.Description,
+		DataType:          input.DataType,
+		Tokenization:      input.Tokenization,
+		IndexFilterable:   input.IndexFilterable,
+		IndexSearchable:   input.IndexSearchable,
+		IndexRangeFilters: input.IndexRangeFilters,
+	}
+	if len(input.ModuleConfig) > 0 {
+		p.ModuleConfig = input.ModuleConfig
+	}
+	for _, nested := range input.NestedProperties {
+		p.NestedProperties = append(p.NestedProperties, toNestedProperty(nested))
+	}
+
+	return p
+}
+
+func toNestedProperty(input PropertyInput) *models.NestedProperty {
+	p := &models.NestedProperty{
+		Name:              input.Name,
+		Description:       input.Description,
+		DataType:          input.DataType,
+		Tokenization:      input.Tokenization,
+		IndexFilterable:   input.IndexFilterable,
+		IndexSearchable:   input.IndexSearchable,
+		IndexRangeFilters: input.IndexRangeFilters,
+	}
+	for _, nested := range input.NestedProperties {
+		p.NestedProperties = append(p.NestedProperties, toNestedProperty(nested))
+	}
+
+	return p
+}
+
+func applyInvertedIndex(config *models.InvertedIndexConfig, input *InvertedIndexInput) {
+	if input.Bm25B != nil || input.Bm25K1 != nil {
+		if config.Bm25 == nil {
+			config.Bm25 = &models.BM25Config{B: 0.75, K1: 1.2}
+		}
+		if input.Bm25B != nil {
+			config.Bm25.B = *input.Bm25B
+		}
+		if input.Bm25K1 != nil {
+			config.Bm25.K1 = *input.Bm25K1
+		}
+	}
+
+	if input.StopwordsPreset != "" || input.StopwordsAdditions != nil || input.StopwordsRemovals != nil {
+		if config.Stopwords == nil {
+			config.Stopwords = &models.StopwordConfig{Preset: "en"}
+		}
+		if input.StopwordsPreset != "" {
+			config.Stopwords.Preset = input.StopwordsPreset
+		}
+		if input.StopwordsAdditions != nil {
+			config.Stopwords.Additions = input.StopwordsAdditions
+		}
+		if input.StopwordsRemovals != nil {
+			config.Stopwords.Removals = input.StopwordsRemovals
+		}
+	}
+
+	if input.CleanupIntervalSeconds > 0 {
+		config.CleanupIntervalSeconds = input.CleanupIntervalSeconds
+	}
+}
+
+//nolint:gocognit // every mutable setting is applied separately
+func applyCollectionUpdate(class *models.Class, input CollectionUpdateInput) []FieldError {
+	var errs []FieldError
+
+	if input.Description != nil {
+		class.Description = *input.Description
+	}
+
+	if input.InvertedIndex != nil {
+		if class.InvertedIndexConfig == nil {
+			class.InvertedIndexConfig = &models.InvertedIndexConfig{}
+		}
+		applyInvertedIndex(class.InvertedIndexConfig, input.InvertedIndex)
+	}
+
+	if len(input.VectorIndexConfig) > 0 {
+		current, _ := class.VectorIndexConfig.(map[string]any)
+		if current == nil {
+			current = map[string]any{}
+		}
+		class.VectorIndexConfig = mergeConfig(current, input.VectorIndexConfig)
+	}
+
+	for name, update := range input.VectorIndexConfigs {
+		vectorConfig, ok := class.VectorConfig[name]
+		if !ok {
+			errs = append(errs, FieldError{
+				Field:   "vectorIndexConfigs." + name,
+				Message: fmt.Sprintf("named vector %s does not exist in %s", name, class.Class),
+			})
+			continue
+		}
+
+		current, _ := vectorConfig.VectorIndexConfig.(map[string]any)
+		if current == nil {
+			current = map[string]any{}
+		}
+		vectorConfig.VectorIndexConfig = mergeConfig(current, update)
+		class.VectorConfig[name] = vectorConfig
+	}
+
+	if input.ReplicationFactor != nil || input.AsyncReplication != nil {
+		if class.ReplicationConfig == nil {
+			class.ReplicationConfig = &models.ReplicationConfig{}
+		}
+		if input.ReplicationFactor != nil {
+			if *input.ReplicationFactor < 1 {
+				errs = append(errs, FieldError{Field: "replicationFactor", Message: "must be at least 1"})
+			}
+			class.ReplicationConfig.Factor = *input.ReplicationFactor
+		}
+		if input.AsyncReplication != nil {
+			class.ReplicationConfig.AsyncEnabled = *input.AsyncReplication
+		}
+	}
+
+	if input.AutoTenantCreation != nil || input.AutoTenantActivation != nil {
+		if class.MultiTenancyConfig == nil || !class.MultiTenancyConfig.Enabled {
+			return append(errs, FieldError{
+				Field:   "multiTenancy",
+				Message: fmt.Sprintf("%s is not multi-tenant", class.Class),
+			})
+		}
+		if input.AutoTenantCreation != nil {
+			class.MultiTenancyConfig.AutoTenantCreation = *input.AutoTenantCreation
+		}
+		if input.AutoTenantActivation != nil {
+			class.MultiTenancyConfig.AutoTenantActivation = *input.AutoTenantActivation
+		}
+	}
+
+	return errs
+}
+
+// mergeConfig merges the src config into dst, nested objects are merged as well
+func mergeConfig(dst, src map[string]any) map[string]any {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]any)
+		dstMap, dstIsMap := dst[k].(map[string]any)
+		if srcIsMap && dstIsMap {
+			dst[k] = mergeConfig(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+
+	return dst
+}
+
+// toFieldErrors extracts the validation errors weaviate returns with a 422. The
+// errors are mapped to the properties they mention so the frontend can show them
+// next to the field.
+func toFieldErrors(err error, props []PropertyInput) []FieldError {
+	var clientErr *fault.WeaviateClientError
+	if !errors.As(err, &clientErr) || clientErr.StatusCode != http.StatusUnprocessableEntity {
+		return nil
+	}
+
+	var response models.ErrorResponse
+	if jsonErr := json.Unmarshal([]byte(clientErr.Msg), &response); jsonErr != nil ||
+		len(response.Error) == 0 {
+		return []FieldError{{Message: clientErr.Msg}}
+	}
+
+	errs := make([]FieldError, 0, len(response.Error))
+	for _, e := range response.Error {
+		fieldErr := FieldError{Message: e.Message}
+
+		if match := propertyErrorRegex.FindStringSubmatch(e.Message); match != nil {
+			i := slices.IndexFunc(props, func(p PropertyInput) bool {
+				return strings.EqualFold(p.Name, match[1])
+			})
+			if i >= 0 {
+				fieldErr.Field = fmt.Sprintf("properties[%d]", i)
+			}
+		}
+
+		errs = append(errs, fieldErr)
+	}
+
+	return errs
+}
@@ -0,0 +1,347 @@
+package weaviate
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestCollections(t *testing.T) {
+	connectionID := int64(1)
+	yes := true
+
+	// newWeaviate returns a weaviate with a client connected to the mock server
+	newWeaviate := func(t *testing.T, handler http.HandlerFunc) *Weaviate {
+		t.Helper()
+
+		mockServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/meta" {
+				w.Write([]byte(`{"version": "1.30.0"}`))
+				return
+			}
+			handler(w, r)
+		}))
+		t.Cleanup(mockServer.Close)
+
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+		client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+		require.NoError(t, err)
+		weaviate.clients.add(connectionID, client)
+
+		return weaviate
+	}
+
+	t.Run("validateCollectionInput", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			input    CollectionInput
+			expected []FieldError
+		}{
+			{
+				name: "should accept valid input",
+				input: CollectionInput{
+					Name: "Article",
+					Properties: []PropertyInput{
+						{Name: "title", DataType: []string{"text"}, Tokenization: "word"},
+						{Name: "author", DataType: []string{"Author"}},
+						{
+							Name:     "meta",
+							DataType: []string{"object[]"},
+							NestedProperties: []PropertyInput{
+								{Name: "source", DataType: []string{"text"}},
+							},
+						},
+					},
+					VectorConfig: map[string]NamedVectorInput{
+						"title_vector": {Vectorizer: map[string]any{"none": map[string]any{}}},
+					},
+				},
+			},
+			{
+				name: "should report every invalid field",
+				input: CollectionInput{
+					Name: "article",
+					Properties: []PropertyInput{
+						{Name: "title", DataType: []string{"text"}},
+						{Name: "Title", DataType: []string{"string"}},
+						{Name: "views", DataType: []string{"int"}, Tokenization: "word"},
+						{Name: "tags", DataType: []string{"text[]"}, Tokenization: "words"},
+						{Name: "meta", DataType: []string{"object"}},
+						{Name: "my-prop"},
+					},
+					MultiTenancy: &MultiTenancyInput{Enabled: true},
+					Sharding:     &ShardingInput{DesiredCount: 2},
+				},
+				expected: []FieldError{
+					{
+						Field:   "name",
+						Message: "must start with an uppercase letter and contain only letters, numbers and underscores",
+					},
+					{Field: "properties[1].name", Message: "property Title is defined more than once"},
+					{Field: "properties[1].dataType", Message: "unknown data type string"},
+					{Field: "properties[2].tokenization", Message: "can only be set on text properties"},
+					{
+						Field: "properties[3].tokenization",
+						Message: "unknown tokenization words, expected one of " +
+							"word, lowercase, whitespace, field, trigram, gse, kagome_ja, kagome_kr",
+					},
+					{
+						Field:   "properties[4].nestedProperties",
+						Message: "object properties require at least one nested property",
+					},
+					{
+						Field:   "properties[5].name",
+						Message: "must contain only letters, numbers and underscores",
+					},
+					{Field: "properties[5].dataType", Message: "is required"},
+					{Field: "sharding", Message: "can't be configured for multi-tenant collections"},
+				},
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				assert.Equal(t, tc.expected, validateCollectionInput(tc.input))
+			})
+		}
+	})
+
+	t.Run("CreateCollection", func(t *testing.T) {
+		t.Run("should create collection and return it with the server defaults", func(t *testing.T) {
+			weaviate := newWeaviate(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/v1/schema" && r.Method == http.MethodPost:
+					var class weaviate_models.Class
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&class))
+
+					assert.Equal(t, "Article", class.Class)
+					assert.Equal(t, "text2vec-openai", class.Vectorizer)
+					assert.Equal(t, "hnsw", class.VectorIndexType)
+					assert.Equal(t, map[string]any{"ef": float64(64)}, class.VectorIndexConfig)
+					assert.Equal(t, []*weaviate_models.Property{
+						{
+							Name:            "title",
+							DataType:        []string{"text"},
+							Tokenization:    "field",
+							IndexSearchable: &yes,
+							ModuleConfig: map[string]any{
+								"text2vec-openai": map[string]any{"skip": true},
+							},
+						},
+						{
+							Name:     "meta",
+							DataType: []string{"object"},
+							NestedProperties: []*weaviate_models.NestedProperty{
+								{Name: "source", DataType: []string{"text"}},
+							},
+						},
+					}, class.Properties)
+					assert.Equal(t, &weaviate_models.MultiTenancyConfig{
+						Enabled:            true,
+						AutoTenantCreation: true,
+					}, class.MultiTenancyConfig)
+					assert.Equal(t, &weaviate_models.ReplicationConfig{Factor: 3}, class.ReplicationConfig)
+					assert.Equal(t, float32(0.5), class.InvertedIndexConfig.Bm25.B)
+					assert.True(t, class.InvertedIndexConfig.IndexTimestamps)
+
+					json.NewEncoder(w).Encode(class)
+				case r.URL.Path == "/v1/schema/Article" && r.Method == http.MethodGet:
+					json.NewEncoder(w).Encode(weaviate_models.Class{
+						Class:             "Article",
+						VectorIndexConfig: map[string]any{"ef": 64, "efConstruction": 128},
+					})
+				default:
+					t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+					t.Fail()
+				}
+			})
+
+			bm25b := float32(0.5)
+			result, err := weaviate.CreateCollection(connectionID, CollectionInput{
+				Name: "Article",
+				Properties: []PropertyInput{
+					{
+						Name:            "title",
+						DataType:        []string{"text"},
+						Tokenization:    "field",
+						IndexSearchable: &yes,
+						ModuleConfig: map[string]any{
+							"text2vec-openai": map[string]any{"skip": true},
+						},
+					},
+					{
+						Name:             "meta",
+						DataType:         []string{"object"},
+						NestedProperties: []PropertyInput{{Name: "source", DataType: []string{"text"}}},
+					},
+				},
+				Vectorizer:        "text2vec-openai",
+				VectorIndexType:   "hnsw",
+				VectorIndexConfig: map[string]any{"ef": 64},
+				InvertedIndex:     &InvertedIndexInput{Bm25B: &bm25b, IndexTimestamps: true},
+				MultiTenancy:      &MultiTenancyInput{Enabled: true, AutoTenantCreation: true},
+				ReplicationFactor: 3,
+			})
+
+			require.NoError(t, err)
+			assert.Empty(t, result.Errors)
+			assert.Equal(t, &weaviate_models.Class{
+				Class: "Article",
+				VectorIndexConfig: map[string]any{
+					"ef":             float64(64),
+					"efConstruction": float64(128),
+				},
+			}, result.Collection)
+		})
+
+		t.Run("should return server validation errors per field", func(t *testing.T) {
+			weaviate := newWeaviate(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v1/schema" && r.Method == http.MethodPost {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					w.Write([]byte(`{"error": [
+						{"message": "property 'title': invalid tokenization for data type"},
+						{"message": "vectorizer: no module with name \"text2vec-mock\" present"}
+					]}`))
+					return
+				}
+
+				t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+				t.Fail()
+			})
+
+			result, err := weaviate.CreateCollection(connectionID, CollectionInput{
+				Name: "Article",
+				Properties: []PropertyInput{
+					{Name: "views", DataType: []string{"int"}},
+					{Name: "title", DataType: []string{"text"}},
+				},
+				Vectorizer: "text2vec-mock",
+			})
+
+			require.NoError(t, err)
+			assert.Nil(t, result.Collection)
+			assert.Equal(t, []FieldError{
+				{Field: "properties[1]", Message: "property 'title': invalid tokenization for data type"},
+				{Message: `vectorizer: no module with name "text2vec-mock" present`},
+			}, result.Errors)
+		})
+
+		t.Run("should not send request if validation fails", func(t *testing.T) {
+			weaviate := newWeaviate(t, func(w http.ResponseWriter, r *http.Request) {
+				t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+				t.Fail()
+			})
+
+			result, err := weaviate.CreateCollection(connectionID, CollectionInput{Name: "1Article"})
+
+			require.NoError(t, err)
+			assert.Nil(t, result.Collection)
+			assert.Len(t, result.Errors, 1)
+		})
+	})
+
+	t.Run("UpdateCollection", func(t *testing.T) {
+		t.Run("should merge mutable settings into the current config", func(t *testing.T) {
+			current := weaviate_models.Class{
+				Class:             "Article",
+				Description:       "mock-description",
+				VectorIndexConfig: map[string]any{"ef": 64, "pq": map[string]any{"enabled": false, "segments": 0}},
+				VectorConfig: map[string]weaviate_models.VectorConfig{
+					"title_vector": {
+						VectorIndexType:   "hnsw",
+						VectorIndexConfig: map[string]any{"ef": 32},
+					},
+				},
+				MultiTenancyConfig: &weaviate_models.MultiTenancyConfig{Enabled: true},
+				ReplicationConfig:  &weaviate_models.ReplicationConfig{Factor: 1},
+			}
+
+			updated := false
+			weaviate := newWeaviate(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/v1/schema/Article" && r.Method == http.MethodGet:
+					json.NewEncoder(w).Encode(current)
+				case r.URL.Path == "/v1/schema/Article" && r.Method == http.MethodPut:
+					var class weaviate_models.Class
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&class))
+
+					assert.Equal(t, "new-description", class.Description)
+					assert.Equal(t, map[string]any{
+						"ef": float64(128),
+						"pq": map[string]any{"enabled": true, "segments": float64(0)},
+					}, class.VectorIndexConfig)
+					assert.Equal(
+						t,
+						map[string]any{"ef": float64(256)},
+						class.VectorConfig["title_vector"].VectorIndexConfig,
+					)
+					assert.Equal(t, &weaviate_models.MultiTenancyConfig{
+						Enabled:              true,
+						AutoTenantActivation: true,
+					}, class.MultiTenancyConfig)
+					assert.Equal(t, int64(3), class.ReplicationConfig.Factor)
+					assert.Equal(t, []string{"mock"}, class.InvertedIndexConfig.Stopwords.Additions)
+
+					updated = true
+					json.NewEncoder(w).Encode(class)
+				default:
+					t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+					t.Fail()
+				}
+			})
+
+			description := "new-description"
+			factor := int64(3)
+			result, err := weaviate.UpdateCollection(connectionID, "Article", CollectionUpdateInput{
+				Description:       &description,
+				InvertedIndex:     &InvertedIndexInput{StopwordsAdditions: []string{"mock"}},
+				VectorIndexConfig: map[string]any{"ef": 128, "pq": map[string]any{"enabled": true}},
+				VectorIndexConfigs: map[string]map[string]any{
+					"title_vector": {"ef": 256},
+				},
+				ReplicationFactor:    &factor,
+				AutoTenantActivation: &yes,
+			})
+
+			require.NoError(t, err)
+			assert.Empty(t, result.Errors)
+			assert.True(t, updated)
+		})
+
+		t.Run("should reject updates of settings the collection doesn't have", func(t *testing.T) {
+			weaviate := newWeaviate(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v1/schema/Article" && r.Method == http.MethodGet {
+					json.NewEncoder(w).Encode(weaviate_models.Class{Class: "Article"})
+					return
+				}
+
+				t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+				t.Fail()
+			})
+
+			result, err := weaviate.UpdateCollection(connectionID, "Article", CollectionUpdateInput{
+				VectorIndexConfigs: map[string]map[string]any{"title_vector": {"ef": 256}},
+				AutoTenantCreation: &yes,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, []FieldError{
+				{
+					Field:   "vectorIndexConfigs.title_vector",
+					Message: "named vector title_vector does not exist in Article",
+				},
+				{Field: "multiTenancy", Message: "Article is not multi-tenant"},
+			}, result.Errors)
+		})
+	})
+}
@@ -0,0 +1,197 @@
+package weaviate
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+)
+
+// ClusterHealthEvent is emitted with the ClusterHealth of a connection when it connects
+// and when a check changes its status, version or error
+const ClusterHealthEvent = "cluster-health"
+
+// ClusterHealth is the health of a connection as of its last check
+type ClusterHealth struct {
+	ConnectionID int64 `json:"connectionID"`
+	Healthy      bool  `json:"healthy"`
+	// CheckedAt is the unix time of the check in milliseconds
+	CheckedAt int64 `json:"checkedAt"`
+	// LatencyMs is the duration of the meta request of the check
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+	// Version is the weaviate version reported by the meta endpoint
+	Version string `json:"version,omitempty"`
+}
+
+// clientRegistry holds the clients of the connected connections, it's shared by the
+// bound methods called concurrently by the frontend and the status updater
+type clientRegistry struct {
+	mu      sync.RWMutex
+	clients map[int64]*WClient
+}
+
+func newClientRegistry() *clientRegistry {
+	return &clientRegistry{clients: map[int64]*WClient{}}
+}
+
+func (r *clientRegistry) get(id int64) (*WClient, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, exists := r.clients[id]
+	return c, exists
+}
+
+// add registers the client unless the connection already has one, it returns
+// whether the client was added
+func (r *clientRegistry) add(id int64, c *WClient) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.clients[id]; exists {
+		return false
+	}
+	r.clients[id] = c
+
+	return true
+}
+
+func (r *clientRegistry) remove(id int64) (*WClient, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, exists := r.clients[id]
+	delete(r.clients, id)
+
+	return c, exists
+}
+
+// all returns a copy of the clients so they can be iterated without the lock
+func (r *clientRegistry) all() map[int64]*WClient {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return maps.Clone(r.clients)
+}
+
+func (c *WClient) currentHealth() ClusterHealth {
+	c.healthMu.RLock()
+	defer c.healthMu.RUnlock()
+
+	return c.health
+}
+
+func (c *WClient) setHealth(h ClusterHealth) {
+	c.healthMu.Lock()
+	defer c.healthMu.Unlock()
+
+	c.health = h
+}
+
+// probe requests the meta of weaviate, the SSH tunnel of the client is checked first
+// so a failed tunnel is reported right away
+func (c *WClient) probe(connectionID int64, timeout time.Duration) (ClusterHealth, error) {
+	health := ClusterHealth{ConnectionID: connectionID}
+
+	if err := c.tunnelErr(); err != nil {
+		health.Error = err.Error()
+		health.CheckedAt = time.Now().UnixMilli()
+		return health, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	meta, err := c.w.Misc().MetaGetter().Do(ctx)
+	health.LatencyMs = time.Since(start).Milliseconds()
+	health.CheckedAt = time.Now().UnixMilli()
+	if err != nil {
+		health.Error = err.Error()
+		return health, err
+	}
+
+	health.Healthy = true
+	health.Version = meta.Version
+
+	return health, nil
+}
+
+// updateClusterStatus checks the health of every connection on each tick until the
+// context is canceled, the checks run concurrently so a slow cluster doesn't delay
+// the others
+func (w *Weaviate) updateClusterStatus(ctx context.Context, d time.Duration) {
+	ticker := time.NewTicker(d)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		clients := w.clients.all()
+		if len(clients) == 0 {
+			continue
+		}
+
+		slog.Debug("running status updater", slog.Int("clientsConnected", len(clients)))
+
+		var wg sync.WaitGroup
+		for id, c := range clients {
+			wg.Go(func() { w.checkHealth(id, c) })
+		}
+		wg.Wait()
+	}
+}
+
+// checkHealth updates the health of the client and emits it when it changed, clients
+// disconnected while they were checked are skipped
+func (w *Weaviate) checkHealth(id int64, c *WClient) {
+	health, err := c.probe(id, 10*time.Second)
+	if err != nil {
+		slog.Error(
+			"failed querying status",
+			slog.Int64("connectionID", id),
+			slog.Any("error", err),
+		)
+	}
+
+	if current, exists := w.clients.get(id); !exists || current != c {
+		return
+	}
+
+	previous := c.currentHealth()
+	c.setHealth(health)
+
+	// only changes are pushed, the latest check is returned by ClusterHealth
+	if health.Healthy == previous.Healthy &&
+		health.Version == previous.Version &&
+		health.Error == previous.Error {
+		return
+	}
+	w.emit(ClusterHealthEvent, health)
+}
+
+// SetRuntimeContext sets the wails runtime context so we can emit events
+// to the frontend
+func (w *Weaviate) SetRuntimeContext(ctx context.Context) {
+	w.runtimeMu.Lock()
+	defer w.runtimeMu.Unlock()
+
+	w.runtimeCtx = ctx
+}
+
+func (w *Weaviate) emit(event string, data ...any) {
+	w.runtimeMu.RLock()
+	defer w.runtimeMu.RUnlock()
+
+	if w.runtimeCtx == nil {
+		return
+	}
+
+	eventsEmit(w.runtimeCtx, event, data...)
+}
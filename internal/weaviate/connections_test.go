@@ -0,0 +1,230 @@
+package weaviate
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnections(t *testing.T) {
+	// newServer serves weaviate, meta fails while failing is set
+	newServer := func(t *testing.T, failing *atomic.Bool) string {
+		t.Helper()
+
+		server := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/meta":
+				if failing.Load() {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Write([]byte(`{"version": "1.30.0"}`))
+			case "/v1/schema":
+				w.Write([]byte(`{"classes": []}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		return server.URL
+	}
+
+	// newWeaviate returns a weaviate checking the health every interval, the emitted
+	// health events are recorded
+	newWeaviate := func(
+		t *testing.T,
+		storage Storage,
+		interval time.Duration,
+	) (*Weaviate, func() []ClusterHealth) {
+		t.Helper()
+
+		var mu sync.Mutex
+		events := []ClusterHealth{}
+
+		emit := eventsEmit
+		eventsEmit = func(_ context.Context, event string, data ...any) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			assert.Equal(t, ClusterHealthEvent, event)
+			events = append(events, data[0].(ClusterHealth))
+		}
+
+		weaviate := New(storage, Configuration{StatusUpdateInterval: interval})
+		weaviate.SetRuntimeContext(context.Background())
+		t.Cleanup(func() {
+			weaviate.stopStatusUpdater()
+			eventsEmit = emit
+		})
+
+		return weaviate, func() []ClusterHealth {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return append([]ClusterHealth{}, events...)
+		}
+	}
+
+	t.Run("should handle concurrent connects, disconnects and queries", func(t *testing.T) {
+		uri := newServer(t, &atomic.Bool{})
+
+		storage := NewMockStorage(t)
+		storage.EXPECT().
+			GetConnection(mock.Anything, true).
+			Return(&models.Connection{URI: uri}, nil).
+			Maybe()
+
+		weaviate, _ := newWeaviate(t, storage, time.Millisecond)
+
+		var wg sync.WaitGroup
+		for worker := range 8 {
+			wg.Go(func() {
+				for i := range 20 {
+					id := int64((worker + i) % 3)
+
+					switch i % 4 {
+					case 0:
+						assert.NoError(t, weaviate.Connect(id))
+					case 1:
+						// the connection may have been disconnected by another worker
+						weaviate.GetCollections(id)
+						weaviate.ClusterStatus(id)
+					case 2:
+						weaviate.ClusterHealth(id)
+					case 3:
+						assert.NoError(t, weaviate.Disconnect(id))
+					}
+				}
+			})
+		}
+		wg.Wait()
+
+		for id := range int64(3) {
+			require.NoError(t, weaviate.Connect(id))
+			_, err := weaviate.GetCollections(id)
+			assert.NoError(t, err)
+		}
+		assert.Len(t, weaviate.clients.all(), 3)
+	})
+
+	t.Run("should keep a single client for concurrent connects", func(t *testing.T) {
+		uri := newServer(t, &atomic.Bool{})
+
+		storage := NewMockStorage(t)
+		storage.EXPECT().
+			GetConnection(int64(1), true).
+			Return(&models.Connection{URI: uri}, nil).
+			Maybe()
+
+		weaviate, events := newWeaviate(t, storage, time.Hour)
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() { assert.NoError(t, weaviate.Connect(1)) })
+		}
+		wg.Wait()
+
+		assert.Len(t, weaviate.clients.all(), 1)
+		// only the registered client emits its health
+		assert.Len(t, events(), 1)
+	})
+
+	t.Run("should emit the health when it changes", func(t *testing.T) {
+		failing := &atomic.Bool{}
+		uri := newServer(t, failing)
+
+		storage := NewMockStorage(t)
+		storage.EXPECT().
+			GetConnection(int64(1), true).
+			Return(&models.Connection{URI: uri}, nil)
+
+		weaviate, events := newWeaviate(t, storage, 10*time.Millisecond)
+
+		require.NoError(t, weaviate.Connect(1))
+
+		health, err := weaviate.ClusterHealth(1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), health.ConnectionID)
+		assert.True(t, health.Healthy)
+		assert.Equal(t, "1.30.0", health.Version)
+		assert.Empty(t, health.Error)
+		assert.NotZero(t, health.CheckedAt)
+		assert.GreaterOrEqual(t, health.LatencyMs, int64(0))
+
+		// checks with the same health aren't emitted
+		assert.Never(t, func() bool { return len(events()) > 1 }, 50*time.Millisecond, 10*time.Millisecond)
+
+		failing.Store(true)
+		require.Eventually(t, func() bool { return len(events()) == 2 }, 5*time.Second, 10*time.Millisecond)
+		assert.Never(t, func() bool { return len(events()) > 2 }, 50*time.Millisecond, 10*time.Millisecond)
+
+		e := events()
+		assert.True(t, e[0].Healthy)
+		assert.False(t, e[1].Healthy)
+		assert.Equal(t, int64(1), e[1].ConnectionID)
+		assert.NotEmpty(t, e[1].Error)
+
+		healthy, err := weaviate.ClusterStatus(1)
+		assert.NoError(t, err)
+		assert.False(t, healthy)
+
+		failing.Store(false)
+		require.Eventually(t, func() bool { return len(events()) == 3 }, 5*time.Second, 10*time.Millisecond)
+		assert.True(t, events()[2].Healthy)
+		assert.Empty(t, events()[2].Error)
+	})
+
+	t.Run("should stop emitting after disconnect", func(t *testing.T) {
+		failing := &atomic.Bool{}
+		uri := newServer(t, failing)
+
+		storage := NewMockStorage(t)
+		storage.EXPECT().
+			GetConnection(int64(1), true).
+			Return(&models.Connection{URI: uri}, nil)
+
+		weaviate, events := newWeaviate(t, storage, 5*time.Millisecond)
+
+		require.NoError(t, weaviate.Connect(1))
+		require.NoError(t, weaviate.Disconnect(1))
+
+		// a failing check would be emitted if the connection was still checked
+		failing.Store(true)
+		assert.Never(t, func() bool { return len(events()) > 1 }, 50*time.Millisecond, 5*time.Millisecond)
+
+		_, err := weaviate.ClusterHealth(1)
+		assert.EqualError(t, err, "connection doesn't exist 1")
+	})
+
+	t.Run("should stop checking and disconnect every connection on shutdown", func(t *testing.T) {
+		failing := &atomic.Bool{}
+		uri := newServer(t, failing)
+
+		storage := NewMockStorage(t)
+		storage.EXPECT().
+			GetConnection(mock.Anything, true).
+			Return(&models.Connection{URI: uri}, nil)
+
+		weaviate, events := newWeaviate(t, storage, 5*time.Millisecond)
+
+		require.NoError(t, weaviate.Connect(1))
+		require.NoError(t, weaviate.Connect(2))
+
+		weaviate.Shutdown()
+
+		assert.Empty(t, weaviate.clients.all())
+		failing.Store(true)
+		assert.Never(t, func() bool { return len(events()) > 2 }, 50*time.Millisecond, 5*time.Millisecond)
+	})
+}
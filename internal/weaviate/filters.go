@@ -0,0 +1,370 @@
+package weaviate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/filters"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+// Filter is a where filter built by the frontend.
+//
+// Operand filters (And, Or) only set Operands, every other operator sets Path and
+// Value. Value is a scalar for Equal, NotEqual, Like and the comparison operators,
+// an array for ContainsAny and ContainsAll, a boolean for IsNull and
+// {"latitude", "longitude", "distance"} for WithinGeoRange.
+type Filter struct {
+	Operator string    `json:"operator"`
+	Operands []*Filter `json:"operands,omitempty"`
+	// Path walks nested properties and cross references, e.g.
+	// ["address", "city"] or ["writtenBy", "Author", "name"]
+	Path  []string `json:"path,omitempty"`
+	Value any      `json:"value,omitempty"`
+}
+
+// schemaGetter retrieves collections referenced by cross reference paths
+type schemaGetter func(collection string) (*models.Class, error)
+
+// collectionSchemas returns a schemaGetter caching the collections it retrieves
+func collectionSchemas(ctx context.Context, c *WClient) schemaGetter {
+	cache := map[string]*models.Class{}
+
+	return func(collection string) (*models.Class, error) {
+		if col, ok := cache[collection]; ok {
+			return col, nil
+		}
+
+		col, err := c.w.Schema().ClassGetter().WithClassName(collection).Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed retrieving schema for %s: %w", collection, err)
+		}
+		cache[collection] = col
+
+		return col, nil
+	}
+}
+
+// filterTarget is the property a filter path resolves to
+type filterTarget struct {
+	path     string
+	dataType string
+}
+
+// metadata properties that can be filtered on besides the collection properties
+var filterMetadata = map[string]string{
+	"id":                  "uuid",
+	"_id":                 "uuid",
+	"_creationTimeUnix":   "timestamp",
+	"_lastUpdateTimeUnix": "timestamp",
+}
+
+// buildWhere validates the filter against the collection schema and converts it
+// to a where builder
+func buildWhere(f *Filter, col *models.Class, getSchema schemaGetter) (*filters.WhereBuilder, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	where, err := buildWhereFilter(f, col, getSchema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	return where, nil
+}
+
+//nolint:gocognit // flat switch over filter operators
+func buildWhereFilter(
+	f *Filter,
+	col *models.Class,
+	getSchema schemaGetter,
+) (*filters.WhereBuilder, error) {
+	operator := filters.WhereOperator(f.Operator)
+
+	switch operator {
+	case filters.And, filters.Or:
+		if len(f.Operands) == 0 {
+			return nil, fmt.Errorf("%s expects at least one operand", operator)
+		}
+
+		operands := make([]*filters.WhereBuilder, 0, len(f.Operands))
+		for _, o := range f.Operands {
+			operand, err := buildWhereFilter(o, col, getSchema)
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, operand)
+		}
+
+		return filters.Where().WithOperator(operator).WithOperands(operands), nil
+	case filters.Equal, filters.NotEqual, filters.Like,
+		filters.GreaterThan, filters.GreaterThanEqual, filters.LessThan, filters.LessThanEqual,
+		filters.ContainsAny, filters.ContainsAll, filters.WithinGeoRange, filters.IsNull:
+	default:
+		return nil, fmt.Errorf("unsupported operator %q", f.Operator)
+	}
+
+	if len(f.Operands) > 0 {
+		return nil, fmt.Errorf("%s doesn't accept operands", operator)
+	}
+
+	target, err := resolveFilterPath(f.Path, col, getSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	where := filters.Where().WithOperator(operator).WithPath(f.Path)
+
+	switch operator {
+	case filters.IsNull:
+		isNull, ok := f.Value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("IsNull on %q expects a boolean value", target.path)
+		}
+		return where.WithValueBoolean(isNull), nil
+	case filters.WithinGeoRange:
+		if target.dataType != "geoCoordinates" {
+			return nil, fmt.Errorf(
+				"WithinGeoRange is not supported on %q of type %s",
+				target.path,
+				target.dataType,
+			)
+		}
+		geoRange, err := toGeoRange(f.Value)
+		if err != nil {
+			return nil, fmt.Errorf("WithinGeoRange on %q %w", target.path, err)
+		}
+		return where.WithValueGeoRange(geoRange), nil
+	case filters.Like:
+		if target.dataType != "text" && target.dataType != "string" && target.dataType != "uuid" {
+			return nil, fmt.Errorf("Like is not supported on %q of type %s", target.path, target.dataType)
+		}
+	case filters.GreaterThan, filters.GreaterThanEqual, filters.LessThan, filters.LessThanEqual:
+		if target.dataType == "boolean" {
+			return nil, fmt.Errorf(
+				"%s is not supported on %q of type %s",
+				operator,
+				target.path,
+				target.dataType,
+			)
+		}
+	}
+
+	values := []any{f.Value}
+	if operator == filters.ContainsAny || operator == filters.ContainsAll {
+		items, ok := f.Value.([]any)
+		if !ok || len(items) == 0 {
+			return nil, fmt.Errorf("%s on %q expects a non empty array value", operator, target.path)
+		}
+		values = items
+	} else if _, isArray := f.Value.([]any); isArray {
+		return nil, fmt.Errorf(
+			"%s on %q expects a single value, use ContainsAny or ContainsAll for arrays",
+			operator,
+			target.path,
+		)
+	}
+
+	return withFilterValues(where, target, operator, values)
+}
+
+// resolveFilterPath walks the path through nested properties and cross references
+//
+//nolint:gocognit // walks three kinds of path segments
+func resolveFilterPath(
+	path []string,
+	col *models.Class,
+	getSchema schemaGetter,
+) (filterTarget, error) {
+	if len(path) == 0 {
+		return filterTarget{}, fmt.Errorf("path is required")
+	}
+
+	joined := strings.Join(path, ".")
+	if len(path) == 1 {
+		if dataType, ok := filterMetadata[path[0]]; ok {
+			return filterTarget{path: joined, dataType: dataType}, nil
+		}
+	}
+
+	definitions := propertyDefinitions(col.Properties)
+	owner := col.Class
+
+	for i := 0; i < len(path); i++ {
+		def, ok := definitions[path[i]]
+		if !ok {
+			return filterTarget{}, fmt.Errorf("property %q does not exist in %s", path[i], owner)
+		}
+
+		// filters on array properties match any of their elements
+		dataType, _ := strings.CutSuffix(def.dataType[0], "[]")
+		last := i == len(path)-1
+
+		switch {
+		case last:
+			if isCrossReference(dataType) {
+				dataType = "reference"
+			}
+			return filterTarget{path: joined, dataType: dataType}, nil
+		case isCrossReference(dataType):
+			// the segment after a reference is the target collection
+			target := path[i+1]
+			if !slices.Contains(def.dataType, target) {
+				return filterTarget{}, fmt.Errorf(
+					"reference %q of %s doesn't point to %s, expected one of %s",
+					path[i],
+					owner,
+					target,
+					strings.Join(def.dataType, ", "),
+				)
+			}
+			if i+1 == len(path)-1 {
+				return filterTarget{}, fmt.Errorf(
+					"path %q must end with a property of %s",
+					joined,
+					target,
+				)
+			}
+
+			targetCol, err := getSchema(target)
+			if err != nil {
+				return filterTarget{}, err
+			}
+
+			definitions = propertyDefinitions(targetCol.Properties)
+			owner = target
+			i++
+		case dataType == "object":
+			definitions = nestedPropertyDefinitions(def.nestedProperties)
+			owner = path[i]
+		default:
+			return filterTarget{}, fmt.Errorf(
+				"property %q of %s is a %s and has no nested properties",
+				path[i],
+				owner,
+				def.dataType[0],
+			)
+		}
+	}
+
+	return filterTarget{}, fmt.Errorf("path %q is incomplete", joined)
+}
+
+//nolint:gocognit // flat switch over weaviate data types
+func withFilterValues(
+	where *filters.WhereBuilder,
+	target filterTarget,
+	operator filters.WhereOperator,
+	values []any,
+) (*filters.WhereBuilder, error) {
+	invalid := func(expected string) error {
+		return fmt.Errorf("%s on %q expects %s values", operator, target.path, expected)
+	}
+
+	switch target.dataType {
+	case "text", "string", "uuid":
+		texts := make([]string, len(values))
+		for i, v := range values {
+			s, ok := v.(string)
+			if !ok {
+				return nil, invalid("text")
+			}
+			texts[i] = s
+		}
+		return where.WithValueText(texts...), nil
+	case "int":
+		ints := make([]int64, len(values))
+		for i, v := range values {
+			f, ok := toFloat(v)
+			if !ok || f != float64(int64(f)) {
+				return nil, invalid("integer")
+			}
+			ints[i] = int64(f)
+		}
+		return where.WithValueInt(ints...), nil
+	case "number":
+		numbers := make([]float64, len(values))
+		for i, v := range values {
+			f, ok := toFloat(v)
+			if !ok {
+				return nil, invalid("number")
+			}
+			numbers[i] = f
+		}
+		return where.WithValueNumber(numbers...), nil
+	case "boolean":
+		booleans := make([]bool, len(values))
+		for i, v := range values {
+			b, ok := v.(bool)
+			if !ok {
+				return nil, invalid("boolean")
+			}
+			booleans[i] = b
+		}
+		return where.WithValueBoolean(booleans...), nil
+	case "date":
+		dates := make([]time.Time, len(values))
+		for i, v := range values {
+			s, _ := v.(string)
+			d, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, invalid("RFC3339 date")
+			}
+			dates[i] = d
+		}
+		return where.WithValueDate(dates...), nil
+	case "timestamp":
+		// creation and update times are compared as unix milliseconds
+		timestamps := make([]string, len(values))
+		for i, v := range values {
+			if s, ok := v.(string); ok {
+				d, err := time.Parse(time.RFC3339Nano, s)
+				if err != nil {
+					return nil, invalid("RFC3339 date or unix milliseconds")
+				}
+				timestamps[i] = strconv.FormatInt(d.UnixMilli(), 10)
+				continue
+			}
+			f, ok := toFloat(v)
+			if !ok {
+				return nil, invalid("RFC3339 date or unix milliseconds")
+			}
+			timestamps[i] = strconv.FormatInt(int64(f), 10)
+		}
+		return where.WithValueText(timestamps...), nil
+	default:
+		return nil, fmt.Errorf(
+			"%s is not supported on %q of type %s",
+			operator,
+			target.path,
+			target.dataType,
+		)
+	}
+}
+
+func toGeoRange(value any) (*filters.GeoCoordinatesParameter, error) {
+	geo, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expects {\"latitude\", \"longitude\", \"distance\"}")
+	}
+
+	fields := make(map[string]float32, 3)
+	for _, field := range []string{"latitude", "longitude", "distance"} {
+		f, ok := toFloat(geo[field])
+		if !ok {
+			return nil, fmt.Errorf("expects a numeric %s", field)
+		}
+		fields[field] = float32(f)
+	}
+
+	return &filters.GeoCoordinatesParameter{
+		Latitude:    fields["latitude"],
+		Longitude:   fields["longitude"],
+		MaxDistance: fields["distance"],
+	}, nil
+}
@@ -0,0 +1,306 @@
+package weaviate
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestFilters(t *testing.T) {
+	connectionID := int64(1)
+	class := &weaviate_models.Class{
+		Class: "TestCollection",
+		Properties: []*weaviate_models.Property{
+			{Name: "title", DataType: []string{"text"}},
+			{Name: "views", DataType: []string{"int"}},
+			{Name: "rating", DataType: []string{"number"}},
+			{Name: "draft", DataType: []string{"boolean"}},
+			{Name: "tags", DataType: []string{"text[]"}},
+			{Name: "published", DataType: []string{"date"}},
+			{Name: "location", DataType: []string{"geoCoordinates"}},
+			{Name: "author", DataType: []string{"Author"}},
+			{
+				Name:     "meta",
+				DataType: []string{"object"},
+				NestedProperties: []*weaviate_models.NestedProperty{
+					{Name: "source", DataType: []string{"text"}},
+				},
+			},
+		},
+	}
+	author := &weaviate_models.Class{
+		Class: "Author",
+		Properties: []*weaviate_models.Property{
+			{Name: "name", DataType: []string{"text"}},
+		},
+	}
+	getSchema := func(collection string) (*weaviate_models.Class, error) {
+		if collection == "Author" {
+			return author, nil
+		}
+		return nil, errors.New("mock-error")
+	}
+
+	t.Run("buildWhere", func(t *testing.T) {
+		testCases := []struct {
+			name        string
+			filter      *Filter
+			expected    string
+			expectedErr string
+		}{
+			{
+				name: "should build operands with typed values",
+				filter: &Filter{
+					Operator: "And",
+					Operands: []*Filter{
+						{Operator: "Like", Path: []string{"title"}, Value: "mock*"},
+						{Operator: "GreaterThan", Path: []string{"views"}, Value: float64(10)},
+						{
+							Operator: "Or",
+							Operands: []*Filter{
+								{Operator: "Equal", Path: []string{"draft"}, Value: false},
+								{Operator: "LessThanEqual", Path: []string{"rating"}, Value: 4.5},
+							},
+						},
+					},
+				},
+				expected: `where:{operator: And operands:[` +
+					`{operator: Like path: ["title"] valueText: "mock*"},` +
+					`{operator: GreaterThan path: ["views"] valueInt: 10},` +
+					`{operator: Or operands:[` +
+					`{operator: Equal path: ["draft"] valueBoolean: false},` +
+					`{operator: LessThanEqual path: ["rating"] valueNumber: 4.5}]}]}`,
+			},
+			{
+				name:     "should build ContainsAny on array property",
+				filter:   &Filter{Operator: "ContainsAny", Path: []string{"tags"}, Value: []any{"a", "b"}},
+				expected: `where:{operator: ContainsAny path: ["tags"] valueText: ["a","b"]}`,
+			},
+			{
+				name: "should build date filter",
+				filter: &Filter{
+					Operator: "GreaterThanEqual",
+					Path:     []string{"published"},
+					Value:    "2025-01-02T15:04:05Z",
+				},
+				expected: `where:{operator: GreaterThanEqual path: ["published"] valueDate: "2025-01-02T15:04:05Z"}`,
+			},
+			{
+				name: "should build WithinGeoRange",
+				filter: &Filter{
+					Operator: "WithinGeoRange",
+					Path:     []string{"location"},
+					Value:    map[string]any{"latitude": 52.5, "longitude": 13.4, "distance": 1000},
+				},
+				expected: `where:{operator: WithinGeoRange path: ["location"] ` +
+					`valueGeoRange: {geoCoordinates:{latitude:52.5,longitude:13.4},distance:{max:1000}}}`,
+			},
+			{
+				name:     "should build IsNull",
+				filter:   &Filter{Operator: "IsNull", Path: []string{"author"}, Value: true},
+				expected: `where:{operator: IsNull path: ["author"] valueBoolean: true}`,
+			},
+			{
+				name:     "should walk nested properties",
+				filter:   &Filter{Operator: "Equal", Path: []string{"meta", "source"}, Value: "mock"},
+				expected: `where:{operator: Equal path: ["meta","source"] valueText: "mock"}`,
+			},
+			{
+				name: "should walk cross references",
+				filter: &Filter{
+					Operator: "Equal",
+					Path:     []string{"author", "Author", "name"},
+					Value:    "mock",
+				},
+				expected: `where:{operator: Equal path: ["author","Author","name"] valueText: "mock"}`,
+			},
+			{
+				name:     "should filter on metadata",
+				filter:   &Filter{Operator: "Equal", Path: []string{"id"}, Value: "mock-id"},
+				expected: `where:{operator: Equal path: ["id"] valueText: "mock-id"}`,
+			},
+			{
+				name:        "should reject unknown property",
+				filter:      &Filter{Operator: "Equal", Path: []string{"titel"}, Value: "mock"},
+				expectedErr: `invalid filter: property "titel" does not exist in TestCollection`,
+			},
+			{
+				name:        "should reject unknown nested property",
+				filter:      &Filter{Operator: "Equal", Path: []string{"meta", "other"}, Value: "mock"},
+				expectedErr: `invalid filter: property "other" does not exist in meta`,
+			},
+			{
+				name: "should reject reference to another collection",
+				filter: &Filter{
+					Operator: "Equal",
+					Path:     []string{"author", "Publisher", "name"},
+					Value:    "mock",
+				},
+				expectedErr: `invalid filter: reference "author" of TestCollection doesn't point to Publisher, expected one of Author`,
+			},
+			{
+				name:        "should reject unknown operator",
+				filter:      &Filter{Operator: "Between", Path: []string{"views"}, Value: 1},
+				expectedErr: `invalid filter: unsupported operator "Between"`,
+			},
+			{
+				name:        "should reject Like on non text property",
+				filter:      &Filter{Operator: "Like", Path: []string{"views"}, Value: "1*"},
+				expectedErr: `invalid filter: Like is not supported on "views" of type int`,
+			},
+			{
+				name:        "should reject value of the wrong type",
+				filter:      &Filter{Operator: "Equal", Path: []string{"views"}, Value: 1.5},
+				expectedErr: `invalid filter: Equal on "views" expects integer values`,
+			},
+			{
+				name:        "should reject ContainsAny without array",
+				filter:      &Filter{Operator: "ContainsAny", Path: []string{"tags"}, Value: "a"},
+				expectedErr: `invalid filter: ContainsAny on "tags" expects a non empty array value`,
+			},
+			{
+				name:        "should reject WithinGeoRange on non geo property",
+				filter:      &Filter{Operator: "WithinGeoRange", Path: []string{"title"}, Value: map[string]any{}},
+				expectedErr: `invalid filter: WithinGeoRange is not supported on "title" of type text`,
+			},
+			{
+				name:        "should reject empty operands",
+				filter:      &Filter{Operator: "Or"},
+				expectedErr: `invalid filter: Or expects at least one operand`,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				where, err := buildWhere(tc.filter, class, getSchema)
+
+				if tc.expectedErr != "" {
+					assert.Nil(t, where)
+					assert.EqualError(t, err, tc.expectedErr)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, where.String())
+			})
+		}
+	})
+
+	t.Run("GetObjectsFiltered", func(t *testing.T) {
+		t.Run("should query with where and offset and return objects with vectors", func(t *testing.T) {
+			mockServer := http_util.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case r.URL.Path == "/v1/schema/TestCollection" && r.Method == http.MethodGet:
+						json.NewEncoder(w).Encode(class)
+					case r.URL.Path == "/v1/graphql" && r.Method == http.MethodPost:
+						body, err := io.ReadAll(r.Body)
+						require.NoError(t, err)
+
+						var query struct{ Query string }
+						require.NoError(t, json.Unmarshal(body, &query))
+						assert.Contains(
+							t,
+							query.Query,
+							`TestCollection (tenant: "mock-tenant", `+
+								`where:{operator: Equal path: ["views"] valueInt: 1}, limit: 2, offset: 4)`,
+						)
+						assert.Contains(t, query.Query, "_additional{creationTimeUnix id lastUpdateTimeUnix vector}")
+
+						w.Write([]byte(`{"data": {"Get": {"TestCollection": [{
+							"title": "mock-title",
+							"_additional": {
+								"id": "mock-id",
+								"creationTimeUnix": "1",
+								"lastUpdateTimeUnix": "2",
+								"vector": [0.5, 1]
+							}
+						}]}}}`))
+					case r.URL.Path == "/v1/meta":
+						w.Write([]byte(`{"version": "1.30.0"}`))
+					default:
+						t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+						t.Fail()
+					}
+				}),
+			)
+			t.Cleanup(mockServer.Close)
+
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+			client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+			require.NoError(t, err)
+			weaviate.clients.add(connectionID, client)
+
+			result, err := weaviate.GetObjectsFiltered(connectionID, FilteredObjectsInput{
+				Collection:    "TestCollection",
+				Tenant:        "mock-tenant",
+				Filter:        &Filter{Operator: "Equal", Path: []string{"views"}, Value: float64(1)},
+				Offset:        4,
+				Limit:         2,
+				IncludeVector: true,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, []WeaviateObject{
+				{
+					ID:                 "mock-id",
+					Class:              "TestCollection",
+					CreationTimeUnix:   1,
+					LastUpdateTimeUnix: 2,
+					Properties:         map[string]any{"title": "mock-title"},
+					Vector:             []float32{0.5, 1},
+				},
+			}, result.Objects)
+		})
+	})
+
+	t.Run("GetTotalObjects", func(t *testing.T) {
+		t.Run("should not query if filter is invalid", func(t *testing.T) {
+			mockServer := http_util.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case r.URL.Path == "/v1/schema/TestCollection" && r.Method == http.MethodGet:
+						json.NewEncoder(w).Encode(class)
+						return
+					case r.URL.Path == "/v1/meta":
+						w.Write([]byte(`{"version": "1.30.0"}`))
+						return
+					}
+
+					t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+					t.Fail()
+				}),
+			)
+			t.Cleanup(mockServer.Close)
+
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+			client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+			require.NoError(t, err)
+			weaviate.clients.add(connectionID, client)
+
+			total, err := weaviate.GetTotalObjects(
+				connectionID,
+				"TestCollection",
+				"",
+				&Filter{Operator: "Equal", Path: []string{"titel"}, Value: "mock"},
+			)
+
+			assert.Equal(t, int64(-1), total)
+			assert.EqualError(t, err, `invalid filter: property "titel" does not exist in TestCollection`)
+		})
+	})
+}
@@ -0,0 +1,284 @@
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
+	"github.com/weaviate/weaviate/entities/models"
+	pb "github.com/weaviate/weaviate/grpc/generated/protocol/v1"
+)
+
+// GenerativeOptions generates text from the search results with the generative module
+// of the collection, per object with a prompt and once over all results with a task.
+type GenerativeOptions struct {
+	// SinglePrompt is sent per object, properties are inserted with {property}.
+	SinglePrompt string
+	// GroupedTask is sent once with all the results.
+	GroupedTask string
+	// GroupedProperties are the properties sent with the grouped task (default all).
+	GroupedProperties []string
+	// Provider overrides the generative module of the collection, e.g. "openai".
+	Provider string
+	// Model overrides the model of the provider.
+	Model string
+}
+
+// maxSinglePromptLimit is the most results of a search with a single prompt, the
+// model of the generative module is called for each of them
+const maxSinglePromptLimit = 25
+
+// generativeProviderMinVersion is the first weaviate version choosing the generative
+// provider and model per search
+var generativeProviderMinVersion = semver.MustParse("1.30.0")
+
+// generativeProviders are the providers which can be chosen per search by the name of
+// their module without the "generative-" prefix, with the model set for gRPC
+var generativeProviders = map[string]func(model *string) *pb.GenerativeProvider{
+	"anthropic": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Anthropic{
+			Anthropic: &pb.GenerativeAnthropic{Model: model},
+		}}
+	},
+	"anyscale": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Anyscale{
+			Anyscale: &pb.GenerativeAnyscale{Model: model},
+		}}
+	},
+	"aws": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Aws{
+			Aws: &pb.GenerativeAWS{Model: model},
+		}}
+	},
+	"cohere": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Cohere{
+			Cohere: &pb.GenerativeCohere{Model: model},
+		}}
+	},
+	"contextualai": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Contextualai{
+			Contextualai: &pb.GenerativeContextualAI{Model: model},
+		}}
+	},
+	"databricks": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Databricks{
+			Databricks: &pb.GenerativeDatabricks{Model: model},
+		}}
+	},
+	"friendliai": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Friendliai{
+			Friendliai: &pb.GenerativeFriendliAI{Model: model},
+		}}
+	},
+	"google": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Google{
+			Google: &pb.GenerativeGoogle{Model: model},
+		}}
+	},
+	"mistral": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Mistral{
+			Mistral: &pb.GenerativeMistral{Model: model},
+		}}
+	},
+	"nvidia": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Nvidia{
+			Nvidia: &pb.GenerativeNvidia{Model: model},
+		}}
+	},
+	"ollama": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Ollama{
+			Ollama: &pb.GenerativeOllama{Model: model},
+		}}
+	},
+	"openai": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Openai{
+			Openai: &pb.GenerativeOpenAI{Model: model},
+		}}
+	},
+	"xai": func(model *string) *pb.GenerativeProvider {
+		return &pb.GenerativeProvider{Kind: &pb.GenerativeProvider_Xai{
+			Xai: &pb.GenerativeXAI{Model: model},
+		}}
+	},
+}
+
+// checkGenerative validates the options against the collection before searching and
+// returns the chosen provider, empty when the module of the collection is used
+func checkGenerative(opts *GenerativeOptions, col *models.Class, version string) (string, error) {
+	if opts.SinglePrompt == "" && opts.GroupedTask == "" {
+		return "", errors.New("a single prompt or a grouped task is required for generative search")
+	}
+
+	for _, name := range opts.GroupedProperties {
+		if !slices.ContainsFunc(col.Properties, func(p *models.Property) bool { return p.Name == name }) {
+			return "", fmt.Errorf("property %q does not exist in %s", name, col.Class)
+		}
+	}
+
+	if opts.Provider == "" && opts.Model == "" {
+		return "", nil
+	}
+
+	if v, err := semver.NewVersion(version); err == nil && v.LessThan(generativeProviderMinVersion) {
+		return "", fmt.Errorf(
+			"choosing the generative provider requires weaviate %s or later, got %s",
+			generativeProviderMinVersion, version,
+		)
+	}
+
+	provider := strings.TrimPrefix(opts.Provider, "generative-")
+	if provider == "" {
+		// only the model is chosen, it belongs to the module of the collection
+		provider = collectionGenerativeModule(col)
+		if provider == "" {
+			return "", fmt.Errorf("a generative provider is required to choose the model, %s has no generative module", col.Class)
+		}
+	}
+	if _, ok := generativeProviders[provider]; !ok {
+		return "", fmt.Errorf("unsupported generative provider %q", provider)
+	}
+
+	return provider, nil
+}
+
+// collectionGenerativeModule is the generative module configured in the module config
+// of the collection without the "generative-" prefix
+func collectionGenerativeModule(col *models.Class) string {
+	config, _ := col.ModuleConfig.(map[string]any)
+	for _, name := range slices.Sorted(maps.Keys(config)) {
+		if provider, ok := strings.CutPrefix(name, "generative-"); ok {
+			return provider
+		}
+	}
+
+	return ""
+}
+
+// generativeField requests the generated text like the GenerativeSearchBuilder of the go
+// client, which neither escapes the prompts nor allows choosing the provider
+func generativeField(opts *GenerativeOptions, provider string) graphql.Field {
+	providerArg := ""
+	if provider != "" {
+		model := ""
+		if opts.Model != "" {
+			model = "model: " + gqlQuote(opts.Model)
+		}
+		providerArg = fmt.Sprintf(" %s: {%s}", provider, model)
+	}
+
+	args := []string{}
+	fields := []graphql.Field{}
+	if opts.SinglePrompt != "" {
+		args = append(args, fmt.Sprintf("singleResult: {prompt: %s%s}", gqlQuote(opts.SinglePrompt), providerArg))
+		fields = append(fields, graphql.Field{Name: "singleResult"})
+	}
+	if opts.GroupedTask != "" {
+		properties := ""
+		if len(opts.GroupedProperties) > 0 {
+			names, _ := json.Marshal(opts.GroupedProperties)
+			properties = fmt.Sprintf(" properties: %s", names)
+		}
+		args = append(args, fmt.Sprintf(
+			"groupedResult: {task: %s%s%s}",
+			gqlQuote(opts.GroupedTask), properties, providerArg,
+		))
+		fields = append(fields, graphql.Field{Name: "groupedResult"})
+	}
+
+	return graphql.Field{
+		Name:   fmt.Sprintf("generate(%s)", strings.Join(args, " ")),
+		Fields: append(fields, graphql.Field{Name: "error"}),
+	}
+}
+
+// gqlQuote quotes the value as GraphQL string, the escapes of JSON are valid GraphQL
+func gqlQuote(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
+
+// gqlGroupedResult is the result of the grouped task, weaviate returns it with the
+// first object
+func gqlGroupedResult(objects []any) string {
+	for _, obj := range objects {
+		objMap, _ := obj.(map[string]any)
+		additional, _ := objMap["_additional"].(map[string]any)
+		generate, _ := additional["generate"].(map[string]any)
+		if result, ok := generate["groupedResult"].(string); ok && result != "" {
+			return result
+		}
+	}
+
+	return ""
+}
+
+// grpcGenerative is the generative search of the options, the provider is only sent
+// when it is chosen as weaviate falls back to the module of the collection
+func grpcGenerative(opts *GenerativeOptions, provider string) *pb.GenerativeSearch {
+	var queries []*pb.GenerativeProvider
+	if provider != "" {
+		var model *string
+		if opts.Model != "" {
+			model = &opts.Model
+		}
+		queries = []*pb.GenerativeProvider{generativeProviders[provider](model)}
+	}
+
+	generative := &pb.GenerativeSearch{}
+	if opts.SinglePrompt != "" {
+		generative.Single = &pb.GenerativeSearch_Single{Prompt: opts.SinglePrompt, Queries: queries}
+	}
+	if opts.GroupedTask != "" {
+		generative.Grouped = &pb.GenerativeSearch_Grouped{Task: opts.GroupedTask, Queries: queries}
+		if len(opts.GroupedProperties) > 0 {
+			generative.Grouped.Properties = &pb.TextArray{Values: opts.GroupedProperties}
+		}
+	}
+
+	return generative
+}
+
+// gqlGenerativeSearch runs a generative GraphQL search. Generating the results may take
+// longer than the timeout of the http client, the search is only bounded by ctx.
+func (c *WClient) gqlGenerativeSearch(ctx context.Context, query string) (*models.GraphQLResponse, error) {
+	body, err := json.Marshal(models.GraphQLQuery{Query: query})
+	if err != nil {
+		return nil, fmt.Errorf("failed marshalling query: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/graphql", nil, body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := *c.http
+	httpClient.Timeout = 0
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code: %d, error: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var result models.GraphQLResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed un-marshalling response %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,182 @@
+package weaviate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestGenerativeSearch(t *testing.T) {
+	connectionID := int64(1)
+	class := &weaviate_models.Class{
+		Class: "Article",
+		Properties: []*weaviate_models.Property{
+			{Name: "title", DataType: []string{"text"}},
+			{Name: "body", DataType: []string{"text"}},
+		},
+		ModuleConfig: map[string]any{
+			"text2vec-openai":   map[string]any{},
+			"generative-cohere": map[string]any{"model": "command-r"},
+		},
+	}
+
+	search := searchTest{
+		connectionID: connectionID,
+		classes:      []*weaviate_models.Class{class},
+	}
+
+	t.Run("should return generated text and errors per object", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{"data": {"Get": {"Article": [
+			{"title": "first", "_additional": {
+				"id": "00000000-0000-0000-0000-000000000001",
+				"creationTimeUnix": "1", "lastUpdateTimeUnix": "2",
+				"generate": {"singleResult": "summary", "groupedResult": "in common", "error": null}
+			}},
+			{"title": "second", "_additional": {
+				"id": "00000000-0000-0000-0000-000000000002",
+				"creationTimeUnix": "1", "lastUpdateTimeUnix": "2",
+				"generate": {"singleResult": null, "groupedResult": null, "error": "rate limited"}
+			}}
+		]}}}`, &queries, nil)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "nearText", "databases", SearchOptions{
+			Generative: &GenerativeOptions{
+				SinglePrompt:      `Summarize "{title}"`,
+				GroupedTask:       "What do they have in common?",
+				GroupedProperties: []string{"title", "body"},
+				Model:             "command-r-plus",
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], `generate(`+
+			`singleResult: {prompt: "Summarize \"{title}\"" cohere: {model: "command-r-plus"}} `+
+			`groupedResult: {task: "What do they have in common?" properties: ["title","body"] `+
+			`cohere: {model: "command-r-plus"}}`+
+			`){singleResult groupedResult error}`)
+
+		require.Len(t, res.Objects, 2)
+		assert.Equal(t, "summary", res.Objects[0].Generated)
+		assert.Empty(t, res.Objects[0].GenerativeError)
+		assert.Empty(t, res.Objects[1].Generated)
+		assert.Equal(t, "rate limited", res.Objects[1].GenerativeError)
+		assert.Equal(t, "in common", res.GeneratedGrouped)
+		assert.NotContains(t, res.Objects[0].Properties, "_additional")
+	})
+
+	t.Run("should use the generative module of the collection by default", func(t *testing.T) {
+		queries := []string{}
+		old := search
+		old.version = "1.25.0"
+		weaviate := newSearchTestWeaviate(t, old, `{"data": {"Get": {"Article": []}}}`, &queries, nil)
+
+		_, err := weaviate.Search(connectionID, "Article", "", "bm25", "databases", SearchOptions{
+			Generative: &GenerativeOptions{SinglePrompt: "Summarize {title}"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], `generate(singleResult: {prompt: "Summarize {title}"}){singleResult error}`)
+	})
+
+	t.Run("should search with the generative timeout through grpc", func(t *testing.T) {
+		server := newGRPCServer(t)
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &[]string{}, server)
+
+		_, err := weaviate.Search(connectionID, "Article", "", "bm25", "databases", SearchOptions{})
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(searchTimeout), server.lastDeadline(), time.Second)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "bm25", "databases", SearchOptions{
+			Generative: &GenerativeOptions{SinglePrompt: "Summarize {title}"},
+		})
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(generativeSearchTimeout), server.lastDeadline(), time.Second)
+		assert.Equal(t, "single", res.Objects[0].Generated)
+	})
+
+	t.Run("should cap the limit of single prompt searches", func(t *testing.T) {
+		server := newGRPCServer(t)
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &[]string{}, server)
+
+		_, err := weaviate.Search(connectionID, "Article", "", "bm25", "databases", SearchOptions{
+			Generative: &GenerativeOptions{SinglePrompt: "Summarize {title}"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(maxSinglePromptLimit), server.lastSearch().Limit)
+
+		// the grouped task is generated once for all the results
+		_, err = weaviate.Search(connectionID, "Article", "", "bm25", "databases", SearchOptions{
+			Generative: &GenerativeOptions{GroupedTask: "What do they have in common?"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), server.lastSearch().Limit)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "bm25", "databases", SearchOptions{
+			Limit:      100,
+			Generative: &GenerativeOptions{SinglePrompt: "Summarize {title}"},
+		})
+		assert.Nil(t, res)
+		assert.EqualError(
+			t,
+			err,
+			"a single prompt generates text for each result, the limit of 100 exceeds the maximum of 25",
+		)
+	})
+
+	t.Run("should not search if generative options are invalid", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			version  string
+			opts     GenerativeOptions
+			expected string
+		}{
+			{
+				name:     "missing prompt and task",
+				version:  "1.30.0",
+				opts:     GenerativeOptions{Provider: "openai"},
+				expected: "a single prompt or a grouped task is required for generative search",
+			},
+			{
+				name:     "unknown grouped property",
+				version:  "1.30.0",
+				opts:     GenerativeOptions{GroupedTask: "task", GroupedProperties: []string{"author"}},
+				expected: `property "author" does not exist in Article`,
+			},
+			{
+				name:     "unknown provider",
+				version:  "1.30.0",
+				opts:     GenerativeOptions{SinglePrompt: "prompt", Provider: "generative-unknown"},
+				expected: `unsupported generative provider "unknown"`,
+			},
+			{
+				name:     "provider not supported by weaviate",
+				version:  "1.29.3",
+				opts:     GenerativeOptions{SinglePrompt: "prompt", Provider: "openai"},
+				expected: "choosing the generative provider requires weaviate 1.30.0 or later, got 1.29.3",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				queries := []string{}
+				versioned := search
+				versioned.version = tc.version
+				weaviate := newSearchTestWeaviate(t, versioned, `{}`, &queries, nil)
+
+				res, err := weaviate.Search(connectionID, "Article", "", "bm25", "databases", SearchOptions{
+					Generative: &tc.opts,
+				})
+
+				assert.Nil(t, res)
+				assert.EqualError(t, err, tc.expected)
+				assert.Empty(t, queries)
+			})
+		}
+	})
+}
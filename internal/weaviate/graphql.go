@@ -0,0 +1,410 @@
+package weaviate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+type GraphQLInput struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+	// OperationName selects the operation to run when the query has several
+	OperationName string `json:"operationName,omitempty"`
+}
+
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type GraphQLError struct {
+	Message   string                 `json:"message"`
+	Locations []GraphQLErrorLocation `json:"locations,omitempty"`
+	// Path is the path of the field in the result, field names and list indexes
+	Path []any `json:"path,omitempty"`
+}
+
+type GraphQLResult struct {
+	Data   any            `json:"data"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+	// Validated is false when the query failed the validation and wasn't sent, the
+	// validation errors are returned in Errors
+	Validated     bool   `json:"validated"`
+	ExecutionTime string `json:"executionTime"`
+}
+
+// RunGraphQL runs a raw Get, Aggregate or Explore query. The query is validated
+// against the collections of the connection before it's sent, the collections and
+// properties it selects have to exist and its required variables have to be set.
+// The errors of the result keep their locations and paths.
+func (w *Weaviate) RunGraphQL(connectionID int64, input GraphQLInput) (*GraphQLResult, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+	if strings.TrimSpace(input.Query) == "" {
+		return nil, errors.New("query is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	schema, err := c.w.Schema().Getter().Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving schema: %w", err)
+	}
+
+	collections := make(map[string]*models.Class, len(schema.Classes))
+	for _, class := range schema.Classes {
+		collections[class.Class] = class
+	}
+	// aliases are queried like their collections, they aren't supported before 1.32
+	if aliases, err := c.w.Alias().Getter().Do(ctx); err == nil {
+		for _, a := range aliases {
+			if class, ok := collections[a.Class]; ok {
+				collections[a.Alias] = class
+			}
+		}
+	}
+
+	if errs := validateGraphQL(input, collections); len(errs) > 0 {
+		return &GraphQLResult{Errors: errs}, nil
+	}
+
+	body := map[string]any{"query": input.Query}
+	if len(input.Variables) > 0 {
+		body["variables"] = input.Variables
+	}
+	if input.OperationName != "" {
+		body["operationName"] = input.OperationName
+	}
+
+	now := time.Now()
+
+	var result GraphQLResult
+	if err := w.doREST(ctx, connectionID, http.MethodPost, "/graphql", nil, body, &result); err != nil {
+		return nil, fmt.Errorf("failed running graphql query: %w", err)
+	}
+	result.Validated = true
+	result.ExecutionTime = time.Since(now).String()
+
+	return &result, nil
+}
+
+// validateGraphQL validates the operation of the query that would run
+func validateGraphQL(input GraphQLInput, collections map[string]*models.Class) []GraphQLError {
+	doc, err := parseGQL(input.Query)
+	if err != nil {
+		var syntaxErr *gqlSyntaxError
+		if errors.As(err, &syntaxErr) {
+			return []GraphQLError{gqlError(syntaxErr.line, syntaxErr.column, nil, "%s", syntaxErr.message)}
+		}
+		return []GraphQLError{{Message: err.Error()}}
+	}
+
+	op := doc.operations[0]
+	switch {
+	case input.OperationName != "":
+		i := slices.IndexFunc(doc.operations, func(op *gqlOperation) bool {
+			return op.name == input.OperationName
+		})
+		if i < 0 {
+			return []GraphQLError{{Message: fmt.Sprintf("unknown operation %q", input.OperationName)}}
+		}
+		op = doc.operations[i]
+	case len(doc.operations) > 1:
+		return []GraphQLError{{Message: "operation name is required when the query has several operations"}}
+	}
+
+	if op.kind != "query" {
+		return []GraphQLError{
+			gqlError(op.line, op.column, nil, "%s operations aren't supported, only queries", op.kind),
+		}
+	}
+
+	v := &gqlValidator{collections: collections, fragments: doc.fragments}
+	uses, ok := v.resolveFragments(op)
+	if !ok {
+		return v.errors
+	}
+	v.validateVariables(op, uses, input.Variables)
+	for _, sel := range v.selections(op.selections) {
+		v.validateRoot(sel)
+	}
+
+	return v.errors
+}
+
+func gqlError(line, column int, path []any, format string, args ...any) GraphQLError {
+	return GraphQLError{
+		Message:   fmt.Sprintf(format, args...),
+		Locations: []GraphQLErrorLocation{{Line: line, Column: column}},
+		Path:      path,
+	}
+}
+
+type gqlValidator struct {
+	collections map[string]*models.Class
+	fragments   map[string]*gqlFragment
+	errors      []GraphQLError
+}
+
+func (v *gqlValidator) errorf(sel *gqlSelection, path []any, format string, args ...any) {
+	v.errors = append(v.errors, gqlError(sel.line, sel.column, path, format, args...))
+}
+
+// resolveFragments checks the fragments spread by the operation are defined and don't
+// spread themselves, it returns the variables used by the fragments
+func (v *gqlValidator) resolveFragments(op *gqlOperation) ([]gqlVariable, bool) {
+	var uses []gqlVariable
+	ok := true
+	// resolved fragments are only checked once, however often they are spread
+	resolved := map[string]bool{}
+
+	var resolve func(selections []*gqlSelection, spreading []string)
+	resolve = func(selections []*gqlSelection, spreading []string) {
+		for _, sel := range selections {
+			if !sel.spread {
+				resolve(sel.selections, spreading)
+				continue
+			}
+
+			fragment, exists := v.fragments[sel.name]
+			switch {
+			case !exists:
+				v.errorf(sel, nil, "fragment %q is not defined", sel.name)
+				ok = false
+			case slices.Contains(spreading, sel.name):
+				v.errorf(sel, nil, "fragment %q spreads itself", sel.name)
+				ok = false
+			case !resolved[sel.name]:
+				resolve(fragment.selections, append(spreading, sel.name))
+				resolved[sel.name] = true
+				uses = append(uses, fragment.uses...)
+			}
+		}
+	}
+	resolve(op.selections, nil)
+
+	return uses, ok
+}
+
+// selections returns the selections with the fragments inlined, outside of cross
+// references the type conditions are the types of weaviate and not collections
+func (v *gqlValidator) selections(selections []*gqlSelection) []*gqlSelection {
+	inlined := make([]*gqlSelection, 0, len(selections))
+	for _, sel := range selections {
+		switch {
+		case sel.spread:
+			inlined = append(inlined, v.selections(v.fragments[sel.name].selections)...)
+		case sel.fragment:
+			inlined = append(inlined, v.selections(sel.selections)...)
+		default:
+			inlined = append(inlined, sel)
+		}
+	}
+
+	return inlined
+}
+
+func (v *gqlValidator) validateVariables(
+	op *gqlOperation,
+	fragmentUses []gqlVariable,
+	values map[string]any,
+) {
+	declared := make(map[string]bool, len(op.variables))
+	for _, variable := range op.variables {
+		declared[variable.name] = true
+
+		if value, ok := values[variable.name]; variable.required && (!ok || value == nil) {
+			v.errors = append(v.errors, gqlError(
+				variable.line,
+				variable.column,
+				nil,
+				"variable $%s is required",
+				variable.name,
+			))
+		}
+	}
+
+	for _, use := range slices.Concat(op.uses, fragmentUses) {
+		if !declared[use.name] {
+			v.errors = append(v.errors, gqlError(
+				use.line,
+				use.column,
+				nil,
+				"variable $%s is not declared",
+				use.name,
+			))
+		}
+	}
+}
+
+// validateRoot validates the collections selected by Get and Aggregate, Explore
+// searches every collection and the introspection fields aren't validated
+func (v *gqlValidator) validateRoot(sel *gqlSelection) {
+	if strings.HasPrefix(sel.name, "__") {
+		return
+	}
+
+	path := []any{sel.name}
+	switch sel.name {
+	case "Get", "Aggregate":
+		if len(sel.selections) == 0 {
+			v.errorf(sel, path, "%s requires a selection of collections", sel.name)
+			return
+		}
+
+		for _, col := range v.selections(sel.selections) {
+			if strings.HasPrefix(col.name, "__") {
+				continue
+			}
+
+			class, ok := v.collections[col.name]
+			colPath := append(slices.Clone(path), col.name)
+			if !ok {
+				v.errorf(col, colPath, "collection %q does not exist", col.name)
+				continue
+			}
+			if len(col.selections) == 0 {
+				v.errorf(col, colPath, "%s requires a selection of fields", col.name)
+				continue
+			}
+
+			if sel.name == "Get" {
+				v.validateGetFields(col.selections, class.Class, nestedProperties(class.Properties), colPath)
+			} else {
+				v.validateAggregateFields(col.selections, class, colPath)
+			}
+		}
+	case "Explore":
+	default:
+		v.errorf(sel, path, "unknown field %q, expected Get, Aggregate or Explore", sel.name)
+	}
+}
+
+// validateGetFields validates the properties selected from a collection or from an
+// object property, parent names them in the errors
+func (v *gqlValidator) validateGetFields(
+	selections []*gqlSelection,
+	parent string,
+	props []*models.NestedProperty,
+	path []any,
+) {
+	for _, sel := range v.selections(selections) {
+		if sel.name == "_additional" || strings.HasPrefix(sel.name, "__") {
+			continue
+		}
+
+		fieldPath := append(slices.Clone(path), sel.name)
+		i := slices.IndexFunc(props, func(p *models.NestedProperty) bool { return p.Name == sel.name })
+		if i < 0 {
+			v.errorf(sel, fieldPath, "property %q does not exist in %s", sel.name, parent)
+			continue
+		}
+		prop := props[i]
+
+		switch {
+		case len(prop.DataType) > 0 && isCrossReference(prop.DataType[0]):
+			v.validateReference(sel, prop.DataType, fieldPath)
+		case len(prop.NestedProperties) > 0:
+			if len(sel.selections) == 0 {
+				v.errorf(sel, fieldPath, "property %q requires a selection of its nested properties", sel.name)
+				continue
+			}
+			v.validateGetFields(sel.selections, sel.name, prop.NestedProperties, fieldPath)
+		case len(sel.selections) > 0:
+			v.errorf(sel, fieldPath, "property %q has no fields to select", sel.name)
+		}
+	}
+}
+
+// validateReference validates the "... on Collection" selections of a cross reference
+func (v *gqlValidator) validateReference(sel *gqlSelection, targets []string, path []any) {
+	if len(sel.selections) == 0 {
+		v.errorf(sel, path, "cross reference %q requires a selection of its collections", sel.name)
+		return
+	}
+
+	for _, target := range sel.selections {
+		// a spread fragment selects the collection of its type condition
+		if target.spread {
+			fragment := v.fragments[target.name]
+			target = &gqlSelection{
+				name:       fragment.typeCondition,
+				fragment:   true,
+				line:       target.line,
+				column:     target.column,
+				selections: fragment.selections,
+			}
+		}
+		if target.name == "__typename" {
+			continue
+		}
+		if !target.fragment || target.name == "" {
+			v.errorf(
+				target,
+				append(slices.Clone(path), target.name),
+				"cross reference %q selects collections with \"... on <Collection>\"",
+				sel.name,
+			)
+			continue
+		}
+		if !slices.Contains(targets, target.name) {
+			v.errorf(target, path, "cross reference %q does not point at %s", sel.name, target.name)
+			continue
+		}
+
+		class, ok := v.collections[target.name]
+		if !ok {
+			v.errorf(target, path, "collection %q does not exist", target.name)
+			continue
+		}
+		v.validateGetFields(target.selections, class.Class, nestedProperties(class.Properties), path)
+	}
+}
+
+// validateAggregateFields validates the properties aggregated, their statistics
+// depend on the data type and are checked by weaviate
+func (v *gqlValidator) validateAggregateFields(
+	selections []*gqlSelection,
+	class *models.Class,
+	path []any,
+) {
+	for _, sel := range v.selections(selections) {
+		switch {
+		case strings.HasPrefix(sel.name, "__"):
+		case sel.name == "meta" || sel.name == "groupedBy":
+		case !slices.ContainsFunc(class.Properties, func(p *models.Property) bool { return p.Name == sel.name }):
+			v.errorf(
+				sel,
+				append(slices.Clone(path), sel.name),
+				"property %q does not exist in %s",
+				sel.name,
+				class.Class,
+			)
+		}
+	}
+}
+
+// nestedProperties returns the properties of a collection like nested properties so
+// the properties of collections and objects are validated alike
+func nestedProperties(props []*models.Property) []*models.NestedProperty {
+	nested := make([]*models.NestedProperty, 0, len(props))
+	for _, p := range props {
+		nested = append(nested, &models.NestedProperty{
+			Name:             p.Name,
+			DataType:         p.DataType,
+			NestedProperties: p.NestedProperties,
+		})
+	}
+
+	return nested
+}
@@ -0,0 +1,614 @@
+package weaviate
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// The parser reads the structure of a GraphQL document that is needed to validate it
+// against the collections: the operations with their variables and the selections.
+// Arguments are only scanned for the variables they use.
+
+type gqlTokenKind int
+
+const (
+	gqlEOF gqlTokenKind = iota
+	gqlPunctuator
+	gqlName
+	gqlString
+	gqlNumber
+)
+
+type gqlToken struct {
+	kind   gqlTokenKind
+	value  string
+	line   int
+	column int
+}
+
+func (t gqlToken) String() string {
+	switch t.kind {
+	case gqlEOF:
+		return "end of query"
+	case gqlString:
+		return "string"
+	default:
+		return fmt.Sprintf("%q", t.value)
+	}
+}
+
+// gqlSyntaxError is a syntax error of the query at its line and column
+type gqlSyntaxError struct {
+	message string
+	line    int
+	column  int
+}
+
+func (e *gqlSyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d:%d: %s", e.line, e.column, e.message)
+}
+
+type gqlLexer struct {
+	src    string
+	pos    int
+	line   int
+	column int
+}
+
+func (l *gqlLexer) errorf(format string, args ...any) error {
+	return &gqlSyntaxError{message: fmt.Sprintf(format, args...), line: l.line, column: l.column}
+}
+
+func (l *gqlLexer) advance(n int) {
+	for range n {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.column = 1
+		} else {
+			l.column++
+		}
+		l.pos++
+	}
+}
+
+// skipIgnored skips white space, commas and comments
+func (l *gqlLexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case ' ', '\t', '\n', '\r', ',':
+			l.advance(1)
+		case '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		default:
+			// the unicode BOM is ignored like white space
+			if strings.HasPrefix(l.src[l.pos:], "\uFEFF") {
+				l.pos += len("\uFEFF")
+				continue
+			}
+			return
+		}
+	}
+}
+
+func (l *gqlLexer) next() (gqlToken, error) {
+	l.skipIgnored()
+
+	tok := gqlToken{line: l.line, column: l.column}
+	if l.pos >= len(l.src) {
+		return tok, nil
+	}
+
+	rest := l.src[l.pos:]
+	switch c := rest[0]; {
+	case strings.HasPrefix(rest, "..."):
+		tok.kind, tok.value = gqlPunctuator, "..."
+		l.advance(3)
+	case strings.ContainsRune("!$&():=@[]{}|", rune(c)):
+		tok.kind, tok.value = gqlPunctuator, string(c)
+		l.advance(1)
+	case c == '_' || isLetter(c):
+		n := 1
+		for n < len(rest) && (rest[n] == '_' || isLetter(rest[n]) || isDigit(rest[n])) {
+			n++
+		}
+		tok.kind, tok.value = gqlName, rest[:n]
+		l.advance(n)
+	case c == '-' || isDigit(c):
+		n := 1
+		for n < len(rest) && (isDigit(rest[n]) || strings.ContainsRune(".eE+-", rune(rest[n]))) {
+			n++
+		}
+		tok.kind, tok.value = gqlNumber, rest[:n]
+		l.advance(n)
+	case strings.HasPrefix(rest, `"""`):
+		end := strings.Index(strings.ReplaceAll(rest[3:], `\"""`, "xxxx"), `"""`)
+		if end < 0 {
+			return tok, l.errorf("unterminated string")
+		}
+		tok.kind, tok.value = gqlString, rest[3:3+end]
+		l.advance(end + 6)
+	case c == '"':
+		n := 1
+		for ; n < len(rest) && rest[n] != '"'; n++ {
+			if rest[n] == '\n' {
+				return tok, l.errorf("unterminated string")
+			}
+			if rest[n] == '\\' {
+				n++
+			}
+		}
+		if n >= len(rest) {
+			return tok, l.errorf("unterminated string")
+		}
+		tok.kind, tok.value = gqlString, rest[1:n]
+		l.advance(n + 1)
+	default:
+		r, _ := utf8.DecodeRuneInString(rest)
+		return tok, l.errorf("unexpected character %q", r)
+	}
+
+	return tok, nil
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// gqlSelection is a field, an inline fragment ("... on Name") or a fragment spread
+type gqlSelection struct {
+	// name is the field name, the type condition of inline fragments or the name of
+	// the spread fragment
+	name       string
+	fragment   bool
+	spread     bool
+	line       int
+	column     int
+	selections []*gqlSelection
+}
+
+type gqlVariable struct {
+	name     string
+	required bool
+	line     int
+	column   int
+}
+
+type gqlOperation struct {
+	kind       string
+	name       string
+	line       int
+	column     int
+	variables  []gqlVariable
+	uses       []gqlVariable
+	selections []*gqlSelection
+}
+
+// gqlFragment is a fragment definition, its selections are validated where it's spread
+type gqlFragment struct {
+	name          string
+	typeCondition string
+	uses          []gqlVariable
+	selections    []*gqlSelection
+}
+
+type gqlDocument struct {
+	operations []*gqlOperation
+	fragments  map[string]*gqlFragment
+}
+
+type gqlParser struct {
+	lexer *gqlLexer
+	tok   gqlToken
+	// uses collects the variables used by the definition being parsed
+	uses []gqlVariable
+}
+
+// parseGQL parses the operations and the fragment definitions of the query
+func parseGQL(query string) (*gqlDocument, error) {
+	p := &gqlParser{lexer: &gqlLexer{src: query, line: 1, column: 1}}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+
+	doc := &gqlDocument{fragments: map[string]*gqlFragment{}}
+	for p.tok.kind != gqlEOF {
+		if p.peek(gqlName, "fragment") {
+			line, column := p.tok.line, p.tok.column
+			fragment, err := p.parseFragmentDefinition()
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := doc.fragments[fragment.name]; exists {
+				return nil, &gqlSyntaxError{
+					message: fmt.Sprintf("fragment %q is defined more than once", fragment.name),
+					line:    line,
+					column:  column,
+				}
+			}
+			doc.fragments[fragment.name] = fragment
+			continue
+		}
+
+		op, err := p.parseOperation()
+		if err != nil {
+			return nil, err
+		}
+		doc.operations = append(doc.operations, op)
+	}
+	if len(doc.operations) == 0 {
+		return nil, &gqlSyntaxError{message: "query has no operation", line: 1, column: 1}
+	}
+
+	return doc, nil
+}
+
+func (p *gqlParser) read() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+
+	return nil
+}
+
+func (p *gqlParser) peek(kind gqlTokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+func (p *gqlParser) unexpected(expected string) error {
+	return &gqlSyntaxError{
+		message: fmt.Sprintf("expected %s, found %s", expected, p.tok),
+		line:    p.tok.line,
+		column:  p.tok.column,
+	}
+}
+
+// expect reads the punctuator or fails
+func (p *gqlParser) expect(punctuator string) error {
+	if !p.peek(gqlPunctuator, punctuator) {
+		return p.unexpected(fmt.Sprintf("%q", punctuator))
+	}
+	return p.read()
+}
+
+// skip reads the punctuator when it's the current token
+func (p *gqlParser) skip(punctuator string) (bool, error) {
+	if !p.peek(gqlPunctuator, punctuator) {
+		return false, nil
+	}
+	return true, p.read()
+}
+
+func (p *gqlParser) name() (gqlToken, error) {
+	tok := p.tok
+	if tok.kind != gqlName {
+		return tok, p.unexpected("a name")
+	}
+	return tok, p.read()
+}
+
+func (p *gqlParser) parseOperation() (*gqlOperation, error) {
+	op := &gqlOperation{kind: "query", line: p.tok.line, column: p.tok.column}
+	p.uses = nil
+
+	// the query shorthand is an anonymous query without variables
+	if !p.peek(gqlPunctuator, "{") {
+		kind, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		switch kind.value {
+		case "query", "mutation", "subscription":
+			op.kind = kind.value
+		default:
+			return nil, &gqlSyntaxError{
+				message: fmt.Sprintf("expected an operation, found %s", kind),
+				line:    kind.line,
+				column:  kind.column,
+			}
+		}
+
+		if p.tok.kind == gqlName {
+			op.name = p.tok.value
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+		}
+		if op.variables, err = p.parseVariableDefinitions(); err != nil {
+			return nil, err
+		}
+		if err := p.skipDirectives(); err != nil {
+			return nil, err
+		}
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+	op.uses = p.uses
+
+	return op, nil
+}
+
+func (p *gqlParser) parseVariableDefinitions() ([]gqlVariable, error) {
+	if ok, err := p.skip("("); !ok || err != nil {
+		return nil, err
+	}
+
+	var variables []gqlVariable
+	for !p.peek(gqlPunctuator, ")") {
+		v := gqlVariable{line: p.tok.line, column: p.tok.column}
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		v.name = name.value
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if v.required, err = p.parseType(); err != nil {
+			return nil, err
+		}
+
+		hasDefault, err := p.skip("=")
+		if err != nil {
+			return nil, err
+		}
+		if hasDefault {
+			// a variable with a default value can be omitted
+			v.required = false
+			if err := p.skipValue(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.skipDirectives(); err != nil {
+			return nil, err
+		}
+
+		variables = append(variables, v)
+	}
+
+	return variables, p.read()
+}
+
+// parseType reads the type of a variable, reporting whether it's non null
+func (p *gqlParser) parseType() (bool, error) {
+	list, err := p.skip("[")
+	if err != nil {
+		return false, err
+	}
+	if list {
+		if _, err := p.parseType(); err != nil {
+			return false, err
+		}
+		if err := p.expect("]"); err != nil {
+			return false, err
+		}
+	} else if _, err := p.name(); err != nil {
+		return false, err
+	}
+
+	return p.skip("!")
+}
+
+func (p *gqlParser) parseSelectionSet() ([]*gqlSelection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var selections []*gqlSelection
+	for !p.peek(gqlPunctuator, "}") {
+		if p.tok.kind == gqlEOF {
+			return nil, p.unexpected(`"}"`)
+		}
+
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	if len(selections) == 0 {
+		return nil, p.unexpected("a selection")
+	}
+
+	return selections, p.read()
+}
+
+func (p *gqlParser) parseSelection() (*gqlSelection, error) {
+	sel := &gqlSelection{line: p.tok.line, column: p.tok.column}
+
+	fragment, err := p.skip("...")
+	if err != nil {
+		return nil, err
+	}
+	if fragment {
+		switch {
+		case p.peek(gqlName, "on"):
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+			typeCondition, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			sel.name, sel.fragment = typeCondition.value, true
+		case p.tok.kind == gqlName:
+			sel.name, sel.spread = p.tok.value, true
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+			return sel, p.skipDirectives()
+		default:
+			sel.fragment = true
+		}
+		if err := p.skipDirectives(); err != nil {
+			return nil, err
+		}
+		sel.selections, err = p.parseSelectionSet()
+		return sel, err
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	sel.name = name.value
+
+	// the field is aliased, its name follows the alias
+	aliased, err := p.skip(":")
+	if err != nil {
+		return nil, err
+	}
+	if aliased {
+		sel.line, sel.column = p.tok.line, p.tok.column
+		if name, err = p.name(); err != nil {
+			return nil, err
+		}
+		sel.name = name.value
+	}
+
+	if err := p.skipArguments(); err != nil {
+		return nil, err
+	}
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+	if p.peek(gqlPunctuator, "{") {
+		if sel.selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+
+	return sel, nil
+}
+
+func (p *gqlParser) skipArguments() error {
+	if !p.peek(gqlPunctuator, "(") {
+		return nil
+	}
+	return p.skipFields("(", ")")
+}
+
+func (p *gqlParser) skipDirectives() error {
+	for p.peek(gqlPunctuator, "@") {
+		if err := p.read(); err != nil {
+			return err
+		}
+		if _, err := p.name(); err != nil {
+			return err
+		}
+		if err := p.skipArguments(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// skipValue skips a value recording the variables it uses
+func (p *gqlParser) skipValue() error {
+	switch {
+	case p.peek(gqlPunctuator, "$"):
+		use := gqlVariable{line: p.tok.line, column: p.tok.column}
+		if err := p.read(); err != nil {
+			return err
+		}
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		use.name = name.value
+		p.uses = append(p.uses, use)
+		return nil
+	case p.peek(gqlPunctuator, "["):
+		if err := p.read(); err != nil {
+			return err
+		}
+		for !p.peek(gqlPunctuator, "]") {
+			if err := p.skipValue(); err != nil {
+				return err
+			}
+		}
+		return p.read()
+	case p.peek(gqlPunctuator, "{"):
+		return p.skipFields("{", "}")
+	case p.tok.kind == gqlName || p.tok.kind == gqlString || p.tok.kind == gqlNumber:
+		return p.read()
+	default:
+		return p.unexpected("a value")
+	}
+}
+
+// skipFields skips the "name: value" fields of arguments or of an object value
+func (p *gqlParser) skipFields(open, end string) error {
+	if err := p.expect(open); err != nil {
+		return err
+	}
+
+	for !p.peek(gqlPunctuator, end) {
+		if _, err := p.name(); err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		if err := p.skipValue(); err != nil {
+			return err
+		}
+	}
+
+	return p.read()
+}
+
+func (p *gqlParser) parseFragmentDefinition() (*gqlFragment, error) {
+	fragment := &gqlFragment{}
+	p.uses = nil
+
+	// fragment Name on Type
+	for i, keyword := range []string{"fragment", "", "on", ""} {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if keyword != "" && name.value != keyword {
+			return nil, &gqlSyntaxError{
+				message: fmt.Sprintf("expected %q, found %s", keyword, name),
+				line:    name.line,
+				column:  name.column,
+			}
+		}
+
+		switch i {
+		case 1:
+			fragment.name = name.value
+		case 3:
+			fragment.typeCondition = name.value
+		}
+	}
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	fragment.selections = selections
+	fragment.uses = p.uses
+
+	return fragment, nil
+}
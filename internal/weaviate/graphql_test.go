@@ -0,0 +1,312 @@
+package weaviate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestGraphQL(t *testing.T) {
+	connectionID := int64(1)
+	collections := map[string]*weaviate_models.Class{
+		"Article": {
+			Class: "Article",
+			Properties: []*weaviate_models.Property{
+				{Name: "title", DataType: []string{"text"}},
+				{Name: "views", DataType: []string{"int"}},
+				{
+					Name:     "author",
+					DataType: []string{"object"},
+					NestedProperties: []*weaviate_models.NestedProperty{
+						{Name: "name", DataType: []string{"text"}},
+					},
+				},
+				{Name: "writtenBy", DataType: []string{"Author"}},
+			},
+		},
+		"Author": {
+			Class:      "Author",
+			Properties: []*weaviate_models.Property{{Name: "name", DataType: []string{"text"}}},
+		},
+	}
+
+	t.Run("validateGraphQL", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			input    GraphQLInput
+			expected []GraphQLError
+		}{
+			{
+				name: "should accept valid query",
+				input: GraphQLInput{Query: `
+					query Articles($limit: Int!, $query: String = "weaviate") {
+						Get {
+							Article(limit: $limit, bm25: {query: $query, properties: ["title"]}) {
+								title
+								author { name }
+								writtenBy { ... on Author { name } }
+								_additional { id score }
+							}
+						}
+						Aggregate { Article(groupBy: ["views"]) { meta { count } views { mean } groupedBy { value } } }
+						Explore(nearText: {concepts: ["weaviate"]}) { beacon }
+					}`,
+					Variables: map[string]any{"limit": 10},
+				},
+			},
+			{
+				name:  "should report syntax errors with their location",
+				input: GraphQLInput{Query: "{\n  Get {\n    Article(limit: ) { title }\n  }\n}"},
+				expected: []GraphQLError{{
+					Message:   `expected a value, found ")"`,
+					Locations: []GraphQLErrorLocation{{Line: 3, Column: 20}},
+				}},
+			},
+			{
+				name:  "should report unknown collections and properties",
+				input: GraphQLInput{Query: "{\n  Get {\n    Articles { title }\n    Article { titel author { age } }\n  }\n}"},
+				expected: []GraphQLError{
+					{
+						Message:   `collection "Articles" does not exist`,
+						Locations: []GraphQLErrorLocation{{Line: 3, Column: 5}},
+						Path:      []any{"Get", "Articles"},
+					},
+					{
+						Message:   `property "titel" does not exist in Article`,
+						Locations: []GraphQLErrorLocation{{Line: 4, Column: 15}},
+						Path:      []any{"Get", "Article", "titel"},
+					},
+					{
+						Message:   `property "age" does not exist in author`,
+						Locations: []GraphQLErrorLocation{{Line: 4, Column: 30}},
+						Path:      []any{"Get", "Article", "author", "age"},
+					},
+				},
+			},
+			{
+				name:  "should report cross references without collection selections",
+				input: GraphQLInput{Query: `{ Get { Article { writtenBy { name } } } }`},
+				expected: []GraphQLError{{
+					Message:   `cross reference "writtenBy" selects collections with "... on <Collection>"`,
+					Locations: []GraphQLErrorLocation{{Line: 1, Column: 31}},
+					Path:      []any{"Get", "Article", "writtenBy", "name"},
+				}},
+			},
+			{
+				name:  "should report unknown aggregated properties and root fields",
+				input: GraphQLInput{Query: `{ Aggregate { Article { likes { sum } } } Search { id } }`},
+				expected: []GraphQLError{
+					{
+						Message:   `property "likes" does not exist in Article`,
+						Locations: []GraphQLErrorLocation{{Line: 1, Column: 25}},
+						Path:      []any{"Aggregate", "Article", "likes"},
+					},
+					{
+						Message:   `unknown field "Search", expected Get, Aggregate or Explore`,
+						Locations: []GraphQLErrorLocation{{Line: 1, Column: 43}},
+						Path:      []any{"Search"},
+					},
+				},
+			},
+			{
+				name: "should report missing and undeclared variables",
+				input: GraphQLInput{
+					Query: `query ($limit: Int!) { Get { Article(limit: $limit, offset: $offset) { title } } }`,
+				},
+				expected: []GraphQLError{
+					{
+						Message:   "variable $limit is required",
+						Locations: []GraphQLErrorLocation{{Line: 1, Column: 8}},
+					},
+					{
+						Message:   "variable $offset is not declared",
+						Locations: []GraphQLErrorLocation{{Line: 1, Column: 61}},
+					},
+				},
+			},
+			{
+				name: "should validate the fields of spread fragments",
+				input: GraphQLInput{Query: "{ Get { ...Articles } }\n" +
+					"fragment Articles on GetObjectsObj { Article { ...Fields writtenBy { ...Authors } } }\n" +
+					"fragment Fields on Article { titel views(limit: $limit) }\n" +
+					"fragment Authors on Author { age }"},
+				expected: []GraphQLError{
+					{
+						Message:   "variable $limit is not declared",
+						Locations: []GraphQLErrorLocation{{Line: 3, Column: 49}},
+					},
+					{
+						Message:   `property "titel" does not exist in Article`,
+						Locations: []GraphQLErrorLocation{{Line: 3, Column: 30}},
+						Path:      []any{"Get", "Article", "titel"},
+					},
+					{
+						Message:   `property "age" does not exist in Author`,
+						Locations: []GraphQLErrorLocation{{Line: 4, Column: 30}},
+						Path:      []any{"Get", "Article", "writtenBy", "age"},
+					},
+				},
+			},
+			{
+				name: "should report undefined and cyclic fragments",
+				input: GraphQLInput{Query: "{ Get { Article { ...Missing ...Fields } } }\n" +
+					"fragment Fields on Article { title ...Fields }"},
+				expected: []GraphQLError{
+					{
+						Message:   `fragment "Missing" is not defined`,
+						Locations: []GraphQLErrorLocation{{Line: 1, Column: 19}},
+					},
+					{
+						Message:   `fragment "Fields" spreads itself`,
+						Locations: []GraphQLErrorLocation{{Line: 2, Column: 36}},
+					},
+				},
+			},
+			{
+				name:  "should reject mutations",
+				input: GraphQLInput{Query: `mutation { Get { Article { title } } }`},
+				expected: []GraphQLError{{
+					Message:   "mutation operations aren't supported, only queries",
+					Locations: []GraphQLErrorLocation{{Line: 1, Column: 1}},
+				}},
+			},
+			{
+				name:  "should require operation name with several operations",
+				input: GraphQLInput{Query: `query A { Explore { beacon } } query B { Explore { beacon } }`},
+				expected: []GraphQLError{
+					{Message: "operation name is required when the query has several operations"},
+				},
+			},
+			{
+				name: "should only validate the selected operation",
+				input: GraphQLInput{
+					Query:         `query A { Get { Missing { id } } } query B { Get { Article { title } } }`,
+					OperationName: "B",
+				},
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				assert.Equal(t, tc.expected, validateGraphQL(tc.input, collections))
+			})
+		}
+	})
+
+	t.Run("RunGraphQL", func(t *testing.T) {
+		// newWeaviate serves the schema and answers graphql queries with the response,
+		// the bodies of the queries are recorded
+		newWeaviate := func(t *testing.T, response string, queries *[]map[string]any) *Weaviate {
+			t.Helper()
+
+			mockServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v1/meta":
+					w.Write([]byte(`{"version": "1.30.0"}`))
+				case "/v1/schema":
+					json.NewEncoder(w).Encode(weaviate_models.Schema{
+						Classes: []*weaviate_models.Class{collections["Article"], collections["Author"]},
+					})
+				case "/v1/aliases":
+					w.WriteHeader(http.StatusNotFound)
+				case "/v1/graphql":
+					body, err := io.ReadAll(r.Body)
+					require.NoError(t, err)
+
+					var query map[string]any
+					require.NoError(t, json.Unmarshal(body, &query))
+					*queries = append(*queries, query)
+
+					w.Write([]byte(response))
+				default:
+					t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+					t.Fail()
+				}
+			}))
+			t.Cleanup(mockServer.Close)
+
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+			client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+			require.NoError(t, err)
+			weaviate.clients.add(connectionID, client)
+
+			return weaviate
+		}
+
+		t.Run("should send query with variables and keep error locations and paths", func(t *testing.T) {
+			queries := []map[string]any{}
+			weaviate := newWeaviate(t, `{
+				"data": {"Get": {"Article": [{"title": "Weaviate", "views": null}]}},
+				"errors": [{
+					"message": "cannot return null for non-nullable field",
+					"locations": [{"line": 1, "column": 52}],
+					"path": ["Get", "Article", 0, "views"]
+				}]
+			}`, &queries)
+
+			query := `query ($limit: Int) { Get { Article(limit: $limit) { title views } } }`
+			result, err := weaviate.RunGraphQL(connectionID, GraphQLInput{
+				Query:     query,
+				Variables: map[string]any{"limit": 1},
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.Validated)
+			assert.NotEmpty(t, result.ExecutionTime)
+			assert.Equal(t, map[string]any{
+				"Get": map[string]any{
+					"Article": []any{map[string]any{"title": "Weaviate", "views": nil}},
+				},
+			}, result.Data)
+			assert.Equal(t, []GraphQLError{{
+				Message:   "cannot return null for non-nullable field",
+				Locations: []GraphQLErrorLocation{{Line: 1, Column: 52}},
+				Path:      []any{"Get", "Article", float64(0), "views"},
+			}}, result.Errors)
+			assert.Equal(t, []map[string]any{
+				{"query": query, "variables": map[string]any{"limit": float64(1)}},
+			}, queries)
+		})
+
+		t.Run("should not send query if validation fails", func(t *testing.T) {
+			queries := []map[string]any{}
+			weaviate := newWeaviate(t, `{}`, &queries)
+
+			result, err := weaviate.RunGraphQL(connectionID, GraphQLInput{
+				Query: `{ Get { Article { titel } } }`,
+			})
+
+			require.NoError(t, err)
+			assert.False(t, result.Validated)
+			assert.Nil(t, result.Data)
+			assert.Equal(t, []GraphQLError{{
+				Message:   `property "titel" does not exist in Article`,
+				Locations: []GraphQLErrorLocation{{Line: 1, Column: 19}},
+				Path:      []any{"Get", "Article", "titel"},
+			}}, result.Errors)
+			assert.Empty(t, queries)
+		})
+
+		t.Run("should return error if connection doesn't exist", func(t *testing.T) {
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+
+			result, err := weaviate.RunGraphQL(connectionID, GraphQLInput{Query: `{ Explore { beacon } }`})
+
+			assert.Nil(t, result)
+			assert.EqualError(t, err, "connection doesn't exist 1")
+		})
+	})
+}
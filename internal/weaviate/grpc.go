@@ -0,0 +1,664 @@
+package weaviate
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+	"weaviate-desktop/internal/utils"
+
+	"github.com/Masterminds/semver"
+	"github.com/go-openapi/strfmt"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/db"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/filters"
+	grpcbatch "github.com/weaviate/weaviate-go-client/v5/weaviate/grpc/batch"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+	pb "github.com/weaviate/weaviate/grpc/generated/protocol/v1"
+	"github.com/weaviate/weaviate/usecases/byteops"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	defaultGRPCPort = 50051
+	// grpcMaxMessageSize allows large results, e.g. 10k objects with their vectors
+	grpcMaxMessageSize = 256 << 20
+	// grpcCheckTimeout bounds the health check of the gRPC API when connecting
+	grpcCheckTimeout = 5 * time.Second
+)
+
+// grpcMinVersion is the first weaviate version returning typed properties and named
+// vectors, older versions use the REST and GraphQL APIs
+var grpcMinVersion = semver.MustParse("1.27.0")
+
+// grpcClient sends searches, object fetches and batches through the gRPC API of
+// weaviate, decoding the results is much cheaper than the JSON of GraphQL
+type grpcClient struct {
+	addr   string
+	conn   *grpc.ClientConn
+	client pb.WeaviateClient
+	health grpc_health_v1.HealthClient
+	batch  grpcbatch.Batch
+
+	// walks are the collections of the cursor walks by name, their schema is retrieved
+	// on the first page and reused for the following pages
+	walksMu sync.Mutex
+	walks   map[string]*weaviate_models.Class
+}
+
+// newGRPCClient creates the gRPC client of the connection, nil when the connection
+// has no gRPC settings or would send its credentials without TLS. It uses the TLS
+// options, proxy or SSH tunnel and the authentication of the client, connecting is
+// delayed until the first call.
+func newGRPCClient(c *models.Connection, u *url.URL, client *WClient) (*grpcClient, error) {
+	addr, err := grpcAddress(c, u)
+	if err != nil || addr == "" {
+		return nil, err
+	}
+
+	opts := clientOptions(c, client.tunnel)
+	dial, err := http_util.Dialer(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(grpcMaxMessageSize),
+			grpc.MaxCallSendMsgSize(grpcMaxMessageSize),
+		),
+	}
+
+	// the gRPC API is secured like the REST API, the credentials are only sent over TLS
+	creds := grpcCredentials{
+		headers: maps.Clone(client.headers),
+		tokens:  client.tokens,
+	}
+	switch {
+	case u.Scheme == "https":
+		cfg, err := opts.TLS.Config()
+		if err != nil {
+			return nil, fmt.Errorf("failed configuring TLS: %w", err)
+		}
+		dialOpts = append(dialOpts,
+			grpc.WithTransportCredentials(credentials.NewTLS(cfg)),
+			grpc.WithPerRPCCredentials(creds),
+		)
+	case len(creds.headers) > 0 || creds.tokens != nil:
+		slog.Warn(
+			"grpc isn't used without TLS for connections with credentials, falling back to rest and graphql",
+			slog.String("addr", addr),
+		)
+		return nil, nil
+	default:
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	target := "dns:///" + addr
+	if dial != nil {
+		// the address is resolved by the proxy or the SSH server
+		target = "passthrough:///" + addr
+		dialOpts = append(dialOpts,
+			grpc.WithNoProxy(),
+			grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+				return dial(ctx, "tcp", addr)
+			}),
+		)
+	}
+
+	conn, err := grpc.NewClient(target, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating grpc client for %s: %w", addr, err)
+	}
+
+	version := db.NewVersionProvider(func() string { return client.currentHealth().Version })
+
+	return &grpcClient{
+		addr:   addr,
+		conn:   conn,
+		client: pb.NewWeaviateClient(conn),
+		health: grpc_health_v1.NewHealthClient(conn),
+		batch:  grpcbatch.New(db.NewGRPCVersionSupport(version)),
+		walks:  map[string]*weaviate_models.Class{},
+	}, nil
+}
+
+// grpcAddress is the address of the gRPC API of the connection, empty when neither the
+// host nor the port is set. The host defaults to the host of the URI.
+func grpcAddress(c *models.Connection, u *url.URL) (string, error) {
+	if value(c.GRPCHost) == "" && c.GRPCPort == nil {
+		return "", nil
+	}
+
+	host := value(c.GRPCHost)
+	if host == "" {
+		host = u.Hostname()
+	}
+
+	port := int64(defaultGRPCPort)
+	if c.GRPCPort != nil {
+		port = *c.GRPCPort
+	}
+	if port < 1 || port > 65535 {
+		return "", fmt.Errorf("invalid grpc port %d", port)
+	}
+
+	return net.JoinHostPort(host, strconv.FormatInt(port, 10)), nil
+}
+
+// check verifies the gRPC API of the weaviate version is served
+func (g *grpcClient) check(ctx context.Context, version string) error {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("failed parsing weaviate version %q: %w", version, err)
+	}
+	if v.LessThan(grpcMinVersion) {
+		return fmt.Errorf("grpc requires weaviate %s or later, got %s", grpcMinVersion, version)
+	}
+
+	res, err := g.health.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Errorf("failed checking grpc api %s: %w", g.addr, err)
+	}
+	if res.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("grpc api %s is %s", g.addr, res.Status)
+	}
+
+	return nil
+}
+
+// checkGRPC checks the gRPC API of the client, nil when it has none
+func (c *WClient) checkGRPC(version string) error {
+	if c.grpc == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCheckTimeout)
+	defer cancel()
+
+	return c.grpc.check(ctx, version)
+}
+
+func (g *grpcClient) close() {
+	g.conn.Close()
+}
+
+// search sends the search request and converts its results
+func (g *grpcClient) search(ctx context.Context, req *pb.SearchRequest) ([]WeaviateObject, error) {
+	objects, _, err := g.generativeSearch(ctx, req)
+	return objects, err
+}
+
+// generativeSearch is search returning the result of the grouped task of a generative
+// search too, weaviate fails the search when generating fails
+func (g *grpcClient) generativeSearch(
+	ctx context.Context,
+	req *pb.SearchRequest,
+) ([]WeaviateObject, string, error) {
+	reply, err := g.client.Search(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	objects := make([]WeaviateObject, 0, len(reply.Results))
+	for _, r := range reply.Results {
+		objects = append(objects, grpcObject(req.Collection, req.Tenant, r))
+	}
+
+	grouped := ""
+	if values := reply.GetGenerativeGroupedResults().GetValues(); len(values) > 0 {
+		grouped = values[0].GetResult()
+	}
+
+	return objects, grouped, nil
+}
+
+// batchObjects creates the objects, the objects without an id get the id generated
+// for the batch so failures can be reported with it
+func (g *grpcClient) batchObjects(
+	ctx context.Context,
+	objects []*weaviate_models.Object,
+) ([]weaviate_models.ObjectsGetResponse, error) {
+	batch, err := g.batch.GetBatchObjects(objects)
+	if err != nil {
+		return nil, err
+	}
+	for i, o := range batch {
+		objects[i].ID = strfmt.UUID(o.Uuid)
+	}
+
+	reply, err := g.client.BatchObjects(ctx, &pb.BatchObjectsRequest{Objects: batch})
+	if err != nil {
+		return nil, err
+	}
+
+	return g.batch.ParseReply(reply, objects), nil
+}
+
+// getObjectsPageGRPC walks the collection with the "after" cursor like the objects
+// endpoint, the schema is needed to request the properties
+func (c *WClient) getObjectsPageGRPC(
+	ctx context.Context,
+	input ObjectsPageInput,
+) (*PaginatedObjectResponse, error) {
+	now := time.Now()
+
+	col, err := c.walkClass(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	req := grpcSearchRequest(col, input.Tenant, nil, input.IncludeVector)
+	req.Limit = uint32(max(input.PageSize, 0)) //nolint:gosec // page size is positive
+	req.After = input.Cursor
+
+	objects, err := c.grpc.search(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed on paginated objects request: %w", err)
+	}
+
+	return &PaginatedObjectResponse{
+		Objects:       objects,
+		TotalResults:  len(objects),
+		ExecutionTime: time.Since(now).String(),
+	}, nil
+}
+
+// walkClass is the collection of a cursor walk. The schema is retrieved on the first
+// page of a walk and when the collection wasn't walked before, e.g. resuming an export,
+// so changes of the schema show up with the next walk.
+func (c *WClient) walkClass(ctx context.Context, input ObjectsPageInput) (*weaviate_models.Class, error) {
+	c.grpc.walksMu.Lock()
+	col, ok := c.grpc.walks[input.Collection]
+	c.grpc.walksMu.Unlock()
+	if ok && input.Cursor != "" {
+		return col, nil
+	}
+
+	col, err := c.w.Schema().ClassGetter().WithClassName(input.Collection).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving schema for %s: %w", input.Collection, err)
+	}
+
+	c.grpc.walksMu.Lock()
+	c.grpc.walks[input.Collection] = col
+	c.grpc.walksMu.Unlock()
+
+	return col, nil
+}
+
+// grpcCredentials sends the headers of the client, e.g. the api key, and the OIDC
+// access token with every call, the token source refreshes the token on its own
+type grpcCredentials struct {
+	headers map[string]string
+	tokens  oauth2.TokenSource
+}
+
+func (g grpcCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	md := make(map[string]string, len(g.headers)+1)
+	for name, value := range g.headers {
+		md[strings.ToLower(name)] = value
+	}
+
+	if g.tokens != nil {
+		token, err := g.tokens.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed refreshing access token: %w", err)
+		}
+		md["authorization"] = fmt.Sprintf("%s %s", token.Type(), token.AccessToken)
+	}
+
+	return md, nil
+}
+
+// RequireTransportSecurity keeps the api keys and access tokens off plaintext channels
+func (grpcCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// grpcSearchRequest requests the properties and the metadata of the objects of the
+// collection, vectors are only requested when includeVector is set
+func grpcSearchRequest(
+	col *weaviate_models.Class,
+	tenant string,
+	where *filters.WhereBuilder,
+	includeVector bool,
+) *pb.SearchRequest {
+	metadata := &pb.MetadataRequest{
+		Uuid:               true,
+		CreationTimeUnix:   true,
+		LastUpdateTimeUnix: true,
+	}
+	if includeVector {
+		if len(col.VectorConfig) == 0 {
+			metadata.Vector = true
+		} else {
+			metadata.Vectors = slices.Sorted(maps.Keys(col.VectorConfig))
+		}
+	}
+
+	req := &pb.SearchRequest{
+		Collection: col.Class,
+		Tenant:     tenant,
+		Properties: grpcPropertiesRequest(col.Properties),
+		Metadata:   metadata,
+		// the typed properties and vectors of the results, supported since grpcMinVersion
+		Uses_123Api: true, //nolint:staticcheck
+		Uses_125Api: true, //nolint:staticcheck
+		Uses_127Api: true,
+	}
+	if where != nil {
+		req.Filters = where.ToGRPC()
+	}
+
+	return req
+}
+
+// setGRPCSearch sets the search of the search type on the request, like the arguments
+// of the GraphQL search. Media searches send the base64 encoded media instead of the query.
+func setGRPCSearch(
+	req *pb.SearchRequest,
+	searchType, query, media string,
+	opts SearchOptions,
+	targets *vectorTargets,
+) {
+	switch searchType {
+	case "hybrid":
+		alpha := opts.Alpha
+		if alpha == 0 {
+			alpha = 0.75
+		}
+		req.HybridSearch = &pb.Hybrid{
+			Query: query,
+			// weaviate reads alpha before 1.36 and alpha_param since
+			Alpha:      alpha, //nolint:staticcheck
+			AlphaParam: &alpha,
+			Targets:    targets.grpcTargets(),
+		}
+		switch opts.FusionType {
+		case "rankedFusion":
+			req.HybridSearch.FusionType = pb.Hybrid_FUSION_TYPE_RANKED
+		case "relativeScoreFusion":
+			req.HybridSearch.FusionType = pb.Hybrid_FUSION_TYPE_RELATIVE_SCORE
+		}
+	case "nearText":
+		req.NearText = &pb.NearTextSearch{
+			Query:     []string{query},
+			Distance:  threshold(opts.Distance),
+			Certainty: threshold(opts.Certainty),
+			Targets:   targets.grpcTargets(),
+		}
+	case "nearVector":
+		req.NearVector = targets.grpcNearVector()
+		req.NearVector.Distance = threshold(opts.Distance)
+		req.NearVector.Certainty = threshold(opts.Certainty)
+	case "nearObject":
+		req.NearObject = &pb.NearObject{
+			Id:        query,
+			Distance:  threshold(opts.Distance),
+			Certainty: threshold(opts.Certainty),
+			Targets:   targets.grpcTargets(),
+		}
+	case "nearImage":
+		req.NearImage = &pb.NearImageSearch{
+			Image:     media,
+			Distance:  threshold(opts.Distance),
+			Certainty: threshold(opts.Certainty),
+			Targets:   targets.grpcTargets(),
+		}
+	case "nearAudio":
+		req.NearAudio = &pb.NearAudioSearch{
+			Audio:     media,
+			Distance:  threshold(opts.Distance),
+			Certainty: threshold(opts.Certainty),
+			Targets:   targets.grpcTargets(),
+		}
+	case "nearVideo":
+		req.NearVideo = &pb.NearVideoSearch{
+			Video:     media,
+			Distance:  threshold(opts.Distance),
+			Certainty: threshold(opts.Certainty),
+			Targets:   targets.grpcTargets(),
+		}
+	default: // "bm25"
+		req.Bm25Search = &pb.BM25{Query: query}
+	}
+}
+
+// threshold is nil for the zero value, i.e. not set
+func threshold(v float32) *float64 {
+	if v <= 0 {
+		return nil
+	}
+
+	t := float64(v)
+	return &t
+}
+
+// grpcPropertiesRequest requests the properties like getGQLFields, the nested
+// properties of objects are requested explicitly and references return their ids
+func grpcPropertiesRequest(props []*weaviate_models.Property) *pb.PropertiesRequest {
+	req := &pb.PropertiesRequest{}
+	for _, p := range props {
+		switch {
+		case len(p.DataType) > 0 && isCrossReference(p.DataType[0]):
+			for _, target := range p.DataType {
+				ref := &pb.RefPropertiesRequest{
+					ReferenceProperty: p.Name,
+					Metadata:          &pb.MetadataRequest{Uuid: true},
+					Properties:        &pb.PropertiesRequest{},
+				}
+				// multi target references are requested per target collection
+				if len(p.DataType) > 1 {
+					ref.TargetCollection = target
+				}
+				req.RefProperties = append(req.RefProperties, ref)
+			}
+		case len(p.NestedProperties) > 0:
+			req.ObjectProperties = append(
+				req.ObjectProperties,
+				grpcObjectPropertiesRequest(p.Name, p.NestedProperties),
+			)
+		default:
+			req.NonRefProperties = append(req.NonRefProperties, p.Name)
+		}
+	}
+
+	return req
+}
+
+func grpcObjectPropertiesRequest(
+	name string,
+	props []*weaviate_models.NestedProperty,
+) *pb.ObjectPropertiesRequest {
+	req := &pb.ObjectPropertiesRequest{PropName: name}
+	for _, p := range props {
+		if len(p.NestedProperties) > 0 {
+			req.ObjectProperties = append(
+				req.ObjectProperties,
+				grpcObjectPropertiesRequest(p.Name, p.NestedProperties),
+			)
+			continue
+		}
+		req.PrimitiveProperties = append(req.PrimitiveProperties, p.Name)
+	}
+
+	return req
+}
+
+func grpcObject(collection, tenant string, r *pb.SearchResult) WeaviateObject {
+	md := r.GetMetadata()
+	object := WeaviateObject{
+		ID:                 md.GetId(),
+		Class:              collection,
+		Tenant:             tenant,
+		CreationTimeUnix:   md.GetCreationTimeUnix(),
+		LastUpdateTimeUnix: md.GetLastUpdateTimeUnix(),
+		Properties:         grpcProperties(r.GetProperties()),
+	}
+
+	if len(md.GetVectorBytes()) > 0 {
+		object.Vector = byteops.Fp32SliceFromBytes(md.GetVectorBytes())
+	}
+	if len(md.GetVectors()) > 0 {
+		object.Vectors = make(map[string]any, len(md.GetVectors()))
+		for _, v := range md.GetVectors() {
+			object.Vectors[v.GetName()] = grpcVector(v)
+		}
+	}
+
+	if md.GetDistancePresent() {
+		object.Distance = utils.Pointer(md.GetDistance())
+	}
+	if md.GetCertaintyPresent() {
+		object.Certainty = utils.Pointer(md.GetCertainty())
+	}
+	if md.GetScorePresent() {
+		object.Score = utils.Pointer(md.GetScore())
+	}
+	object.ExplainScore = md.GetExplainScore()
+	if md.GetRerankScorePresent() {
+		object.RerankScore = utils.Pointer(md.GetRerankScore())
+	}
+
+	if values := r.GetGenerative().GetValues(); len(values) > 0 {
+		object.Generated = values[0].GetResult()
+	}
+
+	return object
+}
+
+// grpcProperties converts the properties of a result, references are returned as
+// beacons like the REST API does
+func grpcProperties(p *pb.PropertiesResult) map[string]any {
+	properties := grpcFields(p.GetNonRefProps())
+
+	for _, ref := range p.GetRefProps() {
+		// multi target references have a result per target collection
+		beacons, ok := properties[ref.GetPropName()].([]any)
+		if !ok {
+			beacons = []any{}
+		}
+		for _, target := range ref.GetProperties() {
+			beacons = append(beacons, map[string]any{
+				"beacon": fmt.Sprintf(
+					"weaviate://localhost/%s/%s",
+					target.GetTargetCollection(),
+					target.GetMetadata().GetId(),
+				),
+			})
+		}
+		properties[ref.GetPropName()] = beacons
+	}
+
+	return properties
+}
+
+func grpcFields(p *pb.Properties) map[string]any {
+	fields := make(map[string]any, len(p.GetFields()))
+	for name, v := range p.GetFields() {
+		fields[name] = grpcValue(v)
+	}
+
+	return fields
+}
+
+// grpcValue converts a property value to the value decoded from the JSON of the REST
+// API, the numbers keep their type
+func grpcValue(v *pb.Value) any {
+	switch k := v.GetKind().(type) {
+	case *pb.Value_TextValue:
+		return k.TextValue
+	case *pb.Value_IntValue:
+		return k.IntValue
+	case *pb.Value_NumberValue:
+		return k.NumberValue
+	case *pb.Value_BoolValue:
+		return k.BoolValue
+	case *pb.Value_DateValue:
+		return k.DateValue
+	case *pb.Value_UuidValue:
+		return k.UuidValue
+	case *pb.Value_BlobValue:
+		return k.BlobValue
+	case *pb.Value_GeoValue:
+		return map[string]any{
+			"latitude":  k.GeoValue.GetLatitude(),
+			"longitude": k.GeoValue.GetLongitude(),
+		}
+	case *pb.Value_PhoneValue:
+		return map[string]any{
+			"input":                  k.PhoneValue.GetInput(),
+			"internationalFormatted": k.PhoneValue.GetInternationalFormatted(),
+			"countryCode":            k.PhoneValue.GetCountryCode(),
+			"defaultCountry":         k.PhoneValue.GetDefaultCountry(),
+			"national":               k.PhoneValue.GetNational(),
+			"nationalFormatted":      k.PhoneValue.GetNationalFormatted(),
+			"valid":                  k.PhoneValue.GetValid(),
+		}
+	case *pb.Value_ObjectValue:
+		return grpcFields(k.ObjectValue)
+	case *pb.Value_ListValue:
+		return grpcList(k.ListValue)
+	default: // null
+		return nil
+	}
+}
+
+func grpcList(l *pb.ListValue) any {
+	switch k := l.GetKind().(type) {
+	case *pb.ListValue_TextValues:
+		return k.TextValues.GetValues()
+	case *pb.ListValue_IntValues:
+		data := k.IntValues.GetValues()
+		values := make([]int64, 0, len(data)/8)
+		for i := 0; i+8 <= len(data); i += 8 {
+			values = append(values, int64(binary.LittleEndian.Uint64(data[i:]))) //nolint:gosec
+		}
+		return values
+	case *pb.ListValue_NumberValues:
+		return byteops.Fp64SliceFromBytes(k.NumberValues.GetValues())
+	case *pb.ListValue_BoolValues:
+		return k.BoolValues.GetValues()
+	case *pb.ListValue_DateValues:
+		return k.DateValues.GetValues()
+	case *pb.ListValue_UuidValues:
+		return k.UuidValues.GetValues()
+	case *pb.ListValue_ObjectValues:
+		values := make([]map[string]any, 0, len(k.ObjectValues.GetValues()))
+		for _, o := range k.ObjectValues.GetValues() {
+			values = append(values, grpcFields(o))
+		}
+		return values
+	default: // empty list
+		return []any{}
+	}
+}
+
+// grpcVector decodes a named vector, multi vectors are returned as a list of vectors
+func grpcVector(v *pb.Vectors) any {
+	if v.GetType() != pb.Vectors_VECTOR_TYPE_MULTI_FP32 {
+		return byteops.Fp32SliceFromBytes(v.GetVectorBytes())
+	}
+
+	vectors, err := byteops.Fp32SliceOfSlicesFromBytes(v.GetVectorBytes())
+	if err != nil {
+		return nil
+	}
+
+	return vectors
+}
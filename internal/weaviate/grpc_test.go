@@ -0,0 +1,730 @@
+package weaviate
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+	"weaviate-desktop/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+	pb "github.com/weaviate/weaviate/grpc/generated/protocol/v1"
+	"github.com/weaviate/weaviate/usecases/byteops"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	grpcObjectID = "00000000-0000-0000-0000-000000000001"
+	grpcCitedID  = "00000000-0000-0000-0000-000000000002"
+	// grpcExplainScore is the explain score of a hybrid search with relativeScoreFusion
+	grpcExplainScore = "\nHybrid (Result Set keyword) Document " + grpcObjectID +
+		": original score 2.5, normalized score: 0.25 - \nHybrid (Result Set vector) Document " +
+		grpcObjectID + ": original score 0.9, normalized score: 0.5"
+)
+
+func TestGRPC(t *testing.T) {
+	connectionID := int64(1)
+
+	// newWeaviateServer serves the REST and GraphQL APIs of weaviate with the version,
+	// the requested paths are recorded
+	newWeaviateServer := func(t *testing.T, version string) (http.Handler, string, func() []string) {
+		t.Helper()
+
+		var mu sync.Mutex
+		paths := []string{}
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			paths = append(paths, r.URL.Path)
+			mu.Unlock()
+
+			switch r.URL.Path {
+			case "/v1/meta":
+				w.Write([]byte(`{"version": "` + version + `"}`))
+			case "/v1/schema/Article":
+				w.Write([]byte(`{
+					"class": "Article",
+					"properties": [
+						{"name": "title", "dataType": ["text"]},
+						{"name": "views", "dataType": ["int"]},
+						{
+							"name": "author",
+							"dataType": ["object"],
+							"nestedProperties": [{"name": "name", "dataType": ["text"]}]
+						},
+						{"name": "cites", "dataType": ["Article"]}
+					]
+				}`))
+			case "/v1/graphql":
+				w.Write([]byte(`{"data": {"Get": {"Article": [{
+					"title": "from graphql",
+					"_additional": {
+						"id": "` + grpcObjectID + `",
+						"creationTimeUnix": "1",
+						"lastUpdateTimeUnix": "2"
+					}
+				}]}}}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+
+		server := http_util.NewServer(handler)
+		t.Cleanup(server.Close)
+
+		return handler, server.URL, func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return append([]string{}, paths...)
+		}
+	}
+
+	// connect connects to the connection, its gRPC API is checked while connecting
+	connect := func(t *testing.T, connection *models.Connection) *Weaviate {
+		t.Helper()
+
+		storage := NewMockStorage(t)
+		storage.EXPECT().GetConnection(connectionID, true).Return(connection, nil)
+		weaviate := New(storage, Configuration{StatusUpdateInterval: time.Hour})
+		t.Cleanup(func() { weaviate.Disconnect(connectionID) })
+
+		require.NoError(t, weaviate.Connect(connectionID))
+
+		return weaviate
+	}
+
+	t.Run("should search through grpc", func(t *testing.T) {
+		handler, _, paths := newWeaviateServer(t, "1.30.0")
+		rest := httptest.NewTLSServer(handler)
+		t.Cleanup(rest.Close)
+		server := newGRPCServer(t, grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: rest.TLS.Certificates,
+		})))
+
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rest.Certificate().Raw})
+		require.NoError(t, os.WriteFile(caFile, ca, 0o600))
+
+		weaviate := connect(t, &models.Connection{
+			URI:        rest.URL,
+			ApiKey:     utils.Pointer("secret"),
+			CACertFile: utils.Pointer(caFile),
+			GRPCHost:   utils.Pointer("127.0.0.1"),
+			GRPCPort:   utils.Pointer(server.port),
+			Headers:    []models.ConnectionHeader{{Name: "X-Openai-Api-Key", Value: "sk"}},
+		})
+
+		opts := SearchOptions{Limit: 5, Alpha: 0.5, FusionType: "relativeScoreFusion"}
+		res, err := weaviate.Search(connectionID, "Article", "tenant", "hybrid", "weaviate", opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, []WeaviateObject{{
+			ID:                 grpcObjectID,
+			Class:              "Article",
+			Tenant:             "tenant",
+			CreationTimeUnix:   1,
+			LastUpdateTimeUnix: 2,
+			Properties: map[string]any{
+				"title":   "Weaviate",
+				"views":   int64(42),
+				"tags":    []string{"db", "vector"},
+				"counts":  []int64{1, -2},
+				"ratings": []float64{0.5, 1},
+				"author":  map[string]any{"name": "Ada"},
+				"cites": []any{
+					map[string]any{"beacon": "weaviate://localhost/Article/" + grpcCitedID},
+				},
+				"location": map[string]any{"latitude": float32(52.5), "longitude": float32(13.4)},
+				"missing":  nil,
+			},
+			Score:        utils.Pointer(float32(0.75)),
+			ExplainScore: grpcExplainScore,
+			KeywordScore: &HybridScore{Score: 0.25, OriginalScore: utils.Pointer(float32(2.5))},
+			VectorScore:  &HybridScore{Score: 0.5, OriginalScore: utils.Pointer(float32(0.9))},
+		}}, res.Objects)
+		assert.Equal(t, 1, res.TotalResults)
+
+		req := server.lastSearch()
+		assert.Equal(t, "Article", req.Collection)
+		assert.Equal(t, "tenant", req.Tenant)
+		assert.Equal(t, uint32(5), req.Limit)
+		assert.Equal(t, "weaviate", req.HybridSearch.Query)
+		assert.Equal(t, float32(0.5), req.HybridSearch.GetAlphaParam())
+		assert.Equal(t, pb.Hybrid_FUSION_TYPE_RELATIVE_SCORE, req.HybridSearch.FusionType)
+		assert.Equal(t, []string{"title", "views"}, req.Properties.NonRefProperties)
+		assert.Equal(t, "author", req.Properties.ObjectProperties[0].PropName)
+		assert.Equal(t, []string{"name"}, req.Properties.ObjectProperties[0].PrimitiveProperties)
+		assert.Equal(t, "cites", req.Properties.RefProperties[0].ReferenceProperty)
+		assert.True(t, req.Metadata.Uuid)
+		assert.False(t, req.Metadata.Vector)
+
+		md := server.lastMetadata()
+		assert.Equal(t, []string{"Bearer secret"}, md.Get("authorization"))
+		assert.Equal(t, []string{"sk"}, md.Get("x-openai-api-key"))
+
+		// the search isn't sent through graphql
+		assert.NotContains(t, paths(), "/v1/graphql")
+	})
+
+	t.Run("should search near vectors with thresholds", func(t *testing.T) {
+		_, uri, _ := newWeaviateServer(t, "1.30.0")
+		server := newGRPCServer(t)
+
+		weaviate := connect(t, &models.Connection{
+			URI:      uri,
+			GRPCPort: utils.Pointer(server.port),
+		})
+
+		_, err := weaviate.Search(connectionID, "Article", "", "nearVector", "[0.5, 1]", SearchOptions{
+			Distance: 0.25,
+		})
+		require.NoError(t, err)
+
+		req := server.lastSearch()
+		assert.Equal(t, uint32(100), req.Limit)
+		assert.Equal(t, 0.25, req.NearVector.GetDistance())
+		assert.Nil(t, req.NearVector.Certainty)
+		assert.Equal(t, []float32{0.5, 1}, byteops.Fp32SliceFromBytes(req.NearVector.VectorBytes))
+	})
+
+	t.Run("should generate from the results through grpc", func(t *testing.T) {
+		_, uri, _ := newWeaviateServer(t, "1.30.0")
+		server := newGRPCServer(t)
+
+		weaviate := connect(t, &models.Connection{
+			URI:      uri,
+			GRPCPort: utils.Pointer(server.port),
+		})
+
+		res, err := weaviate.Search(connectionID, "Article", "", "bm25", "weaviate", SearchOptions{
+			Generative: &GenerativeOptions{
+				SinglePrompt:      "Summarize {title}",
+				GroupedTask:       "What do they have in common?",
+				GroupedProperties: []string{"title"},
+				Provider:          "openai",
+				Model:             "gpt-4o",
+			},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, res.Objects, 1)
+		assert.Equal(t, "single", res.Objects[0].Generated)
+		assert.Equal(t, "grouped", res.GeneratedGrouped)
+
+		req := server.lastSearch()
+		assert.Equal(t, "Summarize {title}", req.Generative.Single.Prompt)
+		assert.Equal(t, "gpt-4o", req.Generative.Single.Queries[0].GetOpenai().GetModel())
+		assert.Equal(t, "What do they have in common?", req.Generative.Grouped.Task)
+		assert.Equal(t, []string{"title"}, req.Generative.Grouped.Properties.Values)
+		assert.Equal(t, "gpt-4o", req.Generative.Grouped.Queries[0].GetOpenai().GetModel())
+	})
+
+	t.Run("should page objects through grpc", func(t *testing.T) {
+		_, uri, paths := newWeaviateServer(t, "1.30.0")
+		server := newGRPCServer(t)
+
+		weaviate := connect(t, &models.Connection{
+			URI:      uri,
+			GRPCPort: utils.Pointer(server.port),
+		})
+
+		res, err := weaviate.GetObjectsPage(connectionID, ObjectsPageInput{
+			Collection:    "Article",
+			Cursor:        "cursor",
+			PageSize:      20,
+			IncludeVector: true,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, res.Objects, 1)
+		assert.Equal(t, []float32{0.5, 1}, res.Objects[0].Vector)
+
+		req := server.lastSearch()
+		assert.Equal(t, "cursor", req.After)
+		assert.Equal(t, uint32(20), req.Limit)
+		assert.True(t, req.Metadata.Vector)
+		assert.NotContains(t, paths(), "/v1/objects")
+	})
+
+	t.Run("should retrieve the schema once per page walk", func(t *testing.T) {
+		_, uri, paths := newWeaviateServer(t, "1.30.0")
+		server := newGRPCServer(t)
+
+		weaviate := connect(t, &models.Connection{
+			URI:      uri,
+			GRPCPort: utils.Pointer(server.port),
+		})
+
+		for _, cursor := range []string{"", "first", "second", "", "first"} {
+			_, err := weaviate.GetObjectsPage(connectionID, ObjectsPageInput{
+				Collection: "Article",
+				Cursor:     cursor,
+				PageSize:   20,
+			})
+			require.NoError(t, err)
+		}
+
+		schemas := slices.DeleteFunc(paths(), func(path string) bool { return path != "/v1/schema/Article" })
+		assert.Len(t, schemas, 2)
+	})
+
+	t.Run("should browse filtered objects through grpc", func(t *testing.T) {
+		_, uri, _ := newWeaviateServer(t, "1.30.0")
+		server := newGRPCServer(t)
+
+		weaviate := connect(t, &models.Connection{
+			URI:      uri,
+			GRPCPort: utils.Pointer(server.port),
+		})
+
+		_, err := weaviate.GetObjectsFiltered(connectionID, FilteredObjectsInput{
+			Collection: "Article",
+			Filter: &Filter{
+				Path:     []string{"views"},
+				Operator: "GreaterThan",
+				Value:    10,
+			},
+			Offset: 40,
+			Limit:  20,
+		})
+		require.NoError(t, err)
+
+		req := server.lastSearch()
+		assert.Equal(t, uint32(40), req.Offset)
+		assert.Equal(t, uint32(20), req.Limit)
+		require.NotNil(t, req.Filters)
+		assert.Equal(t, pb.Filters_OPERATOR_GREATER_THAN, req.Filters.Operator)
+	})
+
+	t.Run("should browse filtered objects sorted by id through grpc", func(t *testing.T) {
+		_, uri, _ := newWeaviateServer(t, "1.30.0")
+		server := newGRPCServer(t)
+
+		weaviate := connect(t, &models.Connection{
+			URI:      uri,
+			GRPCPort: utils.Pointer(server.port),
+		})
+
+		_, err := weaviate.GetObjectsFiltered(connectionID, FilteredObjectsInput{
+			Collection: "Article",
+			Filter: &Filter{
+				Path:     []string{"id"},
+				Operator: "GreaterThan",
+				Value:    grpcObjectID,
+			},
+			Limit:    20,
+			SortByID: true,
+		})
+		require.NoError(t, err)
+
+		req := server.lastSearch()
+		assert.Equal(t, uint32(0), req.Offset)
+		require.Len(t, req.SortBy, 1)
+		assert.Equal(t, []string{"_id"}, req.SortBy[0].Path)
+		assert.True(t, req.SortBy[0].Ascending)
+	})
+
+	t.Run("should batch objects through grpc", func(t *testing.T) {
+		_, uri, paths := newWeaviateServer(t, "1.30.0")
+		server := newGRPCServer(t)
+		server.batchErrors = []*pb.BatchObjectsReply_BatchError{{Index: 1, Error: "invalid title"}}
+
+		weaviate := connect(t, &models.Connection{
+			URI:      uri,
+			GRPCPort: utils.Pointer(server.port),
+		})
+
+		col := &weaviate_models.Class{
+			Class:      "Article",
+			Properties: []*weaviate_models.Property{{Name: "title", DataType: []string{"text"}}},
+		}
+		batchErrors, err := weaviate.BatchCreateObjects(connectionID, col, []ObjectInput{
+			{Collection: "Article", ID: grpcObjectID, Properties: map[string]any{"title": "a"}},
+			{Collection: "Article", Properties: map[string]any{"title": "b"}},
+		})
+		require.NoError(t, err)
+
+		req := server.lastBatch()
+		require.Len(t, req.Objects, 2)
+		assert.Equal(t, grpcObjectID, req.Objects[0].Uuid)
+		// the generated id is reported with the error
+		require.Len(t, batchErrors, 1)
+		assert.Equal(t, 1, batchErrors[0].Index)
+		assert.Equal(t, req.Objects[1].Uuid, batchErrors[0].ID)
+		assert.Equal(t, "invalid title", batchErrors[0].Message)
+		assert.NotContains(t, paths(), "/v1/batch/objects")
+		assert.NotContains(t, paths(), "/v1/schema/Article")
+	})
+
+	t.Run("should dial grpc through the proxy", func(t *testing.T) {
+		handler, _, _ := newWeaviateServer(t, "1.30.0")
+		server := newGRPCServer(t)
+		grpcAddr := net.JoinHostPort("127.0.0.1", strconv.FormatInt(server.port, 10))
+
+		t.Run("HTTP CONNECT", func(t *testing.T) {
+			proxyURL, targets := newConnectProxy(t, handler)
+
+			weaviate := connect(t, &models.Connection{
+				URI:      "http://weaviate.internal:8080",
+				ProxyURL: utils.Pointer(proxyURL),
+				GRPCHost: utils.Pointer("127.0.0.1"),
+				GRPCPort: utils.Pointer(server.port),
+			})
+
+			_, err := weaviate.Search(connectionID, "Article", "", "bm25", "weaviate", SearchOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, "weaviate", server.lastSearch().Bm25Search.Query)
+			assert.Equal(t, []string{grpcAddr}, targets())
+		})
+
+		t.Run("SOCKS5", func(t *testing.T) {
+			_, uri, _ := newWeaviateServer(t, "1.30.0")
+			proxyAddr, targets := newSOCKS5Server(t)
+
+			weaviate := connect(t, &models.Connection{
+				URI:      uri,
+				ProxyURL: utils.Pointer("socks5://" + proxyAddr),
+				GRPCHost: utils.Pointer("127.0.0.1"),
+				GRPCPort: utils.Pointer(server.port),
+			})
+
+			_, err := weaviate.Search(connectionID, "Article", "", "bm25", "weaviate", SearchOptions{})
+			require.NoError(t, err)
+			assert.Contains(t, targets(), grpcAddr)
+		})
+	})
+
+	t.Run("should fall back to graphql when grpc is unavailable", func(t *testing.T) {
+		_, uri, paths := newWeaviateServer(t, "1.30.0")
+
+		weaviate := connect(t, &models.Connection{
+			URI:      uri,
+			GRPCHost: utils.Pointer("127.0.0.1"),
+			GRPCPort: utils.Pointer(closedPort(t)),
+		})
+
+		client, _ := weaviate.clients.get(connectionID)
+		assert.Nil(t, client.grpc)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "bm25", "weaviate", SearchOptions{})
+		require.NoError(t, err)
+		require.Len(t, res.Objects, 1)
+		assert.Equal(t, "from graphql", res.Objects[0].Properties.(map[string]any)["title"])
+		assert.Contains(t, paths(), "/v1/graphql")
+	})
+
+	t.Run("should not send credentials through grpc without TLS", func(t *testing.T) {
+		_, uri, paths := newWeaviateServer(t, "1.30.0")
+		server := newGRPCServer(t)
+
+		weaviate := connect(t, &models.Connection{
+			URI:      uri,
+			ApiKey:   utils.Pointer("secret"),
+			GRPCPort: utils.Pointer(server.port),
+		})
+
+		client, _ := weaviate.clients.get(connectionID)
+		assert.Nil(t, client.grpc)
+
+		_, err := weaviate.Search(connectionID, "Article", "", "bm25", "weaviate", SearchOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, paths(), "/v1/graphql")
+	})
+
+	t.Run("should fall back to graphql for versions without grpc", func(t *testing.T) {
+		_, uri, _ := newWeaviateServer(t, "1.24.0")
+		server := newGRPCServer(t)
+
+		weaviate := connect(t, &models.Connection{
+			URI:      uri,
+			GRPCPort: utils.Pointer(server.port),
+		})
+
+		client, _ := weaviate.clients.get(connectionID)
+		assert.Nil(t, client.grpc)
+	})
+
+	t.Run("should fail testing a connection with an unavailable grpc api", func(t *testing.T) {
+		_, uri, _ := newWeaviateServer(t, "1.30.0")
+		weaviate := New(NewMockStorage(t), Configuration{StatusUpdateInterval: time.Hour})
+
+		err := weaviate.TestConnection(TestConnectionInput{
+			URI:      uri,
+			GRPCHost: utils.Pointer("127.0.0.1"),
+			GRPCPort: utils.Pointer(closedPort(t)),
+		})
+		assert.ErrorContains(t, err, "failed checking grpc api 127.0.0.1:")
+
+		err = weaviate.TestConnection(TestConnectionInput{
+			URI:      uri,
+			GRPCPort: utils.Pointer(int64(70000)),
+		})
+		assert.EqualError(t, err, "invalid grpc port 70000")
+
+		server := newGRPCServer(t)
+		err = weaviate.TestConnection(TestConnectionInput{
+			URI:      uri,
+			GRPCPort: utils.Pointer(server.port),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should send the OIDC access token", func(t *testing.T) {
+		creds := grpcCredentials{
+			headers: map[string]string{"X-Custom": "value"},
+			tokens:  oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		}
+
+		md, err := creds.GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"authorization": "Bearer token",
+			"x-custom":      "value",
+		}, md)
+	})
+}
+
+// grpcServer serves the search and batch calls of the gRPC API of weaviate, the
+// requests and their metadata are recorded
+type grpcServer struct {
+	pb.UnimplementedWeaviateServer
+
+	port        int64
+	batchErrors []*pb.BatchObjectsReply_BatchError
+
+	mu        sync.Mutex
+	searches  []*pb.SearchRequest
+	batches   []*pb.BatchObjectsRequest
+	metadata  []metadata.MD
+	deadlines []time.Time
+}
+
+func newGRPCServer(t *testing.T, opts ...grpc.ServerOption) *grpcServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &grpcServer{port: int64(listener.Addr().(*net.TCPAddr).Port)}
+	server := grpc.NewServer(opts...)
+	pb.RegisterWeaviateServer(server, s)
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return s
+}
+
+func (s *grpcServer) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchReply, error) {
+	s.record(ctx, func() { s.searches = append(s.searches, req) })
+
+	counts := binary.LittleEndian.AppendUint64(nil, 1)
+	counts = binary.LittleEndian.AppendUint64(counts, uint64(0xFFFFFFFFFFFFFFFE))
+
+	md := &pb.MetadataResult{
+		Id:                 grpcObjectID,
+		CreationTimeUnix:   1,
+		LastUpdateTimeUnix: 2,
+	}
+	if req.Metadata.GetVector() {
+		md.VectorBytes = byteops.Fp32SliceToBytes([]float32{0.5, 1})
+	}
+	if req.Metadata.GetDistance() {
+		md.Distance, md.DistancePresent = 0.25, true
+	}
+	if req.Metadata.GetCertainty() {
+		md.Certainty, md.CertaintyPresent = 0.875, true
+	}
+	if req.Metadata.GetScore() {
+		md.Score, md.ScorePresent = 0.75, true
+	}
+	if req.Metadata.GetExplainScore() {
+		md.ExplainScore, md.ExplainScorePresent = grpcExplainScore, true
+	}
+	if req.GetRerank() != nil {
+		md.RerankScore, md.RerankScorePresent = 0.9, true
+	}
+	for _, name := range req.Metadata.GetVectors() {
+		md.Vectors = append(md.Vectors, &pb.Vectors{
+			Name:        name,
+			VectorBytes: byteops.Fp32SliceToBytes([]float32{1, 0}),
+		})
+	}
+
+	result := &pb.SearchResult{
+		Metadata: md,
+		Properties: &pb.PropertiesResult{
+			NonRefProps: &pb.Properties{Fields: map[string]*pb.Value{
+				"title": {Kind: &pb.Value_TextValue{TextValue: "Weaviate"}},
+				"views": {Kind: &pb.Value_IntValue{IntValue: 42}},
+				"tags": {Kind: &pb.Value_ListValue{ListValue: &pb.ListValue{
+					Kind: &pb.ListValue_TextValues{
+						TextValues: &pb.TextValues{Values: []string{"db", "vector"}},
+					},
+				}}},
+				"counts": {Kind: &pb.Value_ListValue{ListValue: &pb.ListValue{
+					Kind: &pb.ListValue_IntValues{IntValues: &pb.IntValues{Values: counts}},
+				}}},
+				"ratings": {Kind: &pb.Value_ListValue{ListValue: &pb.ListValue{
+					Kind: &pb.ListValue_NumberValues{NumberValues: &pb.NumberValues{
+						Values: byteops.Fp64SliceToBytes([]float64{0.5, 1}),
+					}},
+				}}},
+				"author": {Kind: &pb.Value_ObjectValue{ObjectValue: &pb.Properties{
+					Fields: map[string]*pb.Value{
+						"name": {Kind: &pb.Value_TextValue{TextValue: "Ada"}},
+					},
+				}}},
+				"location": {Kind: &pb.Value_GeoValue{
+					GeoValue: &pb.GeoCoordinate{Latitude: 52.5, Longitude: 13.4},
+				}},
+				"missing": {Kind: &pb.Value_NullValue{}},
+			}},
+			RefProps: []*pb.RefPropertiesResult{{
+				PropName: "cites",
+				Properties: []*pb.PropertiesResult{{
+					TargetCollection: "Article",
+					Metadata:         &pb.MetadataResult{Id: grpcCitedID},
+				}},
+			}},
+		},
+	}
+	reply := &pb.SearchReply{Results: []*pb.SearchResult{result}}
+
+	if req.Generative.GetSingle() != nil {
+		result.Generative = &pb.GenerativeResult{Values: []*pb.GenerativeReply{{Result: "single"}}}
+	}
+	if req.Generative.GetGrouped() != nil {
+		reply.GenerativeGroupedResults = &pb.GenerativeResult{
+			Values: []*pb.GenerativeReply{{Result: "grouped"}},
+		}
+	}
+
+	return reply, nil
+}
+
+func (s *grpcServer) BatchObjects(
+	ctx context.Context,
+	req *pb.BatchObjectsRequest,
+) (*pb.BatchObjectsReply, error) {
+	s.record(ctx, func() { s.batches = append(s.batches, req) })
+
+	return &pb.BatchObjectsReply{Errors: s.batchErrors}, nil
+}
+
+func (s *grpcServer) record(ctx context.Context, f func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.metadata = append(s.metadata, md)
+	deadline, _ := ctx.Deadline()
+	s.deadlines = append(s.deadlines, deadline)
+	f()
+}
+
+func (s *grpcServer) lastSearch() *pb.SearchRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.searches[len(s.searches)-1]
+}
+
+func (s *grpcServer) lastBatch() *pb.BatchObjectsRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.batches[len(s.batches)-1]
+}
+
+func (s *grpcServer) lastMetadata() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.metadata[len(s.metadata)-1]
+}
+
+func (s *grpcServer) lastDeadline() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.deadlines[len(s.deadlines)-1]
+}
+
+// newConnectProxy returns a HTTP proxy tunneling the CONNECT requests, the other
+// requests are served by the handler. The addresses of the CONNECT requests are
+// recorded.
+func newConnectProxy(t *testing.T, handler http.Handler) (string, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	targets := []string{}
+
+	server := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		mu.Lock()
+		targets = append(targets, r.Host)
+		mu.Unlock()
+
+		target, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		conn, _, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			target.Close()
+			return
+		}
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+
+		go pipe(conn, target)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string{}, targets...)
+	}
+}
+
+// closedPort returns a port nothing listens on
+func closedPort(t *testing.T) int64 {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	return int64(port)
+}
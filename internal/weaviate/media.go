@@ -0,0 +1,161 @@
+package weaviate
+
+import (
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+// searchMedia are the media searched by the media search types
+var searchMedia = map[string]string{
+	"nearImage": "image",
+	"nearAudio": "audio",
+	"nearVideo": "video",
+}
+
+// maxMediaSize limits the files of media searches, they are sent base64 encoded within
+// a single request
+const maxMediaSize = 20 << 20
+
+// mediaExtensions are the file types accepted per medium
+var mediaExtensions = map[string][]string{
+	"image": {".bmp", ".gif", ".jpeg", ".jpg", ".png", ".tif", ".tiff", ".webp"},
+	"audio": {".aac", ".flac", ".m4a", ".mp3", ".ogg", ".wav"},
+	"video": {".avi", ".mkv", ".mov", ".mp4", ".webm"},
+}
+
+// mediaModules are the vectorizer modules with the media they vectorize
+var mediaModules = map[string][]string{
+	"img2vec-neural":     {"image"},
+	"multi2vec-aws":      {"image"},
+	"multi2vec-bind":     {"image", "audio", "video"},
+	"multi2vec-clip":     {"image"},
+	"multi2vec-cohere":   {"image"},
+	"multi2vec-google":   {"image", "video"},
+	"multi2vec-jinaai":   {"image"},
+	"multi2vec-nvidia":   {"image"},
+	"multi2vec-palm":     {"image", "video"},
+	"multi2vec-voyageai": {"image"},
+}
+
+// mediaQuery reads the file of a media search and returns it base64 encoded. The
+// vectorizers of the searched vectors are checked to be enabled and to support the
+// medium before the file is read.
+func (w *Weaviate) mediaQuery(
+	connectionID int64,
+	col *models.Class,
+	medium, path string,
+	targets []string,
+) (string, error) {
+	res, err := w.GetModules(connectionID)
+	if err != nil {
+		return "", err
+	}
+	modules, _ := res.(map[string]any)
+
+	vectorizers := mediaVectorizers(col, targets)
+	for _, target := range slices.Sorted(maps.Keys(vectorizers)) {
+		module := vectorizers[target]
+		of := col.Class
+		if target != "" {
+			of = fmt.Sprintf("named vector %q of %s", target, col.Class)
+		}
+
+		if module == "" || module == "none" {
+			return "", fmt.Errorf("%s has no vectorizer to search %s", of, medium)
+		}
+		if _, ok := modules[module]; !ok {
+			return "", fmt.Errorf("vectorizer %s of %s isn't enabled in weaviate", module, of)
+		}
+		if !slices.Contains(mediaModules[module], medium) {
+			return "", fmt.Errorf("vectorizer %s of %s doesn't support %s search", module, of, medium)
+		}
+	}
+
+	data, err := readMediaFile(medium, path)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// readMediaFile reads the file after validating its type and size
+func readMediaFile(medium, path string) ([]byte, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if !slices.Contains(mediaExtensions[medium], ext) {
+		return nil, fmt.Errorf(
+			"unsupported %s file type %q, expected one of %s",
+			medium, ext, strings.Join(mediaExtensions[medium], ", "),
+		)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading %s file: %w", medium, err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+	if info.Size() > maxMediaSize {
+		return nil, fmt.Errorf(
+			"%s file of %d MB exceeds the limit of %d MB",
+			medium, info.Size()>>20, maxMediaSize>>20,
+		)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading %s file: %w", medium, err)
+	}
+	if contentType := http.DetectContentType(data); !mediaContent(medium, contentType) {
+		return nil, fmt.Errorf("content of %s is %s, expected %s", filepath.Base(path), contentType, medium)
+	}
+
+	return data, nil
+}
+
+// mediaContent checks the sniffed content type of a file, the sniffing only knows a few
+// audio and video formats so unknown binary content is accepted
+func mediaContent(medium, contentType string) bool {
+	switch {
+	case strings.HasPrefix(contentType, medium+"/"), contentType == "application/octet-stream":
+		return true
+	case medium == "audio":
+		// ogg and mp4 containers hold audio as well
+		return contentType == "application/ogg" || contentType == "video/mp4"
+	default:
+		return false
+	}
+}
+
+// mediaVectorizers are the vectorizer modules of the searched vectors by target vector,
+// all named vectors without targets and the vectorizer of the collection without
+// named vectors by an empty target
+func mediaVectorizers(col *models.Class, targets []string) map[string]string {
+	if len(col.VectorConfig) == 0 {
+		return map[string]string{"": col.Vectorizer}
+	}
+
+	if len(targets) == 0 {
+		targets = slices.Sorted(maps.Keys(col.VectorConfig))
+	}
+
+	vectorizers := make(map[string]string, len(targets))
+	for _, target := range targets {
+		vectorizers[target] = ""
+		config, _ := col.VectorConfig[target].Vectorizer.(map[string]any)
+		for module := range config {
+			vectorizers[target] = module
+		}
+	}
+
+	return vectorizers
+}
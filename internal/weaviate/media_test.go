@@ -0,0 +1,180 @@
+package weaviate
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestMediaSearch(t *testing.T) {
+	connectionID := int64(1)
+	class := &weaviate_models.Class{
+		Class:      "Product",
+		Properties: []*weaviate_models.Property{{Name: "name", DataType: []string{"text"}}},
+		VectorConfig: map[string]weaviate_models.VectorConfig{
+			"photo": {
+				Vectorizer:        map[string]any{"multi2vec-clip": map[string]any{}},
+				VectorIndexConfig: map[string]any{},
+			},
+			"clip": {
+				Vectorizer:        map[string]any{"multi2vec-bind": map[string]any{}},
+				VectorIndexConfig: map[string]any{},
+			},
+			"description": {
+				Vectorizer:        map[string]any{"text2vec-openai": map[string]any{}},
+				VectorIndexConfig: map[string]any{},
+			},
+		},
+	}
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 16)...)
+	wav := append([]byte("RIFF\x00\x00\x00\x00WAVEfmt "), make([]byte, 16)...)
+
+	// writeFile writes the content to a file with the name in a temporary directory
+	writeFile := func(t *testing.T, name string, content []byte) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, content, 0o600))
+
+		return path
+	}
+
+	search := searchTest{
+		connectionID: connectionID,
+		modules: map[string]any{
+			"multi2vec-clip":  map[string]any{"clip_model": "ViT-B-32"},
+			"text2vec-openai": map[string]any{},
+		},
+		classes: []*weaviate_models.Class{class},
+	}
+
+	t.Run("should search near an image file through grpc", func(t *testing.T) {
+		server := newGRPCServer(t)
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &[]string{}, server)
+
+		path := writeFile(t, "shoe.PNG", png)
+		_, err := weaviate.Search(connectionID, "Product", "", "nearImage", path, SearchOptions{
+			TargetVectors: []string{"photo"},
+			Certainty:     0.5,
+		})
+		require.NoError(t, err)
+
+		req := server.lastSearch()
+		assert.Equal(t, base64.StdEncoding.EncodeToString(png), req.NearImage.Image)
+		assert.Equal(t, 0.5, req.NearImage.GetCertainty())
+		assert.Nil(t, req.NearImage.Distance)
+		assert.Equal(t, []string{"photo"}, req.NearImage.Targets.TargetVectors)
+	})
+
+	t.Run("should search near an image file through graphql", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{"data": {"Get": {"Product": []}}}`, &queries, nil)
+
+		path := writeFile(t, "shoe.png", png)
+		_, err := weaviate.Search(connectionID, "Product", "", "nearImage", path, SearchOptions{
+			TargetVectors: []string{"photo"},
+			Distance:      0.2,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], `nearImage:{image: "`+base64.StdEncoding.EncodeToString(png)+
+			`" distance: 0.2 targetVectors: ["photo"]}`)
+	})
+
+	t.Run("should not search if the media file or vectorizer is invalid", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			searchType string
+			file       string
+			content    []byte
+			opts       SearchOptions
+			expected   string
+		}{
+			{
+				name:       "unsupported file type",
+				searchType: "nearImage",
+				file:       "shoe.svg",
+				content:    []byte("<svg></svg>"),
+				opts:       SearchOptions{TargetVectors: []string{"photo"}},
+				expected: `unsupported image file type ".svg", expected one of ` +
+					".bmp, .gif, .jpeg, .jpg, .png, .tif, .tiff, .webp",
+			},
+			{
+				name:       "content of another type",
+				searchType: "nearImage",
+				file:       "shoe.jpg",
+				content:    []byte("not an image"),
+				opts:       SearchOptions{TargetVectors: []string{"photo"}},
+				expected:   "content of shoe.jpg is text/plain; charset=utf-8, expected image",
+			},
+			{
+				name:       "vectorizer without the medium",
+				searchType: "nearImage",
+				file:       "shoe.png",
+				content:    png,
+				opts:       SearchOptions{TargetVectors: []string{"photo", "description"}},
+				expected: `vectorizer text2vec-openai of named vector "description" of Product ` +
+					"doesn't support image search",
+			},
+			{
+				name:       "vectorizer not enabled",
+				searchType: "nearAudio",
+				file:       "squeak.wav",
+				content:    wav,
+				opts:       SearchOptions{TargetVectors: []string{"clip"}},
+				expected:   `vectorizer multi2vec-bind of named vector "clip" of Product isn't enabled in weaviate`,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				queries := []string{}
+				weaviate := newSearchTestWeaviate(t, search, `{}`, &queries, nil)
+
+				path := writeFile(t, tc.file, tc.content)
+				res, err := weaviate.Search(connectionID, "Product", "", tc.searchType, path, tc.opts)
+
+				assert.Nil(t, res)
+				assert.EqualError(t, err, tc.expected)
+				assert.Empty(t, queries)
+			})
+		}
+	})
+
+	t.Run("should not search if the media file is too large", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &queries, nil)
+
+		path := filepath.Join(t.TempDir(), "shoe.png")
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+		require.NoError(t, os.Truncate(path, maxMediaSize+1<<20))
+
+		res, err := weaviate.Search(connectionID, "Product", "", "nearImage", path, SearchOptions{
+			TargetVectors: []string{"photo"},
+		})
+
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "image file of 21 MB exceeds the limit of 20 MB")
+		assert.Empty(t, queries)
+	})
+
+	t.Run("should check the vectorizers before reading the media file", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &queries, nil)
+
+		path := filepath.Join(t.TempDir(), "missing.mp4")
+		res, err := weaviate.Search(connectionID, "Product", "", "nearVideo", path, SearchOptions{
+			TargetVectors: []string{"clip"},
+		})
+
+		assert.Nil(t, res)
+		assert.EqualError(t, err, `vectorizer multi2vec-bind of named vector "clip" of Product isn't enabled in weaviate`)
+		assert.Empty(t, queries)
+	})
+}
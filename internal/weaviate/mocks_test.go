@@ -93,3 +93,103 @@ func (_c *MockStorage_GetConnection_Call) RunAndReturn(run func(id int64, decryp
 	_c.Call.Return(run)
 	return _c
 }
+
+// SaveRequest provides a mock function for the type MockStorage
+func (_mock *MockStorage) SaveRequest(r models.RequestHistory) (int64, error) {
+	ret := _mock.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRequest")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(models.RequestHistory) (int64, error)); ok {
+		return returnFunc(r)
+	}
+	if returnFunc, ok := ret.Get(0).(func(models.RequestHistory) int64); ok {
+		r0 = returnFunc(r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(models.RequestHistory) error); ok {
+		r1 = returnFunc(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_SaveRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveRequest'
+type MockStorage_SaveRequest_Call struct {
+	*mock.Call
+}
+
+// SaveRequest is a helper method to define mock.On call
+//   - r
+func (_e *MockStorage_Expecter) SaveRequest(r interface{}) *MockStorage_SaveRequest_Call {
+	return &MockStorage_SaveRequest_Call{Call: _e.mock.On("SaveRequest", r)}
+}
+
+func (_c *MockStorage_SaveRequest_Call) Run(run func(r models.RequestHistory)) *MockStorage_SaveRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.RequestHistory))
+	})
+	return _c
+}
+
+func (_c *MockStorage_SaveRequest_Call) Return(n int64, err error) *MockStorage_SaveRequest_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockStorage_SaveRequest_Call) RunAndReturn(run func(r models.RequestHistory) (int64, error)) *MockStorage_SaveRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRefreshToken provides a mock function for the type MockStorage
+func (_mock *MockStorage) UpdateRefreshToken(id int64, refreshToken string) error {
+	ret := _mock.Called(id, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRefreshToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int64, string) error); ok {
+		r0 = returnFunc(id, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_UpdateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRefreshToken'
+type MockStorage_UpdateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// UpdateRefreshToken is a helper method to define mock.On call
+//   - id
+//   - refreshToken
+func (_e *MockStorage_Expecter) UpdateRefreshToken(id interface{}, refreshToken interface{}) *MockStorage_UpdateRefreshToken_Call {
+	return &MockStorage_UpdateRefreshToken_Call{Call: _e.mock.On("UpdateRefreshToken", id, refreshToken)}
+}
+
+func (_c *MockStorage_UpdateRefreshToken_Call) Run(run func(id int64, refreshToken string)) *MockStorage_UpdateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorage_UpdateRefreshToken_Call) Return(err error) *MockStorage_UpdateRefreshToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_UpdateRefreshToken_Call) RunAndReturn(run func(id int64, refreshToken string) error) *MockStorage_UpdateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,410 @@
+package weaviate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+// ObjectInput describes an object to be created, replaced or patched.
+type ObjectInput struct {
+	Collection string         `json:"collection"`
+	ID         string         `json:"id,omitempty"`
+	Tenant     string         `json:"tenant,omitempty"`
+	Properties map[string]any `json:"properties,omitempty"`
+	// Vector is an optional user supplied vector for collections without named vectors.
+	Vector []float32 `json:"vector,omitempty"`
+	// Vectors holds optional user supplied vectors keyed by the named vector.
+	Vectors map[string][]float32 `json:"vectors,omitempty"`
+}
+
+func (w *Weaviate) CreateObject(connectionID int64, input ObjectInput) (*WeaviateObject, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	col, err := c.w.Schema().ClassGetter().WithClassName(input.Collection).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving schema for %s: %w", input.Collection, err)
+	}
+
+	if err := validateObjectInput(col, input); err != nil {
+		return nil, err
+	}
+
+	creator := c.w.Data().Creator().
+		WithClassName(input.Collection).
+		WithProperties(input.Properties)
+
+	if input.ID != "" {
+		creator = creator.WithID(input.ID)
+	}
+	if input.Tenant != "" {
+		creator = creator.WithTenant(input.Tenant)
+	}
+	if len(input.Vector) > 0 {
+		creator = creator.WithVector(input.Vector)
+	}
+	if len(input.Vectors) > 0 {
+		creator = creator.WithVectors(toModelVectors(input.Vectors))
+	}
+
+	res, err := creator.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating object in %s: %w", input.Collection, err)
+	}
+
+	return toWeaviateObject(res.Object), nil
+}
+
+// ReplaceObject replaces all the properties of an existing object. Properties
+// not present on the input are removed from the object.
+func (w *Weaviate) ReplaceObject(connectionID int64, input ObjectInput) (*WeaviateObject, error) {
+	if err := w.updateObject(connectionID, input, false); err != nil {
+		return nil, err
+	}
+
+	return w.getObject(connectionID, input.Collection, input.ID, input.Tenant)
+}
+
+// PatchObject merges the given properties into an existing object leaving the
+// rest of the properties untouched.
+func (w *Weaviate) PatchObject(connectionID int64, input ObjectInput) (*WeaviateObject, error) {
+	if len(input.Properties) == 0 && len(input.Vector) == 0 && len(input.Vectors) == 0 {
+		return nil, errors.New("nothing to update, properties or vectors are required")
+	}
+
+	if err := w.updateObject(connectionID, input, true); err != nil {
+		return nil, err
+	}
+
+	return w.getObject(connectionID, input.Collection, input.ID, input.Tenant)
+}
+
+func (w *Weaviate) updateObject(connectionID int64, input ObjectInput, merge bool) error {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	if input.ID == "" {
+		return errors.New("object id is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	col, err := c.w.Schema().ClassGetter().WithClassName(input.Collection).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed retrieving schema for %s: %w", input.Collection, err)
+	}
+
+	if err := validateObjectInput(col, input); err != nil {
+		return err
+	}
+
+	updater := c.w.Data().Updater().
+		WithClassName(input.Collection).
+		WithID(input.ID).
+		WithProperties(input.Properties)
+
+	if merge {
+		updater = updater.WithMerge()
+	}
+	if input.Tenant != "" {
+		updater = updater.WithTenant(input.Tenant)
+	}
+	if len(input.Vector) > 0 {
+		updater = updater.WithVector(input.Vector)
+	}
+	if len(input.Vectors) > 0 {
+		updater = updater.WithVectors(toModelVectors(input.Vectors))
+	}
+
+	if err := updater.Do(ctx); err != nil {
+		return fmt.Errorf("failed updating object %s in %s: %w", input.ID, input.Collection, err)
+	}
+
+	return nil
+}
+
+func (w *Weaviate) getObject(
+	connectionID int64,
+	collection, id, tenant string,
+) (*WeaviateObject, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	getter := c.w.Data().ObjectsGetter().WithClassName(collection).WithID(id)
+	if tenant != "" {
+		getter = getter.WithTenant(tenant)
+	}
+
+	objects, err := getter.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving object %s from %s: %w", id, collection, err)
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("object %s not found in %s", id, collection)
+	}
+
+	return toWeaviateObject(objects[0]), nil
+}
+
+func toWeaviateObject(o *models.Object) *WeaviateObject {
+	return &WeaviateObject{
+		ID:                 o.ID.String(),
+		Class:              o.Class,
+		LastUpdateTimeUnix: o.LastUpdateTimeUnix,
+		CreationTimeUnix:   o.CreationTimeUnix,
+		Tenant:             o.Tenant,
+		Properties:         o.Properties,
+	}
+}
+
+func toModelVectors(vectors map[string][]float32) models.Vectors {
+	v := make(models.Vectors, len(vectors))
+	for name, vector := range vectors {
+		v[name] = vector
+	}
+
+	return v
+}
+
+// validateObjectInput checks the object payload against the collection schema so
+// we can return a meaningful error before sending the request to weaviate.
+func validateObjectInput(col *models.Class, input ObjectInput) error {
+	if input.ID != "" && !strfmt.IsUUID(input.ID) {
+		return fmt.Errorf("invalid object id %q: expected a UUID", input.ID)
+	}
+
+	if err := validateProperties(col.Properties, input.Properties); err != nil {
+		return err
+	}
+
+	if len(input.Vector) > 0 && len(col.VectorConfig) > 0 && col.VectorIndexType == "" {
+		return fmt.Errorf(
+			"collection %s uses named vectors, vector must be provided per target vector",
+			col.Class,
+		)
+	}
+
+	for name := range input.Vectors {
+		if _, ok := col.VectorConfig[name]; !ok {
+			return fmt.Errorf("named vector %q does not exist in collection %s", name, col.Class)
+		}
+	}
+
+	return nil
+}
+
+// propertyDefinition is the common denominator of models.Property and models.NestedProperty
+type propertyDefinition struct {
+	dataType         []string
+	nestedProperties []*models.NestedProperty
+}
+
+func propertyDefinitions(props []*models.Property) map[string]propertyDefinition {
+	definitions := make(map[string]propertyDefinition, len(props))
+	for _, p := range props {
+		definitions[p.Name] = propertyDefinition{
+			dataType:         p.DataType,
+			nestedProperties: p.NestedProperties,
+		}
+	}
+
+	return definitions
+}
+
+func nestedPropertyDefinitions(props []*models.NestedProperty) map[string]propertyDefinition {
+	definitions := make(map[string]propertyDefinition, len(props))
+	for _, p := range props {
+		definitions[p.Name] = propertyDefinition{
+			dataType:         p.DataType,
+			nestedProperties: p.NestedProperties,
+		}
+	}
+
+	return definitions
+}
+
+func validateProperties(props []*models.Property, values map[string]any) error {
+	return validateValues(propertyDefinitions(props), values, "")
+}
+
+func validateNestedProperties(
+	props []*models.NestedProperty,
+	values map[string]any,
+	parent string,
+) error {
+	return validateValues(nestedPropertyDefinitions(props), values, parent)
+}
+
+func validateValues(
+	definitions map[string]propertyDefinition,
+	values map[string]any,
+	parent string,
+) error {
+	// sorted so errors are deterministic when more than one property is invalid
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		value := values[name]
+		path := name
+		if parent != "" {
+			path = parent + "." + name
+		}
+
+		def, ok := definitions[name]
+		if !ok {
+			return fmt.Errorf("property %q does not exist in schema", path)
+		}
+
+		if value == nil || len(def.dataType) == 0 {
+			continue
+		}
+
+		if err := validateValue(def, value, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//nolint:gocognit // flat switch over weaviate data types
+func validateValue(def propertyDefinition, value any, path string) error {
+	dataType := def.dataType[0]
+
+	// cross references are declared with the target collection name(s)
+	if isCrossReference(dataType) {
+		refs, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("property %q expects a list of references", path)
+		}
+		for _, r := range refs {
+			ref, ok := r.(map[string]any)
+			if !ok {
+				return fmt.Errorf("property %q expects references in the form {\"beacon\": \"...\"}", path)
+			}
+			if _, ok := ref["beacon"].(string); !ok {
+				return fmt.Errorf("property %q expects references in the form {\"beacon\": \"...\"}", path)
+			}
+		}
+		return nil
+	}
+
+	if elementType, isArray := strings.CutSuffix(dataType, "[]"); isArray {
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("property %q expects an array of %s", path, elementType)
+		}
+		for i, item := range items {
+			itemDef := propertyDefinition{
+				dataType:         []string{elementType},
+				nestedProperties: def.nestedProperties,
+			}
+			if err := validateValue(itemDef, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch dataType {
+	case "text", "string", "blob":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("property %q expects a %s value", path, dataType)
+		}
+	case "uuid":
+		s, ok := value.(string)
+		if !ok || !strfmt.IsUUID(s) {
+			return fmt.Errorf("property %q expects a UUID", path)
+		}
+	case "date":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("property %q expects an RFC3339 date", path)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+			return fmt.Errorf("property %q expects an RFC3339 date: %w", path, err)
+		}
+	case "int":
+		f, ok := toFloat(value)
+		if !ok || f != math.Trunc(f) {
+			return fmt.Errorf("property %q expects an integer", path)
+		}
+	case "number":
+		if _, ok := toFloat(value); !ok {
+			return fmt.Errorf("property %q expects a number", path)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("property %q expects a boolean", path)
+		}
+	case "geoCoordinates":
+		geo, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("property %q expects {\"latitude\": number, \"longitude\": number}", path)
+		}
+		_, latOk := toFloat(geo["latitude"])
+		_, lonOk := toFloat(geo["longitude"])
+		if !latOk || !lonOk {
+			return fmt.Errorf("property %q expects {\"latitude\": number, \"longitude\": number}", path)
+		}
+	case "phoneNumber":
+		phone, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("property %q expects {\"input\": string}", path)
+		}
+		if _, ok := phone["input"].(string); !ok {
+			return fmt.Errorf("property %q expects {\"input\": string}", path)
+		}
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("property %q expects an object", path)
+		}
+		return validateNestedProperties(def.nestedProperties, obj, path)
+	}
+
+	return nil
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// isCrossReference reports whether the data type is a reference to another collection.
+// Collection names always start with an upper case letter while primitive types don't.
+func isCrossReference(dataType string) bool {
+	return dataType != "" && dataType[0] >= 'A' && dataType[0] <= 'Z'
+}
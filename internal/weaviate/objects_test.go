@@ -0,0 +1,226 @@
+package weaviate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestObjects(t *testing.T) {
+	connectionID := int64(1)
+	class := &weaviate_models.Class{
+		Class: "TestCollection",
+		Properties: []*weaviate_models.Property{
+			{Name: "title", DataType: []string{"text"}},
+			{Name: "views", DataType: []string{"int"}},
+			{Name: "tags", DataType: []string{"text[]"}},
+			{Name: "published", DataType: []string{"date"}},
+			{Name: "author", DataType: []string{"Author"}},
+			{
+				Name:     "meta",
+				DataType: []string{"object"},
+				NestedProperties: []*weaviate_models.NestedProperty{
+					{Name: "source", DataType: []string{"text"}},
+					{Name: "score", DataType: []string{"number"}},
+				},
+			},
+		},
+		VectorConfig: map[string]weaviate_models.VectorConfig{
+			"title_vector": {VectorIndexType: "hnsw"},
+		},
+	}
+
+	t.Run("validateObjectInput", func(t *testing.T) {
+		testCases := []struct {
+			name        string
+			input       ObjectInput
+			expectedErr string
+		}{
+			{
+				name: "should accept valid properties",
+				input: ObjectInput{
+					ID: "0e4ec2d6-1d6e-4b0e-8a3f-6b0f1a0d5c11",
+					Properties: map[string]any{
+						"title":     "mock-title",
+						"views":     float64(10),
+						"tags":      []any{"a", "b"},
+						"published": "2025-01-02T15:04:05Z",
+						"author": []any{
+							map[string]any{"beacon": "weaviate://localhost/Author/mock-id"},
+						},
+						"meta": map[string]any{"source": "mock-source", "score": 0.5},
+					},
+					Vectors: map[string][]float32{"title_vector": {0.1, 0.2}},
+				},
+			},
+			{
+				name:        "should reject invalid id",
+				input:       ObjectInput{ID: "mock-id"},
+				expectedErr: `invalid object id "mock-id": expected a UUID`,
+			},
+			{
+				name:        "should reject unknown property",
+				input:       ObjectInput{Properties: map[string]any{"unknown": "value"}},
+				expectedErr: `property "unknown" does not exist in schema`,
+			},
+			{
+				name:        "should reject unknown nested property",
+				input:       ObjectInput{Properties: map[string]any{"meta": map[string]any{"other": 1}}},
+				expectedErr: `property "meta.other" does not exist in schema`,
+			},
+			{
+				name:        "should reject non integer value",
+				input:       ObjectInput{Properties: map[string]any{"views": 1.5}},
+				expectedErr: `property "views" expects an integer`,
+			},
+			{
+				name:        "should reject wrong array element type",
+				input:       ObjectInput{Properties: map[string]any{"tags": []any{"a", float64(1)}}},
+				expectedErr: `property "tags[1]" expects a text value`,
+			},
+			{
+				name:        "should reject invalid date",
+				input:       ObjectInput{Properties: map[string]any{"published": "yesterday"}},
+				expectedErr: `property "published" expects an RFC3339 date: parsing time "yesterday" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "yesterday" as "2006"`,
+			},
+			{
+				name:        "should reject malformed reference",
+				input:       ObjectInput{Properties: map[string]any{"author": []any{"mock-id"}}},
+				expectedErr: `property "author" expects references in the form {"beacon": "..."}`,
+			},
+			{
+				name:        "should reject unnamed vector on named vectors collection",
+				input:       ObjectInput{Vector: []float32{0.1}},
+				expectedErr: "collection TestCollection uses named vectors, vector must be provided per target vector",
+			},
+			{
+				name:        "should reject unknown named vector",
+				input:       ObjectInput{Vectors: map[string][]float32{"body_vector": {0.1}}},
+				expectedErr: `named vector "body_vector" does not exist in collection TestCollection`,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := validateObjectInput(class, tc.input)
+				if tc.expectedErr == "" {
+					assert.NoError(t, err)
+					return
+				}
+
+				assert.EqualError(t, err, tc.expectedErr)
+			})
+		}
+	})
+
+	t.Run("CreateObject", func(t *testing.T) {
+		t.Run("should return error if connection doesn't exist", func(t *testing.T) {
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+
+			object, err := weaviate.CreateObject(connectionID, ObjectInput{})
+
+			assert.Nil(t, object)
+			assert.EqualError(t, err, "connection doesn't exist 1")
+		})
+
+		t.Run("should validate and create object with tenant", func(t *testing.T) {
+			mockServer := http_util.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case r.URL.Path == "/v1/schema/TestCollection" && r.Method == http.MethodGet:
+						json.NewEncoder(w).Encode(class)
+					case r.URL.Path == "/v1/objects" && r.Method == http.MethodPost:
+						body, _ := io.ReadAll(r.Body)
+
+						var object weaviate_models.Object
+						require.NoError(t, json.Unmarshal(body, &object))
+						assert.Equal(t, "TestCollection", object.Class)
+						assert.Equal(t, "mock-tenant", object.Tenant)
+						assert.Equal(
+							t,
+							map[string]any{"title": "mock-title"},
+							object.Properties,
+						)
+
+						object.ID = "0e4ec2d6-1d6e-4b0e-8a3f-6b0f1a0d5c11"
+						object.CreationTimeUnix = 1
+						json.NewEncoder(w).Encode(object)
+					case r.URL.Path == "/v1/meta":
+						w.Write([]byte(`{"version": "1.30.0"}`))
+					default:
+						t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+						t.Fail()
+					}
+				}),
+			)
+			t.Cleanup(mockServer.Close)
+
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+			client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+			require.NoError(t, err)
+			weaviate.clients.add(connectionID, client)
+
+			object, err := weaviate.CreateObject(connectionID, ObjectInput{
+				Collection: "TestCollection",
+				Tenant:     "mock-tenant",
+				Properties: map[string]any{"title": "mock-title"},
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, &WeaviateObject{
+				ID:               "0e4ec2d6-1d6e-4b0e-8a3f-6b0f1a0d5c11",
+				Class:            "TestCollection",
+				CreationTimeUnix: 1,
+				Tenant:           "mock-tenant",
+				Properties:       map[string]any{"title": "mock-title"},
+			}, object)
+		})
+
+		t.Run("should not send request if validation fails", func(t *testing.T) {
+			mockServer := http_util.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case r.URL.Path == "/v1/schema/TestCollection" && r.Method == http.MethodGet:
+						json.NewEncoder(w).Encode(class)
+						return
+					case r.URL.Path == "/v1/meta":
+						w.Write([]byte(`{"version": "1.30.0"}`))
+						return
+					}
+
+					t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+					t.Fail()
+				}),
+			)
+			t.Cleanup(mockServer.Close)
+
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+			client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+			require.NoError(t, err)
+			weaviate.clients.add(connectionID, client)
+
+			object, err := weaviate.CreateObject(connectionID, ObjectInput{
+				Collection: "TestCollection",
+				Properties: map[string]any{"views": "ten"},
+			})
+
+			assert.Nil(t, object)
+			assert.EqualError(t, err, `property "views" expects an integer`)
+		})
+	})
+}
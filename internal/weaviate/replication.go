@@ -0,0 +1,296 @@
+package weaviate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+const (
+	ReplicationCopy = "COPY"
+	ReplicationMove = "MOVE"
+)
+
+type ReplicationState struct {
+	// State is REGISTERED, HYDRATING, FINALIZING, DEHYDRATING, READY or CANCELLED
+	State     string   `json:"state"`
+	Errors    []string `json:"errors"`
+	StartedAt int64    `json:"startedAt,omitempty"`
+}
+
+type ReplicationOperation struct {
+	ID         string `json:"id"`
+	Collection string `json:"collection"`
+	Shard      string `json:"shard"`
+	SourceNode string `json:"sourceNode"`
+	TargetNode string `json:"targetNode"`
+	// Type is COPY or MOVE
+	Type               string             `json:"type"`
+	Status             ReplicationState   `json:"status"`
+	History            []ReplicationState `json:"history"`
+	ScheduledForCancel bool               `json:"scheduledForCancel"`
+	ScheduledForDelete bool               `json:"scheduledForDelete"`
+	Uncancelable       bool               `json:"uncancelable"`
+	StartedAt          int64              `json:"startedAt,omitempty"`
+}
+
+type ReplicationOperationsInput struct {
+	Collection     string `json:"collection,omitempty"`
+	Shard          string `json:"shard,omitempty"`
+	TargetNode     string `json:"targetNode,omitempty"`
+	IncludeHistory bool   `json:"includeHistory,omitempty"`
+}
+
+type ReplicateInput struct {
+	Collection string `json:"collection"`
+	Shard      string `json:"shard"`
+	SourceNode string `json:"sourceNode"`
+	TargetNode string `json:"targetNode"`
+	// Type is COPY or MOVE, defaults to COPY
+	Type string `json:"type,omitempty"`
+}
+
+type ShardReplicas struct {
+	Shard string `json:"shard"`
+	// Replicas are the nodes holding a replica of the shard
+	Replicas []string `json:"replicas"`
+}
+
+type ShardingState struct {
+	Collection string          `json:"collection"`
+	Shards     []ShardReplicas `json:"shards"`
+	// Nodes are the nodes of the cluster, including the ones without replicas of the collection
+	Nodes []string `json:"nodes"`
+}
+
+// GetReplicationOperations lists the replication operations sorted by the time they
+// started, most recent first. Weaviate only allows them from version 1.32 onwards.
+func (w *Weaviate) GetReplicationOperations(
+	connectionID int64,
+	input ReplicationOperationsInput,
+) ([]ReplicationOperation, error) {
+	if _, exists := w.clients.get(connectionID); !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := url.Values{}
+	if input.Collection != "" {
+		query.Set("collection", input.Collection)
+	}
+	if input.Shard != "" {
+		query.Set("shard", input.Shard)
+	}
+	if input.TargetNode != "" {
+		query.Set("targetNode", input.TargetNode)
+	}
+	if input.IncludeHistory {
+		query.Set("includeHistory", strconv.FormatBool(input.IncludeHistory))
+	}
+
+	var res []*models.ReplicationReplicateDetailsReplicaResponse
+	err := w.doREST(ctx, connectionID, http.MethodGet, "/replication/replicate/list", query, nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving replication operations: %w", err)
+	}
+
+	operations := make([]ReplicationOperation, 0, len(res))
+	for _, op := range res {
+		operations = append(operations, toReplicationOperation(op))
+	}
+	slices.SortFunc(operations, func(a, b ReplicationOperation) int {
+		return cmp.Or(cmp.Compare(b.StartedAt, a.StartedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	return operations, nil
+}
+
+// ReplicateShard starts copying or moving a shard replica to another node and
+// returns the id of the replication operation
+func (w *Weaviate) ReplicateShard(connectionID int64, input ReplicateInput) (string, error) {
+	if _, exists := w.clients.get(connectionID); !exists {
+		return "", fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	if input.Collection == "" || input.Shard == "" || input.SourceNode == "" || input.TargetNode == "" {
+		return "", errors.New("collection, shard, source and target node are required")
+	}
+	if input.SourceNode == input.TargetNode {
+		return "", errors.New("source and target node must be different")
+	}
+	replicationType := cmp.Or(input.Type, ReplicationCopy)
+	if replicationType != ReplicationCopy && replicationType != ReplicationMove {
+		return "", fmt.Errorf(
+			"invalid replication type %q, expected %s or %s",
+			input.Type,
+			ReplicationCopy,
+			ReplicationMove,
+		)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req := models.ReplicationReplicateReplicaRequest{
+		Collection: &input.Collection,
+		Shard:      &input.Shard,
+		SourceNode: &input.SourceNode,
+		TargetNode: &input.TargetNode,
+		Type:       &replicationType,
+	}
+
+	var res models.ReplicationReplicateReplicaResponse
+	err := w.doREST(ctx, connectionID, http.MethodPost, "/replication/replicate", nil, req, &res)
+	if err != nil {
+		return "", fmt.Errorf("failed replicating shard %s of %s: %w", input.Shard, input.Collection, err)
+	}
+	if res.ID == nil {
+		return "", errors.New("weaviate didn't return the replication operation id")
+	}
+
+	return res.ID.String(), nil
+}
+
+// CancelReplicationOperation stops the operation, it's kept in the CANCELLED state
+// until it's deleted
+func (w *Weaviate) CancelReplicationOperation(connectionID int64, id string) error {
+	if _, exists := w.clients.get(connectionID); !exists {
+		return fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := fmt.Sprintf("/replication/replicate/%s/cancel", url.PathEscape(id))
+	if err := w.doREST(ctx, connectionID, http.MethodPost, path, nil, nil, nil); err != nil {
+		return fmt.Errorf("failed cancelling replication operation %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// DeleteReplicationOperation removes the operation, an operation that is still running
+// is cancelled first
+func (w *Weaviate) DeleteReplicationOperation(connectionID int64, id string) error {
+	if _, exists := w.clients.get(connectionID); !exists {
+		return fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := fmt.Sprintf("/replication/replicate/%s", url.PathEscape(id))
+	if err := w.doREST(ctx, connectionID, http.MethodDelete, path, nil, nil, nil); err != nil {
+		return fmt.Errorf("failed deleting replication operation %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// GetShardingState returns the nodes holding a replica of each shard of the collection
+func (w *Weaviate) GetShardingState(connectionID int64, collection string) (*ShardingState, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var res models.ReplicationShardingStateResponse
+	err := w.doREST(
+		ctx,
+		connectionID,
+		http.MethodGet,
+		"/replication/sharding-state",
+		url.Values{"collection": {collection}},
+		nil,
+		&res,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving sharding state of %s: %w", collection, err)
+	}
+
+	nodes, err := c.w.Cluster().NodesStatusGetter().Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying node status: %w", err)
+	}
+
+	state := &ShardingState{
+		Collection: collection,
+		Shards:     []ShardReplicas{},
+		Nodes:      []string{},
+	}
+	for _, node := range nodes.Nodes {
+		state.Nodes = append(state.Nodes, node.Name)
+	}
+	slices.Sort(state.Nodes)
+
+	if res.ShardingState != nil {
+		for _, s := range res.ShardingState.Shards {
+			replicas := slices.Clone(s.Replicas)
+			slices.Sort(replicas)
+			state.Shards = append(state.Shards, ShardReplicas{Shard: s.Shard, Replicas: replicas})
+		}
+	}
+	slices.SortFunc(state.Shards, func(a, b ShardReplicas) int {
+		return cmp.Compare(a.Shard, b.Shard)
+	})
+
+	return state, nil
+}
+
+func toReplicationOperation(op *models.ReplicationReplicateDetailsReplicaResponse) ReplicationOperation {
+	operation := ReplicationOperation{
+		ID:                 deref(op.ID).String(),
+		Collection:         deref(op.Collection),
+		Shard:              deref(op.Shard),
+		SourceNode:         deref(op.SourceNode),
+		TargetNode:         deref(op.TargetNode),
+		Type:               deref(op.Type),
+		History:            []ReplicationState{},
+		ScheduledForCancel: op.ScheduledForCancel,
+		ScheduledForDelete: op.ScheduledForDelete,
+		Uncancelable:       op.Uncancelable,
+		StartedAt:          op.WhenStartedUnixMs,
+	}
+	if op.Status != nil {
+		operation.Status = toReplicationState(op.Status)
+	}
+	for _, s := range op.StatusHistory {
+		operation.History = append(operation.History, toReplicationState(s))
+	}
+
+	return operation
+}
+
+func toReplicationState(s *models.ReplicationReplicateDetailsReplicaStatus) ReplicationState {
+	state := ReplicationState{
+		State:     s.State,
+		Errors:    []string{},
+		StartedAt: s.WhenStartedUnixMs,
+	}
+	for _, e := range s.Errors {
+		state.Errors = append(state.Errors, e.Message)
+	}
+
+	return state
+}
+
+func deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
@@ -0,0 +1,237 @@
+package weaviate
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplication(t *testing.T) {
+	connectionID := int64(1)
+	operationID := "00000000-0000-0000-0000-000000000001"
+
+	// newWeaviate serves the replication endpoints of a 3 node cluster, recording the
+	// operations changed
+	newWeaviate := func(t *testing.T, requests *[]string) *Weaviate {
+		t.Helper()
+
+		var mu sync.Mutex
+		mockServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case r.URL.Path == "/v1/meta":
+				w.Write([]byte(`{"version": "1.32.0"}`))
+			case r.URL.Path == "/v1/replication/replicate/list":
+				*requests = append(*requests, "list "+r.URL.RawQuery)
+				w.Write([]byte(`[
+					{
+						"id":"00000000-0000-0000-0000-000000000001","collection":"Article","shard":"shard-a",
+						"sourceNode":"node-1","targetNode":"node-3","type":"COPY","whenStartedUnixMs":100,
+						"status":{"state":"READY","errors":[]}
+					},
+					{
+						"id":"00000000-0000-0000-0000-000000000002","collection":"Article","shard":"shard-b",
+						"sourceNode":"node-2","targetNode":"node-3","type":"MOVE","whenStartedUnixMs":200,
+						"status":{"state":"HYDRATING","errors":[{"message":"target node busy"}]},
+						"statusHistory":[{"state":"REGISTERED","whenStartedUnixMs":200}]
+					}
+				]`))
+			case r.URL.Path == "/v1/replication/sharding-state":
+				assert.Equal(t, "Article", r.URL.Query().Get("collection"))
+				w.Write([]byte(`{"shardingState":{"collection":"Article","shards":[
+					{"shard":"shard-b","replicas":["node-2","node-1"]},
+					{"shard":"shard-a","replicas":["node-1"]}
+				]}}`))
+			case r.URL.Path == "/v1/nodes":
+				w.Write([]byte(`{"nodes":[{"name":"node-3"},{"name":"node-1"},{"name":"node-2"}]}`))
+			case r.URL.Path == "/v1/replication/replicate/00000000-0000-0000-0000-000000000404/cancel":
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				*requests = append(*requests, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+
+				switch r.Method {
+				case http.MethodPost:
+					if strings.HasSuffix(r.URL.Path, "/cancel") {
+						w.WriteHeader(http.StatusNoContent)
+						return
+					}
+					w.Write([]byte(`{"id":"` + operationID + `"}`))
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}
+		}))
+		t.Cleanup(mockServer.Close)
+
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+		client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+		require.NoError(t, err)
+		weaviate.clients.add(connectionID, client)
+
+		return weaviate
+	}
+
+	t.Run("GetReplicationOperations", func(t *testing.T) {
+		t.Run("should list operations most recent first", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			operations, err := weaviate.GetReplicationOperations(connectionID, ReplicationOperationsInput{
+				Collection:     "Article",
+				IncludeHistory: true,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, []ReplicationOperation{
+				{
+					ID:         "00000000-0000-0000-0000-000000000002",
+					Collection: "Article",
+					Shard:      "shard-b",
+					SourceNode: "node-2",
+					TargetNode: "node-3",
+					Type:       ReplicationMove,
+					Status:     ReplicationState{State: "HYDRATING", Errors: []string{"target node busy"}},
+					History:    []ReplicationState{{State: "REGISTERED", Errors: []string{}, StartedAt: 200}},
+					StartedAt:  200,
+				},
+				{
+					ID:         operationID,
+					Collection: "Article",
+					Shard:      "shard-a",
+					SourceNode: "node-1",
+					TargetNode: "node-3",
+					Type:       ReplicationCopy,
+					Status:     ReplicationState{State: "READY", Errors: []string{}},
+					History:    []ReplicationState{},
+					StartedAt:  100,
+				},
+			}, operations)
+			assert.Equal(t, []string{"list collection=Article&includeHistory=true"}, requests)
+		})
+	})
+
+	t.Run("ReplicateShard", func(t *testing.T) {
+		t.Run("should start a copy by default", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			id, err := weaviate.ReplicateShard(connectionID, ReplicateInput{
+				Collection: "Article",
+				Shard:      "shard-a",
+				SourceNode: "node-1",
+				TargetNode: "node-2",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, operationID, id)
+			assert.Equal(t, []string{
+				`POST /v1/replication/replicate {"collection":"Article","shard":"shard-a",` +
+					`"sourceNode":"node-1","targetNode":"node-2","type":"COPY"}`,
+			}, requests)
+		})
+
+		t.Run("should return error on invalid input", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			_, err := weaviate.ReplicateShard(connectionID, ReplicateInput{
+				Collection: "Article",
+				Shard:      "shard-a",
+				SourceNode: "node-1",
+				TargetNode: "node-1",
+			})
+			assert.EqualError(t, err, "source and target node must be different")
+
+			_, err = weaviate.ReplicateShard(connectionID, ReplicateInput{
+				Collection: "Article",
+				Shard:      "shard-a",
+				SourceNode: "node-1",
+				TargetNode: "node-2",
+				Type:       "MIRROR",
+			})
+			assert.EqualError(t, err, `invalid replication type "MIRROR", expected COPY or MOVE`)
+			assert.Empty(t, requests)
+		})
+	})
+
+	t.Run("CancelReplicationOperation", func(t *testing.T) {
+		t.Run("should cancel operation", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			err := weaviate.CancelReplicationOperation(connectionID, operationID)
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"POST /v1/replication/replicate/" + operationID + "/cancel "}, requests)
+		})
+
+		t.Run("should return error if operation doesn't exist", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			err := weaviate.CancelReplicationOperation(connectionID, "00000000-0000-0000-0000-000000000404")
+
+			assert.ErrorContains(
+				t,
+				err,
+				"failed cancelling replication operation 00000000-0000-0000-0000-000000000404: "+
+					"weaviate return non successful http status code 404 Not Found",
+			)
+		})
+	})
+
+	t.Run("DeleteReplicationOperation", func(t *testing.T) {
+		t.Run("should delete operation", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			err := weaviate.DeleteReplicationOperation(connectionID, operationID)
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"DELETE /v1/replication/replicate/" + operationID + " "}, requests)
+		})
+	})
+
+	t.Run("GetShardingState", func(t *testing.T) {
+		t.Run("should return replicas per shard and every node", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			state, err := weaviate.GetShardingState(connectionID, "Article")
+
+			require.NoError(t, err)
+			assert.Equal(t, &ShardingState{
+				Collection: "Article",
+				Shards: []ShardReplicas{
+					{Shard: "shard-a", Replicas: []string{"node-1"}},
+					{Shard: "shard-b", Replicas: []string{"node-1", "node-2"}},
+				},
+				Nodes: []string{"node-1", "node-2", "node-3"},
+			}, state)
+		})
+
+		t.Run("should return error if connection doesn't exist", func(t *testing.T) {
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+
+			state, err := weaviate.GetShardingState(connectionID, "Article")
+
+			assert.Nil(t, state)
+			assert.EqualError(t, err, "connection doesn't exist 1")
+		})
+	})
+}
@@ -0,0 +1,96 @@
+package weaviate
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
+	"github.com/weaviate/weaviate/entities/models"
+	pb "github.com/weaviate/weaviate/grpc/generated/protocol/v1"
+)
+
+// RerankOptions reorders the search results by the relevance of a text property to the
+// query, scored by the reranker module of the collection.
+type RerankOptions struct {
+	// Property is the text property scored by the reranker.
+	Property string
+	// Query is scored against the property, it defaults to the query of bm25, hybrid
+	// and nearText searches.
+	Query string
+}
+
+// rerankQueries are the search types whose query is text which the reranker can score
+var rerankQueries = []string{"bm25", "hybrid", "nearText"}
+
+// checkRerank validates the options against the collection before searching and
+// returns the query to rerank with
+func checkRerank(opts *RerankOptions, col *models.Class, searchType, query string) (string, error) {
+	if collectionRerankerModule(col) == "" {
+		return "", fmt.Errorf("%s has no reranker module in its moduleConfig", col.Class)
+	}
+
+	if opts.Property == "" {
+		return "", errors.New("a property is required to rerank")
+	}
+	idx := slices.IndexFunc(col.Properties, func(p *models.Property) bool { return p.Name == opts.Property })
+	if idx < 0 {
+		return "", fmt.Errorf("property %q does not exist in %s", opts.Property, col.Class)
+	}
+	dataType := col.Properties[idx].DataType
+	if !slices.Contains(dataType, "text") && !slices.Contains(dataType, "string") {
+		return "", fmt.Errorf("property %q of type %s can't be reranked", opts.Property, strings.Join(dataType, ", "))
+	}
+
+	if opts.Query != "" {
+		return opts.Query, nil
+	}
+	if !slices.Contains(rerankQueries, searchType) {
+		return "", fmt.Errorf("a rerank query is required for %s search", searchType)
+	}
+
+	return query, nil
+}
+
+// collectionRerankerModule is the reranker module configured in the module config of
+// the collection
+func collectionRerankerModule(col *models.Class) string {
+	config, _ := col.ModuleConfig.(map[string]any)
+	for _, name := range slices.Sorted(maps.Keys(config)) {
+		if strings.HasPrefix(name, "reranker-") {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// rerankField requests the rerank score, the go client has no builder for it
+func rerankField(property, query string) graphql.Field {
+	return graphql.Field{
+		Name:   fmt.Sprintf("rerank(property: %s query: %s)", gqlQuote(property), gqlQuote(query)),
+		Fields: []graphql.Field{{Name: "score"}},
+	}
+}
+
+// gqlRerankScore reads the rerank score of the additional fields, weaviate returns it
+// as list with a single result
+func gqlRerankScore(value any) *float64 {
+	results, _ := value.([]any)
+	if len(results) == 0 {
+		return nil
+	}
+	result, _ := results[0].(map[string]any)
+	score, ok := toFloat(result["score"])
+	if !ok {
+		return nil
+	}
+
+	return &score
+}
+
+func grpcRerank(property, query string) *pb.Rerank {
+	return &pb.Rerank{Property: property, Query: &query}
+}
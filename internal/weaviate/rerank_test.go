@@ -0,0 +1,133 @@
+package weaviate
+
+import (
+	"testing"
+
+	"weaviate-desktop/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestRerankSearch(t *testing.T) {
+	connectionID := int64(1)
+	class := &weaviate_models.Class{
+		Class: "Article",
+		Properties: []*weaviate_models.Property{
+			{Name: "title", DataType: []string{"text"}},
+			{Name: "views", DataType: []string{"int"}},
+		},
+		ModuleConfig: map[string]any{
+			"text2vec-openai": map[string]any{},
+			"reranker-cohere": map[string]any{"model": "rerank-v3.5"},
+		},
+	}
+	plain := &weaviate_models.Class{
+		Class:        "Note",
+		Properties:   []*weaviate_models.Property{{Name: "title", DataType: []string{"text"}}},
+		ModuleConfig: map[string]any{"text2vec-openai": map[string]any{}},
+	}
+
+	search := searchTest{
+		connectionID: connectionID,
+		classes:      []*weaviate_models.Class{class, plain},
+	}
+
+	t.Run("should rerank with the search query through grpc", func(t *testing.T) {
+		server := newGRPCServer(t)
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &[]string{}, server)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "bm25", "vector databases", SearchOptions{
+			Rerank: &RerankOptions{Property: "title"},
+		})
+		require.NoError(t, err)
+
+		req := server.lastSearch()
+		assert.Equal(t, "title", req.Rerank.Property)
+		assert.Equal(t, "vector databases", req.Rerank.GetQuery())
+
+		require.Len(t, res.Objects, 1)
+		assert.Equal(t, utils.Pointer(0.9), res.Objects[0].RerankScore)
+		assert.Equal(t, utils.Pointer(float32(0.75)), res.Objects[0].Score)
+	})
+
+	t.Run("should rerank with the rerank query through graphql", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{"data": {"Get": {"Article": [{
+			"title": "Weaviate",
+			"_additional": {
+				"id": "00000000-0000-0000-0000-000000000001",
+				"creationTimeUnix": "1", "lastUpdateTimeUnix": "2",
+				"distance": 0.25,
+				"rerank": [{"score": 0.9}]
+			}
+		}]}}}`, &queries, nil)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "nearVector", "[1, 0]", SearchOptions{
+			Rerank: &RerankOptions{Property: "title", Query: `"open source" databases`},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], `rerank(property: "title" query: "\"open source\" databases"){score}`)
+
+		require.Len(t, res.Objects, 1)
+		assert.Equal(t, utils.Pointer(0.9), res.Objects[0].RerankScore)
+		assert.Equal(t, utils.Pointer(float32(0.25)), res.Objects[0].Distance)
+	})
+
+	t.Run("should not search if rerank options are invalid", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			collection string
+			searchType string
+			opts       RerankOptions
+			expected   string
+		}{
+			{
+				name:       "collection without reranker",
+				collection: "Note",
+				searchType: "bm25",
+				opts:       RerankOptions{Property: "title"},
+				expected:   "Note has no reranker module in its moduleConfig",
+			},
+			{
+				name:       "unknown property",
+				collection: "Article",
+				searchType: "bm25",
+				opts:       RerankOptions{Property: "body"},
+				expected:   `property "body" does not exist in Article`,
+			},
+			{
+				name:       "property that isn't text",
+				collection: "Article",
+				searchType: "bm25",
+				opts:       RerankOptions{Property: "views"},
+				expected:   `property "views" of type int can't be reranked`,
+			},
+			{
+				name:       "missing query of vector search",
+				collection: "Article",
+				searchType: "nearVector",
+				opts:       RerankOptions{Property: "title"},
+				expected:   "a rerank query is required for nearVector search",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				queries := []string{}
+				weaviate := newSearchTestWeaviate(t, search, `{}`, &queries, nil)
+
+				res, err := weaviate.Search(connectionID, tc.collection, "", tc.searchType, "[1, 0]", SearchOptions{
+					Rerank: &tc.opts,
+				})
+
+				assert.Nil(t, res)
+				assert.EqualError(t, err, tc.expected)
+				assert.Empty(t, queries)
+			})
+		}
+	})
+}
@@ -0,0 +1,257 @@
+package weaviate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"weaviate-desktop/internal/models"
+)
+
+// doREST sends a request to the REST API of the connection for the endpoints the
+// go client doesn't support, out is left untouched when it's nil or there's no body
+func (w *Weaviate) doREST(
+	ctx context.Context,
+	connectionID int64,
+	method, path string,
+	query url.Values,
+	body, out any,
+) error {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	var reqBody []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed marshalling request body: %w", err)
+		}
+		reqBody = data
+	}
+
+	req, err := c.newRequest(ctx, method, path, query, reqBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed on %s %s request: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed reading response body: %w", err)
+	}
+
+	if resp.StatusCode > 299 || resp.StatusCode < 200 {
+		var errResponse struct {
+			Error []struct{ Message string }
+		}
+		errMessage := strings.TrimSpace(string(data))
+		if err := json.Unmarshal(data, &errResponse); err == nil && len(errResponse.Error) > 0 {
+			errMessage = errResponse.Error[0].Message
+		}
+
+		return fmt.Errorf(
+			"weaviate return non successful http status code %s for %s %s: %s",
+			resp.Status,
+			method,
+			path,
+			errMessage,
+		)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed un-marshalling response %w", err)
+	}
+
+	return nil
+}
+
+// newRequest creates a request to the REST API of the connection with its headers, the
+// body is sent as JSON when it isn't empty
+func (c *WClient) newRequest(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	body []byte,
+) (*http.Request, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/v1%s", c.uri, path))
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing connection URI %s: %w", c.uri, err)
+	}
+	u.RawQuery = query.Encode()
+
+	var reqBody io.Reader
+	if len(body) > 0 {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating request: %w", err)
+	}
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	return req, nil
+}
+
+type RawRequestInput struct {
+	Method string `json:"method"`
+	// Path is the path under /v1, e.g. /schema/Article
+	Path  string              `json:"path"`
+	Query map[string][]string `json:"query,omitempty"`
+	// Body is sent as is with the JSON content type
+	Body string `json:"body,omitempty"`
+	// Confirm has to be set for the methods changing data
+	Confirm bool `json:"confirm,omitempty"`
+}
+
+type RawResponse struct {
+	Status        int                 `json:"status"`
+	StatusText    string              `json:"statusText"`
+	Headers       map[string][]string `json:"headers"`
+	Body          string              `json:"body"`
+	ExecutionTime string              `json:"executionTime"`
+}
+
+// rawRequestMethods are the methods raw requests can be sent with, mapped to whether
+// they change data
+var rawRequestMethods = map[string]bool{
+	http.MethodGet:     false,
+	http.MethodHead:    false,
+	http.MethodOptions: false,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+}
+
+// SendRawRequest sends a request to any endpoint of the REST API with the
+// authentication and the headers of the connection, so endpoints the app doesn't
+// wrap yet can still be used. The response is returned whatever its status, the
+// requests are recorded in the request history of the connection.
+func (w *Weaviate) SendRawRequest(connectionID int64, input RawRequestInput) (*RawResponse, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	method := strings.ToUpper(strings.TrimSpace(input.Method))
+	mutates, ok := rawRequestMethods[method]
+	if !ok {
+		return nil, fmt.Errorf("unsupported method %q", input.Method)
+	}
+	if mutates && !input.Confirm {
+		return nil, fmt.Errorf("%s requests change data and have to be confirmed", method)
+	}
+
+	path, err := rawRequestPath(input.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req, err := c.newRequest(ctx, method, path, input.Query, []byte(input.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	history := models.RequestHistory{
+		ConnectionID: connectionID,
+		Method:       method,
+		Path:         strings.TrimPrefix(req.URL.RequestURI(), "/v1"),
+		Body:         input.Body,
+		CreatedAt:    now.UnixMilli(),
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		history.Error = err.Error()
+		history.DurationMs = time.Since(now).Milliseconds()
+		w.saveRequest(history)
+
+		return nil, fmt.Errorf("failed on %s %s request: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	history.Status = resp.StatusCode
+	history.DurationMs = time.Since(now).Milliseconds()
+	if err != nil {
+		history.Error = err.Error()
+		w.saveRequest(history)
+
+		return nil, fmt.Errorf("failed reading response body: %w", err)
+	}
+	w.saveRequest(history)
+
+	return &RawResponse{
+		Status:        resp.StatusCode,
+		StatusText:    http.StatusText(resp.StatusCode),
+		Headers:       resp.Header,
+		Body:          string(data),
+		ExecutionTime: time.Since(now).String(),
+	}, nil
+}
+
+// rawRequestPath validates the path of a raw request, a leading /v1 is optional
+func rawRequestPath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", errors.New("path is required")
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if path == "/v1" || strings.HasPrefix(path, "/v1/") {
+		path = strings.TrimPrefix(path, "/v1")
+	}
+
+	if strings.ContainsAny(path, "?#") {
+		return "", fmt.Errorf("invalid path %s: the query is set separately", path)
+	}
+	for segment := range strings.SplitSeq(path, "/") {
+		if segment == ".." || segment == "." {
+			return "", fmt.Errorf("invalid path %s: paths have to stay under /v1", path)
+		}
+	}
+
+	return path, nil
+}
+
+// saveRequest records the request, failing to record it doesn't fail the request
+func (w *Weaviate) saveRequest(r models.RequestHistory) {
+	if _, err := w.storage.SaveRequest(r); err != nil {
+		slog.Warn(
+			"failed recording request",
+			slog.Int64("connectionID", r.ConnectionID),
+			slog.String("method", r.Method),
+			slog.String("path", r.Path),
+			slog.Any("error", err),
+		)
+	}
+}
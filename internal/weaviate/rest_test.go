@@ -0,0 +1,167 @@
+package weaviate
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+	"weaviate-desktop/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawRequest(t *testing.T) {
+	connectionID := int64(1)
+
+	// newWeaviate answers every request with a 422 echoing the request, the requests
+	// are recorded
+	newWeaviate := func(t *testing.T, storage *MockStorage, requests *[]string) *Weaviate {
+		t.Helper()
+
+		mockServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/meta" {
+				w.Write([]byte(`{"version": "1.32.0"}`))
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "Bearer mock-api-key", r.Header.Get("Authorization"))
+			assert.Equal(t, "mock-openai-key", r.Header.Get("X-Openai-Api-Key"))
+			*requests = append(*requests, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+
+			w.Header().Set("X-Request-Id", "mock-request")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"error":[{"message":"mock error"}]}`))
+		}))
+		t.Cleanup(mockServer.Close)
+
+		weaviate := New(storage, Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+		client, err := weaviate.getClientFromConnection(&models.Connection{
+			URI:     mockServer.URL,
+			ApiKey:  utils.Pointer("mock-api-key"),
+			Headers: []models.ConnectionHeader{{Name: "X-Openai-Api-Key", Value: "mock-openai-key"}},
+		})
+		require.NoError(t, err)
+		weaviate.clients.add(connectionID, client)
+
+		return weaviate
+	}
+
+	t.Run("should send confirmed request and record it", func(t *testing.T) {
+		requests := []string{}
+		storage := NewMockStorage(t)
+		storage.EXPECT().SaveRequest(mock.MatchedBy(func(r models.RequestHistory) bool {
+			return r.ConnectionID == connectionID &&
+				r.Method == http.MethodPost &&
+				r.Path == "/schema/Article/tenants?consistency=true" &&
+				r.Body == `[{"name":"tenant"}]` &&
+				r.Status == http.StatusUnprocessableEntity &&
+				r.CreatedAt > 0
+		})).Return(1, nil)
+		weaviate := newWeaviate(t, storage, &requests)
+
+		res, err := weaviate.SendRawRequest(connectionID, RawRequestInput{
+			Method:  "post",
+			Path:    "/v1/schema/Article/tenants",
+			Query:   map[string][]string{"consistency": {"true"}},
+			Body:    `[{"name":"tenant"}]`,
+			Confirm: true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+		assert.Equal(t, "Unprocessable Entity", res.StatusText)
+		assert.Equal(t, []string{"mock-request"}, res.Headers["X-Request-Id"])
+		assert.JSONEq(t, `{"error":[{"message":"mock error"}]}`, res.Body)
+		assert.NotEmpty(t, res.ExecutionTime)
+		assert.Equal(t, []string{
+			`POST /v1/schema/Article/tenants?consistency=true [{"name":"tenant"}]`,
+		}, requests)
+	})
+
+	t.Run("should not fail request if recording it fails", func(t *testing.T) {
+		requests := []string{}
+		storage := NewMockStorage(t)
+		storage.EXPECT().SaveRequest(mock.Anything).Return(0, errors.New("mock error"))
+		weaviate := newWeaviate(t, storage, &requests)
+
+		res, err := weaviate.SendRawRequest(connectionID, RawRequestInput{
+			Method: http.MethodGet,
+			Path:   "nodes",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+		assert.Equal(t, []string{"GET /v1/nodes "}, requests)
+	})
+
+	t.Run("should not send request that isn't valid", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			input    RawRequestInput
+			expected string
+		}{
+			{
+				name:     "unconfirmed mutation",
+				input:    RawRequestInput{Method: http.MethodDelete, Path: "/schema/Article"},
+				expected: "DELETE requests change data and have to be confirmed",
+			},
+			{
+				name:     "unsupported method",
+				input:    RawRequestInput{Method: "TRACE", Path: "/nodes", Confirm: true},
+				expected: `unsupported method "TRACE"`,
+			},
+			{
+				name:     "path outside of v1",
+				input:    RawRequestInput{Method: http.MethodGet, Path: "/v1/../metrics"},
+				expected: "invalid path /../metrics: paths have to stay under /v1",
+			},
+			{
+				name:     "query in path",
+				input:    RawRequestInput{Method: http.MethodGet, Path: "/objects?limit=1"},
+				expected: "invalid path /objects?limit=1: the query is set separately",
+			},
+			{
+				name:     "missing path",
+				input:    RawRequestInput{Method: http.MethodGet},
+				expected: "path is required",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				requests := []string{}
+				weaviate := newWeaviate(t, NewMockStorage(t), &requests)
+
+				res, err := weaviate.SendRawRequest(connectionID, tc.input)
+
+				assert.Nil(t, res)
+				assert.EqualError(t, err, tc.expected)
+				assert.Empty(t, requests)
+			})
+		}
+	})
+
+	t.Run("should return error if connection doesn't exist", func(t *testing.T) {
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+
+		res, err := weaviate.SendRawRequest(connectionID, RawRequestInput{
+			Method: http.MethodGet,
+			Path:   "/nodes",
+		})
+
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "connection doesn't exist 1")
+	})
+}
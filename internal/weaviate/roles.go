@@ -9,7 +9,7 @@ import (
 )
 
 func (w *Weaviate) ListRoles(connectionID int64) ([]Role, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -34,7 +34,7 @@ func (w *Weaviate) ListRoles(connectionID int64) ([]Role, error) {
 }
 
 func (w *Weaviate) CreateRole(connectionID int64, role Role) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -50,7 +50,7 @@ func (w *Weaviate) CreateRole(connectionID int64, role Role) error {
 }
 
 func (w *Weaviate) DeleteRole(connectionID int64, roleName string) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -66,7 +66,7 @@ func (w *Weaviate) DeleteRole(connectionID int64, roleName string) error {
 }
 
 func (w *Weaviate) AddRolePermissions(connectionID int64, roleName string, permissions Role) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -93,7 +93,7 @@ func (w *Weaviate) RemoveRolePermissions(
 	roleName string,
 	permissions Role,
 ) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -0,0 +1,474 @@
+package weaviate
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+const (
+	// ChangeMissingCollection is a collection of the source that doesn't exist in the target
+	ChangeMissingCollection = "missingCollection"
+	// ChangeExtraCollection is a collection that only exists in the target
+	ChangeExtraCollection = "extraCollection"
+	// ChangeMissingProperty is a property of the source that doesn't exist in the target
+	ChangeMissingProperty = "missingProperty"
+	// ChangeExtraProperty is a property that only exists in the target
+	ChangeExtraProperty = "extraProperty"
+	// ChangeProperty is a property whose data type, tokenization or indexes differ
+	ChangeProperty = "changedProperty"
+	// ChangeSetting is a vectorizer, index, replication, multi-tenancy or sharding setting that differs
+	ChangeSetting = "changedSetting"
+)
+
+// the settings compared between collections, description is left out on purpose
+var diffSettings = []string{
+	"vectorizer",
+	"moduleConfig",
+	"vectorIndexType",
+	"vectorIndexConfig",
+	"vectorConfig",
+	"invertedIndexConfig",
+	"replicationConfig",
+	"multiTenancyConfig",
+	"shardingConfig",
+}
+
+// settings derived by weaviate from the cluster size, they are expected to differ
+var ignoredSettings = []string{
+	"shardingConfig.actualCount",
+	"shardingConfig.actualVirtualCount",
+	"shardingConfig.desiredVirtualCount",
+}
+
+// settings that can be updated on an existing collection, everything else requires
+// the collection to be recreated
+var mutableSettings = []string{
+	"invertedIndexConfig.bm25",
+	"invertedIndexConfig.stopwords",
+	"invertedIndexConfig.cleanupIntervalSeconds",
+	"vectorIndexConfig.ef",
+	"vectorIndexConfig.dynamicEfMin",
+	"vectorIndexConfig.dynamicEfMax",
+	"vectorIndexConfig.dynamicEfFactor",
+	"vectorIndexConfig.flatSearchCutoff",
+	"vectorIndexConfig.vectorCacheMaxObjects",
+	"vectorIndexConfig.skip",
+	"vectorIndexConfig.pq",
+	"vectorIndexConfig.bq",
+	"vectorIndexConfig.sq",
+	"vectorIndexConfig.threshold",
+	"replicationConfig.factor",
+	"replicationConfig.asyncEnabled",
+	"replicationConfig.deletionStrategy",
+	"multiTenancyConfig.autoTenantCreation",
+	"multiTenancyConfig.autoTenantActivation",
+}
+
+// SchemaChange is a difference between the source and the target schema
+type SchemaChange struct {
+	Kind       string `json:"kind"`
+	Collection string `json:"collection"`
+	Property   string `json:"property,omitempty"`
+	// Field is the setting or property field that differs, e.g. vectorIndexConfig.ef
+	Field  string `json:"field,omitempty"`
+	Source any    `json:"source,omitempty"`
+	Target any    `json:"target,omitempty"`
+	// Immutable changes can't be applied without recreating the collection
+	Immutable bool `json:"immutable"`
+}
+
+// Safe reports whether the change can be synced without touching existing data
+func (c SchemaChange) Safe() bool {
+	return c.Kind == ChangeMissingCollection || c.Kind == ChangeMissingProperty
+}
+
+type SchemaDiff struct {
+	SourceConnectionID int64          `json:"sourceConnectionID"`
+	TargetConnectionID int64          `json:"targetConnectionID"`
+	Changes            []SchemaChange `json:"changes"`
+}
+
+type SchemaSyncError struct {
+	Change  SchemaChange `json:"change"`
+	Message string       `json:"message"`
+}
+
+type SchemaSyncResult struct {
+	DryRun bool `json:"dryRun"`
+	// Applied are the safe changes that were applied, or would be applied on a dry run
+	Applied []SchemaChange `json:"applied"`
+	// Skipped are the changes that have to be applied manually
+	Skipped []SchemaChange    `json:"skipped"`
+	Failed  []SchemaSyncError `json:"failed"`
+}
+
+// DiffSchemas compares the schema of the source connection with the target one
+func (w *Weaviate) DiffSchemas(sourceConnectionID, targetConnectionID int64) (*SchemaDiff, error) {
+	_, changes, err := w.diffSchemas(sourceConnectionID, targetConnectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SchemaDiff{
+		SourceConnectionID: sourceConnectionID,
+		TargetConnectionID: targetConnectionID,
+		Changes:            changes,
+	}, nil
+}
+
+// SyncSchemas applies the safe changes, missing collections and missing properties,
+// to the target connection. With dryRun nothing is applied and the result previews
+// the changes that would be.
+//
+//nolint:gocognit // collections, their references and properties are applied in order
+func (w *Weaviate) SyncSchemas(
+	sourceConnectionID, targetConnectionID int64,
+	dryRun bool,
+) (*SchemaSyncResult, error) {
+	source, changes, err := w.diffSchemas(sourceConnectionID, targetConnectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SchemaSyncResult{
+		DryRun:  dryRun,
+		Applied: []SchemaChange{},
+		Skipped: []SchemaChange{},
+		Failed:  []SchemaSyncError{},
+	}
+
+	var safe []SchemaChange
+	for _, change := range changes {
+		if change.Safe() {
+			safe = append(safe, change)
+		} else {
+			result.Skipped = append(result.Skipped, change)
+		}
+	}
+
+	if dryRun {
+		result.Applied = append(result.Applied, safe...)
+		return result, nil
+	}
+
+	c, exists := w.clients.get(targetConnectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", targetConnectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// references can only point to existing collections so the collections are created
+	// without them first, and the references are added once every collection exists
+	type pendingReference struct {
+		change   SchemaChange
+		property *models.Property
+	}
+	var references []pendingReference
+	failed := map[string]bool{}
+
+	for _, change := range safe {
+		if change.Kind != ChangeMissingCollection {
+			continue
+		}
+
+		class := syncableClass(source[change.Collection])
+		properties := class.Properties
+		class.Properties = nil
+		for _, p := range properties {
+			if isCrossReference(p.DataType[0]) {
+				references = append(references, pendingReference{change: change, property: p})
+				continue
+			}
+			class.Properties = append(class.Properties, p)
+		}
+
+		if err := c.w.Schema().ClassCreator().WithClass(class).Do(ctx); err != nil {
+			failed[change.Collection] = true
+			result.Failed = append(result.Failed, SchemaSyncError{Change: change, Message: err.Error()})
+			continue
+		}
+		result.Applied = append(result.Applied, change)
+	}
+
+	for _, change := range safe {
+		if change.Kind != ChangeMissingProperty {
+			continue
+		}
+
+		property := findProperty(source[change.Collection].Properties, change.Property)
+		references = append(references, pendingReference{change: change, property: property})
+	}
+
+	for _, ref := range references {
+		if failed[ref.change.Collection] {
+			continue
+		}
+
+		err := c.w.Schema().PropertyCreator().
+			WithClassName(ref.change.Collection).
+			WithProperty(ref.property).
+			Do(ctx)
+		if err != nil {
+			message := fmt.Sprintf("failed adding property %s: %s", ref.property.Name, err)
+			result.Failed = append(result.Failed, SchemaSyncError{Change: ref.change, Message: message})
+			if ref.change.Kind == ChangeMissingCollection {
+				// the collection was created but it's incomplete
+				failed[ref.change.Collection] = true
+				result.Applied = slices.DeleteFunc(result.Applied, func(c SchemaChange) bool {
+					return c.Kind == ChangeMissingCollection && c.Collection == ref.change.Collection
+				})
+			}
+			continue
+		}
+		if ref.change.Kind == ChangeMissingProperty {
+			result.Applied = append(result.Applied, ref.change)
+		}
+	}
+
+	return result, nil
+}
+
+func (w *Weaviate) diffSchemas(
+	sourceConnectionID, targetConnectionID int64,
+) (map[string]*models.Class, []SchemaChange, error) {
+	sourceCollections, err := w.GetCollections(sourceConnectionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed retrieving source schema: %w", err)
+	}
+
+	targetCollections, err := w.GetCollections(targetConnectionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed retrieving target schema: %w", err)
+	}
+
+	source := make(map[string]*models.Class, len(sourceCollections))
+	for _, c := range sourceCollections {
+		source[c.Class] = c
+	}
+	target := make(map[string]*models.Class, len(targetCollections))
+	for _, c := range targetCollections {
+		target[c.Class] = c
+	}
+
+	changes := []SchemaChange{}
+	for name, sourceClass := range source {
+		targetClass, ok := target[name]
+		if !ok {
+			changes = append(changes, SchemaChange{Kind: ChangeMissingCollection, Collection: name})
+			continue
+		}
+
+		changes = append(changes, diffProperties(name, sourceClass.Properties, targetClass.Properties)...)
+
+		settings, err := diffClassSettings(name, sourceClass, targetClass)
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, settings...)
+	}
+	for name := range target {
+		if _, ok := source[name]; !ok {
+			changes = append(changes, SchemaChange{Kind: ChangeExtraCollection, Collection: name})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b SchemaChange) int {
+		return cmp.Or(
+			cmp.Compare(a.Collection, b.Collection),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Property, b.Property),
+			cmp.Compare(a.Field, b.Field),
+		)
+	})
+
+	return source, changes, nil
+}
+
+func diffProperties(collection string, source, target []*models.Property) []SchemaChange {
+	var changes []SchemaChange
+
+	for _, sp := range source {
+		tp := findProperty(target, sp.Name)
+		if tp == nil {
+			changes = append(changes, SchemaChange{
+				Kind:       ChangeMissingProperty,
+				Collection: collection,
+				Property:   sp.Name,
+				Source:     sp.DataType,
+			})
+			continue
+		}
+
+		fields := []struct {
+			name           string
+			source, target any
+		}{
+			{"dataType", sp.DataType, tp.DataType},
+			{"tokenization", sp.Tokenization, tp.Tokenization},
+			{"indexFilterable", sp.IndexFilterable, tp.IndexFilterable},
+			{"indexSearchable", sp.IndexSearchable, tp.IndexSearchable},
+			{"indexRangeFilters", sp.IndexRangeFilters, tp.IndexRangeFilters},
+			{"nestedProperties", sp.NestedProperties, tp.NestedProperties},
+		}
+		for _, f := range fields {
+			if reflect.DeepEqual(f.source, f.target) {
+				continue
+			}
+			changes = append(changes, SchemaChange{
+				Kind:       ChangeProperty,
+				Collection: collection,
+				Property:   sp.Name,
+				Field:      f.name,
+				Source:     f.source,
+				Target:     f.target,
+				Immutable:  true,
+			})
+		}
+	}
+
+	for _, tp := range target {
+		if findProperty(source, tp.Name) == nil {
+			changes = append(changes, SchemaChange{
+				Kind:       ChangeExtraProperty,
+				Collection: collection,
+				Property:   tp.Name,
+				Target:     tp.DataType,
+			})
+		}
+	}
+
+	return changes
+}
+
+// diffClassSettings compares the settings of the collections leaf by leaf so the
+// change points at the exact setting, e.g. vectorIndexConfig.ef
+func diffClassSettings(collection string, source, target *models.Class) ([]SchemaChange, error) {
+	sourceSettings, err := flattenSettings(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading settings of %s: %w", collection, err)
+	}
+	targetSettings, err := flattenSettings(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading settings of %s: %w", collection, err)
+	}
+
+	fields := map[string]struct{}{}
+	for field := range sourceSettings {
+		fields[field] = struct{}{}
+	}
+	for field := range targetSettings {
+		fields[field] = struct{}{}
+	}
+
+	var changes []SchemaChange
+	for field := range fields {
+		if slices.Contains(ignoredSettings, field) {
+			continue
+		}
+		if reflect.DeepEqual(sourceSettings[field], targetSettings[field]) {
+			continue
+		}
+
+		changes = append(changes, SchemaChange{
+			Kind:       ChangeSetting,
+			Collection: collection,
+			Field:      field,
+			Source:     sourceSettings[field],
+			Target:     targetSettings[field],
+			Immutable:  !isMutableSetting(field),
+		})
+	}
+
+	return changes, nil
+}
+
+// flattenSettings returns the compared settings keyed by their dotted path
+func flattenSettings(class *models.Class) (map[string]any, error) {
+	data, err := json.Marshal(class)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]any{}
+	for _, name := range diffSettings {
+		if value, ok := decoded[name]; ok {
+			flattenSetting(settings, name, value)
+		}
+	}
+
+	return settings, nil
+}
+
+func flattenSetting(settings map[string]any, path string, value any) {
+	nested, ok := value.(map[string]any)
+	if !ok {
+		settings[path] = value
+		return
+	}
+
+	for k, v := range nested {
+		flattenSetting(settings, path+"."+k, v)
+	}
+}
+
+func isMutableSetting(field string) bool {
+	// named vectors share the mutability rules of the single vector index
+	if rest, ok := strings.CutPrefix(field, "vectorConfig."); ok {
+		if _, setting, ok := strings.Cut(rest, "."); ok {
+			field = setting
+		}
+	}
+
+	for _, mutable := range mutableSettings {
+		if field == mutable || strings.HasPrefix(field, mutable+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func findProperty(props []*models.Property, name string) *models.Property {
+	for _, p := range props {
+		// property names are case insensitive in weaviate
+		if strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// syncableClass copies the collection leaving out the settings derived from the
+// size of the source cluster
+func syncableClass(class *models.Class) *models.Class {
+	c := *class
+	c.Properties = slices.Clone(class.Properties)
+
+	if sharding, ok := class.ShardingConfig.(map[string]any); ok {
+		synced := map[string]any{}
+		for _, key := range []string{"desiredCount", "virtualPerPhysical"} {
+			if v, ok := sharding[key]; ok {
+				synced[key] = v
+			}
+		}
+		c.ShardingConfig = synced
+	}
+
+	return &c
+}
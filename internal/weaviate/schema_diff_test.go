@@ -0,0 +1,234 @@
+package weaviate
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestSchemaDiff(t *testing.T) {
+	sourceID := int64(1)
+	targetID := int64(2)
+	yes := true
+	no := false
+
+	source := []*weaviate_models.Class{
+		{
+			Class:      "Article",
+			Vectorizer: "text2vec-openai",
+			Properties: []*weaviate_models.Property{
+				{Name: "title", DataType: []string{"text"}, Tokenization: "word", IndexSearchable: &yes},
+				{Name: "views", DataType: []string{"int"}},
+				{Name: "writtenBy", DataType: []string{"Author"}},
+			},
+			VectorIndexConfig: map[string]any{"ef": 128, "efConstruction": 128},
+			ShardingConfig:    map[string]any{"desiredCount": 3, "actualCount": 3},
+		},
+		{
+			Class: "Author",
+			Properties: []*weaviate_models.Property{
+				{Name: "name", DataType: []string{"text"}},
+				{Name: "articles", DataType: []string{"Article"}},
+			},
+			ShardingConfig: map[string]any{"desiredCount": 3, "actualCount": 3},
+		},
+	}
+	target := []*weaviate_models.Class{
+		{
+			Class:      "Article",
+			Vectorizer: "text2vec-cohere",
+			Properties: []*weaviate_models.Property{
+				{Name: "title", DataType: []string{"text"}, Tokenization: "field", IndexSearchable: &no},
+				{Name: "legacy", DataType: []string{"text"}},
+			},
+			VectorIndexConfig: map[string]any{"ef": 64, "efConstruction": 128},
+			ShardingConfig:    map[string]any{"desiredCount": 3, "actualCount": 1},
+		},
+		{Class: "Legacy"},
+	}
+
+	// newSchemaServer serves the schema and records the changes applied to it
+	newSchemaServer := func(t *testing.T, classes []*weaviate_models.Class, requests *[]string) string {
+		t.Helper()
+
+		var mu sync.Mutex
+		mockServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case r.URL.Path == "/v1/meta":
+				w.Write([]byte(`{"version": "1.30.0"}`))
+			case r.URL.Path == "/v1/schema" && r.Method == http.MethodGet:
+				json.NewEncoder(w).Encode(weaviate_models.Schema{Classes: classes})
+			case r.URL.Path == "/v1/schema" && r.Method == http.MethodPost:
+				var class weaviate_models.Class
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&class))
+
+				names := []string{}
+				for _, p := range class.Properties {
+					names = append(names, p.Name)
+				}
+				*requests = append(*requests, "create "+class.Class+" "+jsonString(t, names)+
+					" sharding "+jsonString(t, class.ShardingConfig))
+				json.NewEncoder(w).Encode(class)
+			case r.Method == http.MethodPost:
+				var property weaviate_models.Property
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&property))
+
+				*requests = append(*requests, "add "+r.URL.Path+" "+property.Name)
+				json.NewEncoder(w).Encode(property)
+			default:
+				t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+				t.Fail()
+			}
+		}))
+		t.Cleanup(mockServer.Close)
+
+		return mockServer.URL
+	}
+
+	newWeaviate := func(t *testing.T, targetRequests *[]string) *Weaviate {
+		t.Helper()
+
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+
+		for id, uri := range map[int64]string{
+			sourceID: newSchemaServer(t, source, &[]string{}),
+			targetID: newSchemaServer(t, target, targetRequests),
+		} {
+			client, err := weaviate.getClientFromConnection(&models.Connection{URI: uri})
+			require.NoError(t, err)
+			weaviate.clients.add(id, client)
+		}
+
+		return weaviate
+	}
+
+	t.Run("DiffSchemas", func(t *testing.T) {
+		t.Run("should report missing, extra and changed collections", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			diff, err := weaviate.DiffSchemas(sourceID, targetID)
+
+			require.NoError(t, err)
+			assert.Equal(t, []SchemaChange{
+				{
+					Kind:       ChangeProperty,
+					Collection: "Article",
+					Property:   "title",
+					Field:      "indexSearchable",
+					Source:     &yes,
+					Target:     &no,
+					Immutable:  true,
+				},
+				{
+					Kind:       ChangeProperty,
+					Collection: "Article",
+					Property:   "title",
+					Field:      "tokenization",
+					Source:     "word",
+					Target:     "field",
+					Immutable:  true,
+				},
+				{
+					Kind:       ChangeSetting,
+					Collection: "Article",
+					Field:      "vectorIndexConfig.ef",
+					Source:     float64(128),
+					Target:     float64(64),
+				},
+				{
+					Kind:       ChangeSetting,
+					Collection: "Article",
+					Field:      "vectorizer",
+					Source:     "text2vec-openai",
+					Target:     "text2vec-cohere",
+					Immutable:  true,
+				},
+				{Kind: ChangeExtraProperty, Collection: "Article", Property: "legacy", Target: []string{"text"}},
+				{Kind: ChangeMissingProperty, Collection: "Article", Property: "views", Source: []string{"int"}},
+				{
+					Kind:       ChangeMissingProperty,
+					Collection: "Article",
+					Property:   "writtenBy",
+					Source:     []string{"Author"},
+				},
+				{Kind: ChangeMissingCollection, Collection: "Author"},
+				{Kind: ChangeExtraCollection, Collection: "Legacy"},
+			}, diff.Changes)
+		})
+
+		t.Run("should return error if connection doesn't exist", func(t *testing.T) {
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+
+			diff, err := weaviate.DiffSchemas(sourceID, targetID)
+
+			assert.Nil(t, diff)
+			assert.EqualError(t, err, "failed retrieving source schema: connection doesn't exist 1")
+		})
+	})
+
+	t.Run("SyncSchemas", func(t *testing.T) {
+		t.Run("should only preview safe changes on dry run", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			result, err := weaviate.SyncSchemas(sourceID, targetID, true)
+
+			require.NoError(t, err)
+			assert.True(t, result.DryRun)
+			assert.Equal(t, []SchemaChange{
+				{Kind: ChangeMissingProperty, Collection: "Article", Property: "views", Source: []string{"int"}},
+				{
+					Kind:       ChangeMissingProperty,
+					Collection: "Article",
+					Property:   "writtenBy",
+					Source:     []string{"Author"},
+				},
+				{Kind: ChangeMissingCollection, Collection: "Author"},
+			}, result.Applied)
+			assert.Len(t, result.Skipped, 6)
+			assert.Empty(t, requests)
+		})
+
+		t.Run("should create collections before adding references", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			result, err := weaviate.SyncSchemas(sourceID, targetID, false)
+
+			require.NoError(t, err)
+			assert.Empty(t, result.Failed)
+			assert.Len(t, result.Applied, 3)
+			assert.Equal(t, []string{
+				`create Author ["name"] sharding {"desiredCount":3}`,
+				"add /v1/schema/Author/properties articles",
+				"add /v1/schema/Article/properties views",
+				"add /v1/schema/Article/properties writtenBy",
+			}, requests)
+		})
+	})
+}
+
+func jsonString(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return string(data)
+}
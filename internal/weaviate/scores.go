@@ -0,0 +1,139 @@
+package weaviate
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
+	"github.com/weaviate/weaviate/entities/models"
+	pb "github.com/weaviate/weaviate/grpc/generated/protocol/v1"
+)
+
+// HybridScore is the contribution of the keyword or the vector search to the score of
+// a hybrid search result
+type HybridScore struct {
+	// Score is the contribution to the fused score.
+	Score float32 `json:"score"`
+	// OriginalScore is the score before the normalization of relativeScoreFusion, the
+	// BM25 score or the vector similarity. rankedFusion only scores by rank.
+	OriginalScore *float32 `json:"originalScore,omitempty"`
+}
+
+// searchScores are the scores requested with the results of a search type
+type searchScores struct {
+	distance  bool
+	certainty bool
+	score     bool
+}
+
+// newSearchScores requests the score of keyword and hybrid searches and the distance of
+// vector searches, with the certainty when the searched vectors support it
+func newSearchScores(col *models.Class, searchType string, targets []string) searchScores {
+	switch searchType {
+	case "bm25", "hybrid":
+		return searchScores{score: true}
+	default:
+		return searchScores{distance: true, certainty: certaintySupported(col, targets)}
+	}
+}
+
+// certaintySupported is true when the searched vectors use the cosine distance, weaviate
+// can't compute the certainty of other distances and fails the search
+func certaintySupported(col *models.Class, targets []string) bool {
+	if len(col.VectorConfig) == 0 {
+		config, _ := col.VectorIndexConfig.(map[string]any)
+		metric, _ := config["distance"].(string)
+		return metric == "" || metric == "cosine"
+	}
+
+	if len(targets) == 0 {
+		targets = slices.Sorted(maps.Keys(col.VectorConfig))
+	}
+	for _, name := range targets {
+		if vectorMetric(col, name) != "cosine" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s searchScores) gqlFields() []graphql.Field {
+	fields := []graphql.Field{}
+	if s.distance {
+		fields = append(fields, graphql.Field{Name: "distance"})
+	}
+	if s.certainty {
+		fields = append(fields, graphql.Field{Name: "certainty"})
+	}
+	if s.score {
+		fields = append(fields, graphql.Field{Name: "score"}, graphql.Field{Name: "explainScore"})
+	}
+
+	return fields
+}
+
+func (s searchScores) setGRPC(metadata *pb.MetadataRequest) {
+	metadata.Distance = s.distance
+	metadata.Certainty = s.certainty
+	metadata.Score = s.score
+	metadata.ExplainScore = s.score
+}
+
+// gqlScore reads a score of the additional fields, GraphQL returns the score of keyword
+// and hybrid searches as string and the distance and certainty as number
+func gqlScore(value any) *float32 {
+	if v, ok := value.(string); ok {
+		return parseScore(v)
+	}
+
+	f, ok := toFloat(value)
+	if !ok {
+		return nil
+	}
+	score := float32(f)
+	return &score
+}
+
+// hybridExplainScore matches the contributions of the result sets in the explain score
+// of hybrid searches, "original score ..., normalized score: ..." of relativeScoreFusion
+// and "contributed ... to the score" of rankedFusion
+var hybridExplainScore = regexp.MustCompile(
+	`Hybrid \(Result Set (keyword|vector)\) Document \S+?` +
+		`(?:: original score ([^,]+), normalized score: (\S+)| contributed (\S+) to the score)`,
+)
+
+// setHybridScores sets the contributions of the keyword and the vector search to the
+// score of hybrid search results from their explain score
+func setHybridScores(objects []WeaviateObject) {
+	for i := range objects {
+		for _, match := range hybridExplainScore.FindAllStringSubmatch(objects[i].ExplainScore, -1) {
+			score := &HybridScore{}
+			contribution := match[4]
+			if contribution == "" {
+				contribution = match[3]
+				score.OriginalScore = parseScore(match[2])
+			}
+			if s := parseScore(contribution); s != nil {
+				score.Score = *s
+			}
+
+			if match[1] == "keyword" {
+				objects[i].KeywordScore = score
+			} else {
+				objects[i].VectorScore = score
+			}
+		}
+	}
+}
+
+func parseScore(value string) *float32 {
+	f, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return nil
+	}
+	score := float32(f)
+	return &score
+}
@@ -0,0 +1,128 @@
+package weaviate
+
+import (
+	"testing"
+
+	"weaviate-desktop/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestSearchScores(t *testing.T) {
+	connectionID := int64(1)
+	class := &weaviate_models.Class{
+		Class:      "Article",
+		Properties: []*weaviate_models.Property{{Name: "title", DataType: []string{"text"}}},
+		VectorConfig: map[string]weaviate_models.VectorConfig{
+			"title": {VectorIndexConfig: map[string]any{}},
+			"body":  {VectorIndexConfig: map[string]any{"distance": "dot"}},
+		},
+	}
+
+	search := searchTest{
+		connectionID: connectionID,
+		classes:      []*weaviate_models.Class{class},
+	}
+
+	t.Run("should return the hybrid score with its contributions through grpc", func(t *testing.T) {
+		server := newGRPCServer(t)
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &[]string{}, server)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "hybrid", "weaviate", SearchOptions{
+			FusionType:    "relativeScoreFusion",
+			TargetVectors: []string{"title"},
+		})
+		require.NoError(t, err)
+
+		req := server.lastSearch()
+		assert.True(t, req.Metadata.Score)
+		assert.True(t, req.Metadata.ExplainScore)
+		assert.False(t, req.Metadata.Distance)
+		assert.False(t, req.Metadata.Certainty)
+
+		require.Len(t, res.Objects, 1)
+		assert.Equal(t, utils.Pointer(float32(0.75)), res.Objects[0].Score)
+		assert.Equal(t, grpcExplainScore, res.Objects[0].ExplainScore)
+		assert.Equal(t, &HybridScore{Score: 0.25, OriginalScore: utils.Pointer(float32(2.5))}, res.Objects[0].KeywordScore)
+		assert.Equal(t, &HybridScore{Score: 0.5, OriginalScore: utils.Pointer(float32(0.9))}, res.Objects[0].VectorScore)
+		assert.Nil(t, res.Objects[0].Distance)
+	})
+
+	t.Run("should return distance and certainty of cosine vectors through grpc", func(t *testing.T) {
+		server := newGRPCServer(t)
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &[]string{}, server)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "nearText", "weaviate", SearchOptions{
+			TargetVectors: []string{"title"},
+			IncludeVector: true,
+		})
+		require.NoError(t, err)
+
+		req := server.lastSearch()
+		assert.True(t, req.Metadata.Distance)
+		assert.True(t, req.Metadata.Certainty)
+		assert.False(t, req.Metadata.Score)
+		assert.Equal(t, []string{"body", "title"}, req.Metadata.Vectors)
+
+		require.Len(t, res.Objects, 1)
+		assert.Equal(t, utils.Pointer(float32(0.25)), res.Objects[0].Distance)
+		assert.Equal(t, utils.Pointer(float32(0.875)), res.Objects[0].Certainty)
+		assert.Nil(t, res.Objects[0].Score)
+		assert.Len(t, res.Objects[0].Vectors, 2)
+	})
+
+	t.Run("should return the ranked hybrid score through graphql", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{"data": {"Get": {"Article": [{
+			"title": "Weaviate",
+			"_additional": {
+				"id": "00000000-0000-0000-0000-000000000001",
+				"creationTimeUnix": "1", "lastUpdateTimeUnix": "2",
+				"score": "0.032522473",
+				"explainScore": "\nHybrid (Result Set keyword) Document 1 contributed 0.016393442 to the score\nHybrid (Result Set vector) Document 1 contributed 0.016129032 to the score"
+			}
+		}]}}}`, &queries, nil)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "hybrid", "weaviate", SearchOptions{
+			FusionType: "rankedFusion",
+		})
+		require.NoError(t, err)
+
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], "_additional{creationTimeUnix id lastUpdateTimeUnix score explainScore}")
+
+		require.Len(t, res.Objects, 1)
+		assert.Equal(t, utils.Pointer(float32(0.032522473)), res.Objects[0].Score)
+		assert.Equal(t, &HybridScore{Score: 0.016393442}, res.Objects[0].KeywordScore)
+		assert.Equal(t, &HybridScore{Score: 0.016129032}, res.Objects[0].VectorScore)
+	})
+
+	t.Run("should not request the certainty of other distances through graphql", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{"data": {"Get": {"Article": [{
+			"title": "Weaviate",
+			"_additional": {
+				"id": "00000000-0000-0000-0000-000000000001",
+				"creationTimeUnix": "1", "lastUpdateTimeUnix": "2",
+				"distance": -0.5,
+				"vectors": {"body": [0.5, 1], "title": [1, 0]}
+			}
+		}]}}}`, &queries, nil)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "nearVector", "[1, 0]", SearchOptions{
+			TargetVectors: []string{"body"},
+			IncludeVector: true,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], "_additional{creationTimeUnix id lastUpdateTimeUnix vectors{body title} distance}")
+
+		require.Len(t, res.Objects, 1)
+		assert.Equal(t, utils.Pointer(float32(-0.5)), res.Objects[0].Distance)
+		assert.Nil(t, res.Objects[0].Certainty)
+		assert.Equal(t, map[string]float32{"body": -0.5}, res.Objects[0].TargetDistances)
+	})
+}
@@ -2,31 +2,82 @@ package weaviate
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
 	"weaviate-desktop/internal/utils"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
 	"github.com/weaviate/weaviate/entities/models"
+	pb "github.com/weaviate/weaviate/grpc/generated/protocol/v1"
+)
+
+const (
+	// searchTimeout bounds a search together with the schema requests it's built with
+	searchTimeout = 10 * time.Second
+	// generativeSearchTimeout bounds generative searches, the text is generated by the
+	// model of the generative module, once per result with a single prompt
+	generativeSearchTimeout = 2 * time.Minute
 )
 
 // SearchOptions holds optional parameters for all search types.
 // Zero values mean "use default / not set".
 type SearchOptions struct {
-	// Limit is the maximum number of results to return (default 100).
+	// Limit is the maximum number of results to return (default 100, or
+	// maxSinglePromptLimit for generative searches with a single prompt).
 	Limit int
 	// Alpha controls the BM25/vector balance in Hybrid search (0.0–1.0, default 0.75).
 	Alpha float32
 	// FusionType is the fusion algorithm for Hybrid search: "rankedFusion" | "relativeScoreFusion".
 	FusionType string
-	// Distance threshold for nearText/nearVector (0 = not set).
+	// Distance threshold of the near searches (0 = not set).
 	Distance float32
-	// Certainty threshold for nearText/nearVector (0 = not set).
+	// Certainty threshold of the near searches (0 = not set).
 	Certainty float32
+	// Filter narrows the results with a where filter (nil = not set).
+	Filter *Filter
+	// Generative generates text from the results (nil = not set).
+	Generative *GenerativeOptions
+	// TargetVectors are the named vectors searched by hybrid and the near searches,
+	// nearVector defaults to the targets of a query with a vector per target.
+	TargetVectors []string
+	// TargetCombination joins the distances of the targets: "sum" | "average" |
+	// "minimum" | "relativeScore" | "manualWeights" (default minimum).
+	TargetCombination string
+	// TargetWeights weigh the targets of relativeScore and manualWeights.
+	TargetWeights map[string]float32
+	// IncludeSource keeps the object of a nearObject search in its results.
+	IncludeSource bool
+	// IncludeVector returns the vectors of the results.
+	IncludeVector bool
+	// Rerank reorders the results with the reranker module of the collection (nil = not set).
+	Rerank *RerankOptions
+}
+
+// searchLimit is the limit of the search. Generative searches with a single prompt
+// generate the text of each result with a call to the model, their limit is capped.
+func searchLimit(opts SearchOptions) (int, error) {
+	singlePrompt := opts.Generative != nil && opts.Generative.SinglePrompt != ""
+
+	switch {
+	case singlePrompt && opts.Limit > maxSinglePromptLimit:
+		return 0, fmt.Errorf(
+			"a single prompt generates text for each result, the limit of %d exceeds the maximum of %d",
+			opts.Limit,
+			maxSinglePromptLimit,
+		)
+	case singlePrompt && opts.Limit <= 0:
+		return maxSinglePromptLimit, nil
+	case opts.Limit <= 0:
+		return 100, nil
+	default:
+		return opts.Limit, nil
+	}
 }
 
 func (w *Weaviate) Search(
@@ -34,12 +85,21 @@ func (w *Weaviate) Search(
 	collection, tenant, searchType, query string,
 	opts SearchOptions,
 ) (*PaginatedObjectResponse, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	limit, err := searchLimit(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := searchTimeout
+	if opts.Generative != nil {
+		timeout = generativeSearchTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	now := time.Now()
@@ -49,15 +109,108 @@ func (w *Weaviate) Search(
 		return nil, fmt.Errorf("failed retrieving schema for %s: %w", collection, err)
 	}
 
-	limit := opts.Limit
-	if limit <= 0 {
-		limit = 100
+	filter := opts.Filter
+	if searchType == "nearObject" {
+		if !strfmt.IsUUID(query) {
+			return nil, fmt.Errorf("invalid object id %q: expected a UUID", query)
+		}
+		if !opts.IncludeSource {
+			filter = excludeObject(filter, query)
+		}
+	}
+
+	where, err := buildWhere(filter, col, collectionSchemas(ctx, c))
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := searchTargets(col, searchType, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// media searches send the content of the file at the path of the query
+	media := ""
+	if medium, ok := searchMedia[searchType]; ok {
+		media, err = w.mediaQuery(connectionID, col, medium, query, targets.names)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	provider := ""
+	if opts.Generative != nil {
+		provider, err = checkGenerative(opts.Generative, col, c.currentHealth().Version)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rerankQuery := ""
+	if opts.Rerank != nil {
+		rerankQuery, err = checkRerank(opts.Rerank, col, searchType, query)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scores := newSearchScores(col, searchType, targets.names)
+
+	if c.grpc != nil {
+		req := grpcSearchRequest(col, tenant, where, opts.IncludeVector)
+		req.Limit = uint32(limit) //nolint:gosec // limit is positive
+		if !opts.IncludeVector && targets.hasQueryVectors() {
+			req.Metadata.Vectors = targets.names
+		}
+		scores.setGRPC(req.Metadata)
+		setGRPCSearch(req, searchType, query, media, opts, targets)
+		if opts.Generative != nil {
+			req.Generative = grpcGenerative(opts.Generative, provider)
+		}
+		if opts.Rerank != nil {
+			req.Rerank = grpcRerank(opts.Rerank.Property, rerankQuery)
+		}
+
+		objects, grouped, err := c.grpc.generativeSearch(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed executing %s search for %s: %w", searchType, query, err)
+		}
+		targets.setDistances(col, objects)
+		if searchType == "hybrid" {
+			setHybridScores(objects)
+		}
+
+		return &PaginatedObjectResponse{
+			Objects:          objects,
+			TotalResults:     len(objects),
+			ExecutionTime:    time.Since(now).String(),
+			GeneratedGrouped: grouped,
+		}, nil
+	}
+
+	fields := getGQLFields(col.Properties)
+	if opts.IncludeVector {
+		fields = withVectorFields(fields, col)
+	}
+	additional := &fields[len(fields)-1]
+	if len(targets.names) > 0 && !opts.IncludeVector && targets.hasQueryVectors() {
+		additional.Fields = append(additional.Fields, targets.gqlVectorsField())
+	}
+	additional.Fields = append(additional.Fields, scores.gqlFields()...)
+	if opts.Rerank != nil {
+		additional.Fields = append(additional.Fields, rerankField(opts.Rerank.Property, rerankQuery))
+	}
+	if opts.Generative != nil {
+		additional.Fields = append(additional.Fields, generativeField(opts.Generative, provider))
 	}
 
 	gqlQuery := c.w.GraphQL().Get().
 		WithClassName(collection).
 		WithLimit(limit).
-		WithFields(getGQLFields(col.Properties)...)
+		WithFields(fields...)
+	if where != nil {
+		gqlQuery = gqlQuery.WithWhere(where)
+	}
 
 	switch searchType {
 	case "hybrid":
@@ -69,29 +222,29 @@ func (w *Weaviate) Search(
 		if opts.FusionType != "" {
 			h = h.WithFusionType(graphql.FusionType(opts.FusionType))
 		}
+		if gqlTargets := targets.gqlTargets(); gqlTargets != nil {
+			h = h.WithTargets(gqlTargets)
+		} else {
+			h = h.WithTargetVectors(targets.names...)
+		}
 		gqlQuery = gqlQuery.WithHybrid(h)
 	case "nearText":
 		nt := (&graphql.NearTextArgumentBuilder{}).WithConcepts([]string{query})
-		if opts.Distance > 0 {
-			nt = nt.WithDistance(opts.Distance)
-		}
-		if opts.Certainty > 0 {
-			nt = nt.WithCertainty(opts.Certainty)
-		}
-		gqlQuery = gqlQuery.WithNearText(nt)
+		gqlQuery = gqlQuery.WithNearText(withNearOptions(nt, opts, targets))
 	case "nearVector":
-		vector, err := parseVectorQuery(query)
-		if err != nil {
-			return nil, err
-		}
-		nv := (&graphql.NearVectorArgumentBuilder{}).WithVector(vector)
-		if opts.Distance > 0 {
-			nv = nv.WithDistance(opts.Distance)
-		}
-		if opts.Certainty > 0 {
-			nv = nv.WithCertainty(opts.Certainty)
-		}
-		gqlQuery = gqlQuery.WithNearVector(nv)
+		gqlQuery = gqlQuery.WithNearVector(withNearOptions(targets.gqlNearVector(), opts, targets))
+	case "nearObject":
+		no := (&graphql.NearObjectArgumentBuilder{}).WithID(query)
+		gqlQuery = gqlQuery.WithNearObject(withNearOptions(no, opts, targets))
+	case "nearImage":
+		ni := (&graphql.NearImageArgumentBuilder{}).WithImage(media)
+		gqlQuery = gqlQuery.WithNearImage(withNearOptions(ni, opts, targets))
+	case "nearAudio":
+		na := (&graphql.NearAudioArgumentBuilder{}).WithAudio(media)
+		gqlQuery = gqlQuery.WithNearAudio(withNearOptions(na, opts, targets))
+	case "nearVideo":
+		nv := (&graphql.NearVideoArgumentBuilder{}).WithVideo(media)
+		gqlQuery = gqlQuery.WithNearVideo(withNearOptions(nv, opts, targets))
 	default: // "bm25"
 		gqlQuery = gqlQuery.WithBM25((&graphql.BM25ArgumentBuilder{}).WithQuery(query))
 	}
@@ -100,7 +253,12 @@ func (w *Weaviate) Search(
 		gqlQuery = gqlQuery.WithTenant(tenant)
 	}
 
-	result, err := gqlQuery.Do(ctx)
+	var result *models.GraphQLResponse
+	if opts.Generative != nil {
+		result, err = c.gqlGenerativeSearch(ctx, gqlQuery.Build())
+	} else {
+		result, err = gqlQuery.Do(ctx)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed executing %s search for %s: %w", searchType, query, err)
 	}
@@ -132,34 +290,200 @@ func (w *Weaviate) Search(
 		}, nil
 	}
 
-	response := &PaginatedObjectResponse{
-		Objects:       make([]WeaviateObject, 0, len(objects)),
+	grouped := gqlGroupedResult(objects)
+	results := toWeaviateObjects(collection, objects)
+	targets.setDistances(col, results)
+	if searchType == "hybrid" {
+		setHybridScores(results)
+	}
+
+	return &PaginatedObjectResponse{
+		Objects:          results,
+		TotalResults:     len(objects),
+		ExecutionTime:    time.Since(now).String(),
+		GeneratedGrouped: grouped,
+	}, nil
+}
+
+// nearArgument is a GraphQL argument builder of the near search types
+type nearArgument[T any] interface {
+	WithDistance(distance float32) T
+	WithCertainty(certainty float32) T
+	WithTargets(targets *graphql.MultiTargetArgumentBuilder) T
+	WithTargetVectors(targetVectors ...string) T
+}
+
+// withNearOptions sets the distance and certainty thresholds and the target vectors of
+// a near search
+func withNearOptions[T nearArgument[T]](arg T, opts SearchOptions, targets *vectorTargets) T {
+	if opts.Distance > 0 {
+		arg = arg.WithDistance(opts.Distance)
+	}
+	if opts.Certainty > 0 {
+		arg = arg.WithCertainty(opts.Certainty)
+	}
+	if gqlTargets := targets.gqlTargets(); gqlTargets != nil {
+		return arg.WithTargets(gqlTargets)
+	}
+
+	return arg.WithTargetVectors(targets.names...)
+}
+
+type FilteredObjectsInput struct {
+	Collection    string  `json:"collection"`
+	Tenant        string  `json:"tenant,omitempty"`
+	Filter        *Filter `json:"filter,omitempty"`
+	Offset        int     `json:"offset"`
+	Limit         int     `json:"limit"`
+	IncludeVector bool    `json:"includeVector,omitempty"`
+	// SortByID sorts the objects by id, so pages can be walked with an "id GreaterThan"
+	// filter past the offset limit of weaviate (QUERY_MAXIMUM_RESULTS)
+	SortByID bool `json:"sortById,omitempty"`
+}
+
+// GetObjectsFiltered browses the objects matching a filter. Weaviate doesn't allow
+// combining the "after" cursor with a filter so pages are walked with offset and limit,
+// or with an id filter on objects sorted by id.
+func (w *Weaviate) GetObjectsFiltered(
+	connectionID int64,
+	input FilteredObjectsInput,
+) (*PaginatedObjectResponse, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+
+	col, err := c.w.Schema().ClassGetter().WithClassName(input.Collection).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving schema for %s: %w", input.Collection, err)
+	}
+
+	where, err := buildWhere(input.Filter, col, collectionSchemas(ctx, c))
+	if err != nil {
+		return nil, err
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	if c.grpc != nil {
+		req := grpcSearchRequest(col, input.Tenant, where, input.IncludeVector)
+		req.Limit = uint32(limit)                 //nolint:gosec // limit is positive
+		req.Offset = uint32(max(input.Offset, 0)) //nolint:gosec // offset is positive
+		if input.SortByID {
+			req.SortBy = []*pb.SortBy{{Path: []string{"_id"}, Ascending: true}}
+		}
+
+		objects, err := c.grpc.search(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed browsing filtered objects of %s: %w", input.Collection, err)
+		}
+
+		return &PaginatedObjectResponse{
+			Objects:       objects,
+			TotalResults:  len(objects),
+			ExecutionTime: time.Since(now).String(),
+		}, nil
+	}
+
+	fields := getGQLFields(col.Properties)
+	if input.IncludeVector {
+		fields = withVectorFields(fields, col)
+	}
+
+	gqlQuery := c.w.GraphQL().Get().
+		WithClassName(input.Collection).
+		WithLimit(limit).
+		WithOffset(input.Offset).
+		WithFields(fields...)
+	if where != nil {
+		gqlQuery = gqlQuery.WithWhere(where)
+	}
+	if input.SortByID {
+		gqlQuery = gqlQuery.WithSort(graphql.Sort{Path: []string{"_id"}, Order: graphql.Asc})
+	}
+	if input.Tenant != "" {
+		gqlQuery = gqlQuery.WithTenant(input.Tenant)
+	}
+
+	result, err := gqlQuery.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed browsing filtered objects of %s: %w", input.Collection, err)
+	}
+	if len(result.Errors) > 0 {
+		return nil, handleGQLError(result, "filtered", input.Collection)
+	}
+
+	objects, _ := result.Data["Get"].(map[string]any)[input.Collection].([]any)
+
+	return &PaginatedObjectResponse{
+		Objects:       toWeaviateObjects(input.Collection, objects),
 		TotalResults:  len(objects),
 		ExecutionTime: time.Since(now).String(),
+	}, nil
+}
+
+// excludeObject extends the filter to exclude the object, e.g. the source object of a
+// nearObject search which is always the nearest result
+func excludeObject(f *Filter, id string) *Filter {
+	exclude := &Filter{Operator: "NotEqual", Path: []string{"id"}, Value: id}
+	if f == nil {
+		return exclude
 	}
 
+	return &Filter{Operator: "And", Operands: []*Filter{f, exclude}}
+}
+
+func toWeaviateObjects(collection string, objects []any) []WeaviateObject {
+	result := make([]WeaviateObject, 0, len(objects))
+
 	for _, obj := range objects {
-		if objMap, ok := obj.(map[string]any); ok {
-			// Convert the object to models.Object
-			object := WeaviateObject{
-				Class: collection,
-				LastUpdateTimeUnix: utils.MustParseInt[int64](
-					objMap["_additional"].(map[string]any)["lastUpdateTimeUnix"].(string),
-				),
-				CreationTimeUnix: utils.MustParseInt[int64](
-					objMap["_additional"].(map[string]any)["creationTimeUnix"].(string),
-				),
-				ID:         objMap["_additional"].(map[string]any)["id"].(string),
-				Properties: objMap,
+		objMap, ok := obj.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		additional := objMap["_additional"].(map[string]any)
+		object := WeaviateObject{
+			Class:              collection,
+			LastUpdateTimeUnix: utils.MustParseInt[int64](additional["lastUpdateTimeUnix"].(string)),
+			CreationTimeUnix:   utils.MustParseInt[int64](additional["creationTimeUnix"].(string)),
+			ID:                 additional["id"].(string),
+			Properties:         objMap,
+		}
+		if vector, ok := additional["vector"].([]any); ok {
+			object.Vector = make([]float32, 0, len(vector))
+			for _, v := range vector {
+				f, _ := toFloat(v)
+				object.Vector = append(object.Vector, float32(f))
 			}
-			// Remove the _additional field from properties
-			delete(object.Properties.(map[string]any), "_additional")
-			// Add the object to the response
-			response.Objects = append(response.Objects, object)
 		}
+		if vectors, ok := additional["vectors"].(map[string]any); ok {
+			object.Vectors = vectors
+		}
+		object.Distance = gqlScore(additional["distance"])
+		object.Certainty = gqlScore(additional["certainty"])
+		object.Score = gqlScore(additional["score"])
+		object.ExplainScore, _ = additional["explainScore"].(string)
+		object.RerankScore = gqlRerankScore(additional["rerank"])
+		if generate, ok := additional["generate"].(map[string]any); ok {
+			object.Generated, _ = generate["singleResult"].(string)
+			object.GenerativeError, _ = generate["error"].(string)
+		}
+
+		// Remove the _additional field from properties
+		delete(objMap, "_additional")
+		result = append(result, object)
 	}
 
-	return response, nil
+	return result
 }
 
 func handleGQLError(result *models.GraphQLResponse, searchType, query string) error {
@@ -172,14 +496,6 @@ func handleGQLError(result *models.GraphQLResponse, searchType, query string) er
 	return fmt.Errorf("weaviate returned errors for %s search %s: %s", searchType, query, errMsg.String())
 }
 
-func parseVectorQuery(query string) ([]float32, error) {
-	var vector []float32
-	if err := json.Unmarshal([]byte(query), &vector); err != nil {
-		return nil, fmt.Errorf("invalid vector: expected a JSON array of floats (e.g. [0.1, 0.2, ...]): %w", err)
-	}
-	return vector, nil
-}
-
 func getGQLFields(props []*models.Property) []graphql.Field {
 	fields := make([]graphql.Field, 0, len(props))
 	for _, prop := range props {
@@ -200,6 +516,25 @@ func getGQLFields(props []*models.Property) []graphql.Field {
 	return fields
 }
 
+// withVectorFields requests the vector, or the named vectors when the collection
+// configures them, as additional fields
+func withVectorFields(fields []graphql.Field, col *models.Class) []graphql.Field {
+	additional := &fields[len(fields)-1]
+
+	if len(col.VectorConfig) == 0 {
+		additional.Fields = append(additional.Fields, graphql.Field{Name: "vector"})
+		return fields
+	}
+
+	names := make([]graphql.Field, 0, len(col.VectorConfig))
+	for _, name := range slices.Sorted(maps.Keys(col.VectorConfig)) {
+		names = append(names, graphql.Field{Name: name})
+	}
+	additional.Fields = append(additional.Fields, graphql.Field{Name: "vectors", Fields: names})
+
+	return fields
+}
+
 func getNestedFields(nestedProps []*models.NestedProperty) []graphql.Field {
 	nestedFields := make([]graphql.Field, 0, len(nestedProps))
 
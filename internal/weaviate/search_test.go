@@ -0,0 +1,90 @@
+package weaviate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+	"weaviate-desktop/internal/utils"
+
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+// searchTest is the weaviate the search tests connect to
+type searchTest struct {
+	connectionID int64
+	// version is reported by the meta endpoint, 1.30.0 when empty
+	version string
+	// modules are reported by the meta endpoint
+	modules map[string]any
+	classes []*weaviate_models.Class
+}
+
+// newSearchTestWeaviate connects to the weaviate of the test. The GraphQL queries are
+// answered with the response and recorded, the searches are sent through the gRPC
+// server when it is given.
+func newSearchTestWeaviate(
+	t *testing.T,
+	test searchTest,
+	response string,
+	queries *[]string,
+	server *grpcServer,
+) *Weaviate {
+	t.Helper()
+
+	meta := map[string]any{"version": "1.30.0"}
+	if test.version != "" {
+		meta["version"] = test.version
+	}
+	if test.modules != nil {
+		meta["modules"] = test.modules
+	}
+
+	classes := map[string]*weaviate_models.Class{}
+	for _, class := range test.classes {
+		classes["/v1/schema/"+class.Class] = class
+	}
+
+	mockServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if class, ok := classes[r.URL.Path]; ok {
+			json.NewEncoder(w).Encode(class)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/meta":
+			json.NewEncoder(w).Encode(meta)
+		case "/v1/graphql":
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			var query struct{ Query string }
+			require.NoError(t, json.Unmarshal(body, &query))
+			*queries = append(*queries, query.Query)
+
+			w.Write([]byte(response))
+		default:
+			t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+			t.Fail()
+		}
+	}))
+	t.Cleanup(mockServer.Close)
+
+	connection := &models.Connection{URI: mockServer.URL}
+	if server != nil {
+		connection.GRPCPort = utils.Pointer(server.port)
+	}
+
+	storage := NewMockStorage(t)
+	storage.EXPECT().GetConnection(test.connectionID, true).Return(connection, nil)
+	weaviate := New(storage, Configuration{StatusUpdateInterval: time.Hour})
+	t.Cleanup(func() { weaviate.Disconnect(test.connectionID) })
+	require.NoError(t, weaviate.Connect(test.connectionID))
+
+	return weaviate
+}
@@ -0,0 +1,199 @@
+package weaviate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+const (
+	ShardStatusReady    = "READY"
+	ShardStatusReadOnly = "READONLY"
+	// ShardStatusIndexing is set by weaviate while the vector index is built, it can't be set manually
+	ShardStatusIndexing = "INDEXING"
+)
+
+type ShardReplica struct {
+	Node                 string `json:"node"`
+	ObjectCount          int64  `json:"objectCount"`
+	VectorIndexingStatus string `json:"vectorIndexingStatus"`
+	VectorQueueLength    int64  `json:"vectorQueueLength"`
+	Loaded               bool   `json:"loaded"`
+}
+
+type Shard struct {
+	Name string `json:"name"`
+	// Status is READY, READONLY or INDEXING, it's empty for tenants whose shard isn't
+	// loaded on any node
+	Status          string `json:"status,omitempty"`
+	VectorQueueSize int64  `json:"vectorQueueSize"`
+	// ObjectCount is the highest object count of the replicas
+	ObjectCount int64 `json:"objectCount"`
+	// ActivityStatus is the status of the tenant on multi-tenant collections
+	ActivityStatus string         `json:"activityStatus,omitempty"`
+	Replicas       []ShardReplica `json:"replicas"`
+}
+
+type ShardsInput struct {
+	Collection string `json:"collection"`
+	// Tenants limits the shards of multi-tenant collections to the tenants, every
+	// tenant is listed when empty
+	Tenants []string `json:"tenants,omitempty"`
+}
+
+type ShardError struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+type ShardsResult struct {
+	// Shards are the shards the status was updated for
+	Shards []string     `json:"shards"`
+	Failed []ShardError `json:"failed"`
+}
+
+// GetShards lists the shards of the collection sorted by name with their status from
+// the schema shards endpoint and the object counts and vector queues of each replica.
+// Each tenant is a shard on multi-tenant collections, their status is reported by the
+// nodes holding them as the shards endpoint only returns a single tenant per request.
+// Tenants that aren't active are listed with their activity status only as their
+// shards aren't loaded.
+func (w *Weaviate) GetShards(connectionID int64, input ShardsInput) ([]Shard, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	col, err := w.GetCollection(connectionID, input.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var shards []Shard
+	if col.MultiTenancyConfig != nil && col.MultiTenancyConfig.Enabled {
+		shards, err = w.getTenantShards(connectionID, input)
+	} else {
+		var res []*models.ShardStatusGetResponse
+		res, err = c.w.Schema().ShardsGetter().WithClassName(input.Collection).Do(ctx)
+		for _, s := range res {
+			shards = append(shards, Shard{Name: s.Name, Status: s.Status, VectorQueueSize: s.VectorQueueSize})
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving shards of %s: %w", input.Collection, err)
+	}
+
+	nodes, err := c.w.Cluster().NodesStatusGetter().
+		WithClass(input.Collection).
+		WithOutput("verbose").
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying node status: %w", err)
+	}
+
+	replicas := map[string][]ShardReplica{}
+	for _, node := range nodes.Nodes {
+		for _, s := range node.Shards {
+			replicas[s.Name] = append(replicas[s.Name], ShardReplica{
+				Node:                 node.Name,
+				ObjectCount:          s.ObjectCount,
+				VectorIndexingStatus: s.VectorIndexingStatus,
+				VectorQueueLength:    s.VectorQueueLength,
+				Loaded:               s.Loaded,
+			})
+		}
+	}
+
+	for i := range shards {
+		shards[i].Replicas = replicas[shards[i].Name]
+		if shards[i].Replicas == nil {
+			shards[i].Replicas = []ShardReplica{}
+		}
+		for _, r := range shards[i].Replicas {
+			shards[i].ObjectCount = max(shards[i].ObjectCount, r.ObjectCount)
+			if shards[i].ActivityStatus != "" && r.Loaded {
+				shards[i].Status = r.VectorIndexingStatus
+				shards[i].VectorQueueSize = max(shards[i].VectorQueueSize, r.VectorQueueLength)
+			}
+		}
+	}
+	slices.SortFunc(shards, func(a, b Shard) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return shards, nil
+}
+
+// UpdateShardsStatus sets the status of the shards, e.g. back to READY after a shard
+// turned READONLY because the disk was running full. On multi-tenant collections the
+// shard name is the tenant name.
+func (w *Weaviate) UpdateShardsStatus(
+	connectionID int64,
+	collection string,
+	shards []string,
+	status string,
+) (*ShardsResult, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	if status != ShardStatusReady && status != ShardStatusReadOnly {
+		return nil, fmt.Errorf(
+			"invalid shard status %q, expected %s or %s",
+			status,
+			ShardStatusReady,
+			ShardStatusReadOnly,
+		)
+	}
+	if len(shards) == 0 {
+		return nil, errors.New("no shards selected")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result := &ShardsResult{Shards: []string{}, Failed: []ShardError{}}
+	for _, shard := range shards {
+		_, err := c.w.Schema().ShardUpdater().
+			WithClassName(collection).
+			WithShardName(shard).
+			WithStatus(status).
+			Do(ctx)
+		if err != nil {
+			result.Failed = append(result.Failed, ShardError{Name: shard, Message: err.Error()})
+			continue
+		}
+		result.Shards = append(result.Shards, shard)
+	}
+
+	return result, nil
+}
+
+// getTenantShards lists a shard per tenant, their status is set from the nodes
+func (w *Weaviate) getTenantShards(connectionID int64, input ShardsInput) ([]Shard, error) {
+	tenants, err := w.GetTenants(connectionID, input.Collection)
+	if err != nil {
+		return nil, err
+	}
+	if len(input.Tenants) > 0 {
+		tenants = slices.DeleteFunc(tenants, func(t models.Tenant) bool {
+			return !slices.Contains(input.Tenants, t.Name)
+		})
+	}
+
+	shards := make([]Shard, 0, len(tenants))
+	for _, t := range tenants {
+		shards = append(shards, Shard{Name: t.Name, ActivityStatus: t.ActivityStatus})
+	}
+
+	return shards, nil
+}
@@ -0,0 +1,213 @@
+package weaviate
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestShards(t *testing.T) {
+	connectionID := int64(1)
+
+	// newWeaviate serves a collection with two replicated shards and a multi-tenant
+	// collection, recording the shard status updates
+	newWeaviate := func(t *testing.T, requests *[]string) *Weaviate {
+		t.Helper()
+
+		var mu sync.Mutex
+		mockServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case r.URL.Path == "/v1/meta":
+				w.Write([]byte(`{"version": "1.30.0"}`))
+			case r.URL.Path == "/v1/schema/Article":
+				json.NewEncoder(w).Encode(weaviate_models.Class{Class: "Article"})
+			case r.URL.Path == "/v1/schema/Tenanted":
+				json.NewEncoder(w).Encode(weaviate_models.Class{
+					Class:              "Tenanted",
+					MultiTenancyConfig: &weaviate_models.MultiTenancyConfig{Enabled: true},
+				})
+			case r.URL.Path == "/v1/schema/Article/shards":
+				w.Write([]byte(`[
+					{"name":"shard-b","status":"READONLY","vectorQueueSize":12},
+					{"name":"shard-a","status":"READY","vectorQueueSize":0}
+				]`))
+			case r.URL.Path == "/v1/schema/Tenanted/tenants":
+				w.Write([]byte(`[
+					{"name":"customer-a","activityStatus":"HOT"},
+					{"name":"customer-b","activityStatus":"COLD"},
+					{"name":"customer-c","activityStatus":"HOT"}
+				]`))
+			case r.URL.Path == "/v1/nodes/Article":
+				assert.Equal(t, "verbose", r.URL.Query().Get("output"))
+				w.Write([]byte(`{"nodes":[
+					{"name":"node-1","shards":[
+						{"name":"shard-a","class":"Article","objectCount":10,"vectorIndexingStatus":"READY","loaded":true},
+						{"name":"shard-b","class":"Article","objectCount":20,"vectorIndexingStatus":"READONLY","vectorQueueLength":12,"loaded":true}
+					]},
+					{"name":"node-2","shards":[
+						{"name":"shard-a","class":"Article","objectCount":9,"vectorIndexingStatus":"READY","loaded":true}
+					]}
+				]}`))
+			case r.URL.Path == "/v1/nodes/Tenanted":
+				w.Write([]byte(`{"nodes":[
+					{"name":"node-1","shards":[
+						{"name":"customer-a","class":"Tenanted","objectCount":5,"vectorIndexingStatus":"READONLY","vectorQueueLength":4,"loaded":true}
+					]}
+				]}`))
+			case r.Method == http.MethodPut && r.URL.Path == "/v1/schema/Article/shards/shard-b":
+				var status weaviate_models.ShardStatus
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+				*requests = append(*requests, r.URL.Path+" "+status.Status)
+				json.NewEncoder(w).Encode(status)
+			case r.Method == http.MethodPut:
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+				t.Fail()
+			}
+		}))
+		t.Cleanup(mockServer.Close)
+
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+		client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+		require.NoError(t, err)
+		weaviate.clients.add(connectionID, client)
+
+		return weaviate
+	}
+
+	t.Run("GetShards", func(t *testing.T) {
+		t.Run("should list shards with their replicas", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			shards, err := weaviate.GetShards(connectionID, ShardsInput{Collection: "Article"})
+
+			require.NoError(t, err)
+			assert.Equal(t, []Shard{
+				{
+					Name:        "shard-a",
+					Status:      ShardStatusReady,
+					ObjectCount: 10,
+					Replicas: []ShardReplica{
+						{Node: "node-1", ObjectCount: 10, VectorIndexingStatus: "READY", Loaded: true},
+						{Node: "node-2", ObjectCount: 9, VectorIndexingStatus: "READY", Loaded: true},
+					},
+				},
+				{
+					Name:            "shard-b",
+					Status:          ShardStatusReadOnly,
+					VectorQueueSize: 12,
+					ObjectCount:     20,
+					Replicas: []ShardReplica{
+						{
+							Node:                 "node-1",
+							ObjectCount:          20,
+							VectorIndexingStatus: "READONLY",
+							VectorQueueLength:    12,
+							Loaded:               true,
+						},
+					},
+				},
+			}, shards)
+		})
+
+		t.Run("should list a shard per tenant on multi-tenant collections", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			shards, err := weaviate.GetShards(connectionID, ShardsInput{Collection: "Tenanted"})
+
+			require.NoError(t, err)
+			require.Len(t, shards, 3)
+			assert.Equal(t, Shard{
+				Name:            "customer-a",
+				Status:          ShardStatusReadOnly,
+				VectorQueueSize: 4,
+				ObjectCount:     5,
+				ActivityStatus:  "HOT",
+				Replicas: []ShardReplica{
+					{
+						Node:                 "node-1",
+						ObjectCount:          5,
+						VectorIndexingStatus: "READONLY",
+						VectorQueueLength:    4,
+						Loaded:               true,
+					},
+				},
+			}, shards[0])
+			assert.Equal(t, Shard{
+				Name:           "customer-b",
+				ActivityStatus: "COLD",
+				Replicas:       []ShardReplica{},
+			}, shards[1])
+			// the shard of the tenant isn't loaded yet
+			assert.Equal(t, Shard{
+				Name:           "customer-c",
+				ActivityStatus: "HOT",
+				Replicas:       []ShardReplica{},
+			}, shards[2])
+		})
+
+		t.Run("should only list the selected tenants", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			shards, err := weaviate.GetShards(connectionID, ShardsInput{
+				Collection: "Tenanted",
+				Tenants:    []string{"customer-b"},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, shards, 1)
+			assert.Equal(t, "customer-b", shards[0].Name)
+		})
+	})
+
+	t.Run("UpdateShardsStatus", func(t *testing.T) {
+		t.Run("should set shards back to ready", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			result, err := weaviate.UpdateShardsStatus(
+				connectionID,
+				"Article",
+				[]string{"shard-b", "shard-x"},
+				ShardStatusReady,
+			)
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"shard-b"}, result.Shards)
+			require.Len(t, result.Failed, 1)
+			assert.Equal(t, "shard-x", result.Failed[0].Name)
+			assert.Equal(t, []string{"/v1/schema/Article/shards/shard-b READY"}, requests)
+		})
+
+		t.Run("should return error on invalid status", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			result, err := weaviate.UpdateShardsStatus(
+				connectionID,
+				"Article",
+				[]string{"shard-b"},
+				ShardStatusIndexing,
+			)
+
+			assert.Nil(t, result)
+			assert.EqualError(t, err, `invalid shard status "INDEXING", expected READY or READONLY`)
+			assert.Empty(t, requests)
+		})
+	})
+}
@@ -0,0 +1,389 @@
+package weaviate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/weaviate/weaviate/entities/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// tenantsBatchSize is the amount of tenants sent on each create, update or delete request
+	tenantsBatchSize = 100
+	// tenantsCountConcurrency is the amount of tenants counted at the same time
+	tenantsCountConcurrency = 4
+)
+
+// the activity statuses a tenant can be set to, ACTIVE, INACTIVE and OFFLOADED are
+// the names used by older weaviate versions for HOT, COLD and FROZEN
+var tenantStatuses = []string{
+	models.TenantActivityStatusHOT,
+	models.TenantActivityStatusCOLD,
+	models.TenantActivityStatusFROZEN,
+	models.TenantActivityStatusACTIVE,
+	models.TenantActivityStatusINACTIVE,
+	models.TenantActivityStatusOFFLOADED,
+}
+
+type TenantInput struct {
+	Name string `json:"name"`
+	// ActivityStatus defaults to HOT when empty
+	ActivityStatus string `json:"activityStatus,omitempty"`
+}
+
+// TenantSelector picks tenants either by name or by a glob pattern, e.g. customer-*
+type TenantSelector struct {
+	Names   []string `json:"names,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+}
+
+type TenantError struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+type TenantsResult struct {
+	// Tenants are the tenants the operation succeeded for
+	Tenants []string      `json:"tenants"`
+	Failed  []TenantError `json:"failed"`
+}
+
+type TenantsPageInput struct {
+	Collection string `json:"collection"`
+	// Pattern only lists the tenants whose name matches the glob pattern
+	Pattern string `json:"pattern,omitempty"`
+	// ActivityStatus only lists the tenants with the status
+	ActivityStatus string `json:"activityStatus,omitempty"`
+	// Cursor is the name of the last tenant of the previous page
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+type TenantsPage struct {
+	Tenants []models.Tenant `json:"tenants"`
+	// NextCursor is empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+	// Total is the amount of tenants matching the input across all the pages
+	Total int `json:"total"`
+}
+
+type TenantObjectCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+	// Error is set when the tenant can't be counted, e.g. when it's not active
+	Error string `json:"error,omitempty"`
+}
+
+// GetTenantsPage lists the tenants sorted by name one page at a time. Weaviate returns
+// every tenant at once and has no cursor for tenants, so they are retrieved on the
+// first page of a walk and the following pages are cut from the same list.
+func (w *Weaviate) GetTenantsPage(connectionID int64, input TenantsPageInput) (*TenantsPage, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	if input.Pattern != "" {
+		if _, err := path.Match(input.Pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid tenant pattern %q: %w", input.Pattern, err)
+		}
+	}
+
+	all, err := w.walkTenants(c, connectionID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	status := normalizeTenantStatus(input.ActivityStatus)
+	tenants := []models.Tenant{}
+	for _, t := range all {
+		if status != "" && normalizeTenantStatus(t.ActivityStatus) != status {
+			continue
+		}
+		if matched, _ := path.Match(cmp.Or(input.Pattern, "*"), t.Name); matched {
+			tenants = append(tenants, t)
+		}
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	start, _ := slices.BinarySearchFunc(tenants, input.Cursor, func(t models.Tenant, cursor string) int {
+		return strings.Compare(t.Name, cursor)
+	})
+	if start < len(tenants) && input.Cursor != "" && tenants[start].Name == input.Cursor {
+		start++
+	}
+	end := min(start+limit, len(tenants))
+
+	page := &TenantsPage{
+		Tenants: tenants[start:end],
+		Total:   len(tenants),
+	}
+	if end < len(tenants) {
+		page.NextCursor = tenants[end-1].Name
+	}
+
+	return page, nil
+}
+
+// walkTenants are the tenants of the collection sorted by name, they are retrieved
+// when a walk starts and reused for the pages following a cursor
+func (w *Weaviate) walkTenants(c *WClient, connectionID int64, input TenantsPageInput) ([]models.Tenant, error) {
+	c.tenantWalksMu.Lock()
+	tenants, ok := c.tenantWalks[input.Collection]
+	c.tenantWalksMu.Unlock()
+	if ok && input.Cursor != "" {
+		return tenants, nil
+	}
+
+	tenants, err := w.GetTenants(connectionID, input.Collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving tenants of %s: %w", input.Collection, err)
+	}
+	slices.SortFunc(tenants, func(a, b models.Tenant) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	c.tenantWalksMu.Lock()
+	if c.tenantWalks == nil {
+		c.tenantWalks = map[string][]models.Tenant{}
+	}
+	c.tenantWalks[input.Collection] = tenants
+	c.tenantWalksMu.Unlock()
+
+	return tenants, nil
+}
+
+func (w *Weaviate) CreateTenants(
+	connectionID int64,
+	collection string,
+	tenants []TenantInput,
+) (*TenantsResult, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	toCreate := make([]models.Tenant, 0, len(tenants))
+	for _, t := range tenants {
+		if t.Name == "" {
+			return nil, errors.New("tenant name is required")
+		}
+		if t.ActivityStatus != "" && !slices.Contains(tenantStatuses, t.ActivityStatus) {
+			return nil, invalidTenantStatus(t.ActivityStatus)
+		}
+		toCreate = append(toCreate, models.Tenant{Name: t.Name, ActivityStatus: t.ActivityStatus})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	return inTenantBatches(toCreate, func(batch []models.Tenant) error {
+		return c.w.Schema().TenantsCreator().
+			WithClassName(collection).
+			WithTenants(batch...).
+			Do(ctx)
+	}), nil
+}
+
+// UpdateTenantsStatus sets the activity status of the selected tenants: HOT to load
+// them, COLD to unload them from memory or FROZEN to offload them to cloud storage
+func (w *Weaviate) UpdateTenantsStatus(
+	connectionID int64,
+	collection string,
+	selector TenantSelector,
+	status string,
+) (*TenantsResult, error) {
+	if !slices.Contains(tenantStatuses, status) {
+		return nil, invalidTenantStatus(status)
+	}
+
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	names, err := w.selectTenants(connectionID, collection, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	tenants := make([]models.Tenant, 0, len(names))
+	for _, name := range names {
+		tenants = append(tenants, models.Tenant{Name: name, ActivityStatus: status})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	return inTenantBatches(tenants, func(batch []models.Tenant) error {
+		return c.w.Schema().TenantsUpdater().
+			WithClassName(collection).
+			WithTenants(batch...).
+			Do(ctx)
+	}), nil
+}
+
+// DeleteTenants deletes the selected tenants together with their objects
+func (w *Weaviate) DeleteTenants(
+	connectionID int64,
+	collection string,
+	selector TenantSelector,
+) (*TenantsResult, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	names, err := w.selectTenants(connectionID, collection, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	tenants := make([]models.Tenant, 0, len(names))
+	for _, name := range names {
+		tenants = append(tenants, models.Tenant{Name: name})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	return inTenantBatches(tenants, func(batch []models.Tenant) error {
+		batchNames := make([]string, len(batch))
+		for i, t := range batch {
+			batchNames[i] = t.Name
+		}
+
+		return c.w.Schema().TenantsDeleter().
+			WithClassName(collection).
+			WithTenants(batchNames...).
+			Do(ctx)
+	}), nil
+}
+
+// GetTenantsObjectCount counts the objects of each tenant. Tenants that aren't active
+// can't be counted, the error is reported on the tenant instead of failing every count.
+func (w *Weaviate) GetTenantsObjectCount(
+	connectionID int64,
+	collection string,
+	tenants []string,
+) ([]TenantObjectCount, error) {
+	if _, exists := w.clients.get(connectionID); !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	counts := make([]TenantObjectCount, len(tenants))
+
+	var errg errgroup.Group
+	errg.SetLimit(tenantsCountConcurrency)
+	for i, name := range tenants {
+		errg.Go(func() error {
+			counts[i] = TenantObjectCount{Name: name}
+
+			count, err := w.GetTotalObjects(connectionID, collection, name, nil)
+			if err != nil {
+				counts[i].Count = -1
+				counts[i].Error = err.Error()
+				return nil
+			}
+			counts[i].Count = count
+
+			return nil
+		})
+	}
+	_ = errg.Wait()
+
+	return counts, nil
+}
+
+// selectTenants resolves the selector to tenant names, patterns are matched against
+// the current tenants of the collection, which are retrieved once
+func (w *Weaviate) selectTenants(
+	connectionID int64,
+	collection string,
+	selector TenantSelector,
+) ([]string, error) {
+	if len(selector.Names) > 0 && selector.Pattern != "" {
+		return nil, errors.New("select tenants either by names or by pattern")
+	}
+	if selector.Pattern == "" {
+		if len(selector.Names) == 0 {
+			return nil, errors.New("no tenants selected")
+		}
+		return selector.Names, nil
+	}
+
+	if _, err := path.Match(selector.Pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid tenant pattern %q: %w", selector.Pattern, err)
+	}
+
+	tenants, err := w.GetTenants(connectionID, collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving tenants of %s: %w", collection, err)
+	}
+
+	var names []string
+	for _, t := range tenants {
+		if matched, _ := path.Match(selector.Pattern, t.Name); matched {
+			names = append(names, t.Name)
+		}
+	}
+	slices.Sort(names)
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no tenants of %s match %q", collection, selector.Pattern)
+	}
+
+	return names, nil
+}
+
+// inTenantBatches applies the operation in batches so a failing batch doesn't stop the others
+func inTenantBatches(tenants []models.Tenant, op func(batch []models.Tenant) error) *TenantsResult {
+	result := &TenantsResult{Tenants: []string{}, Failed: []TenantError{}}
+
+	for batch := range slices.Chunk(tenants, tenantsBatchSize) {
+		err := op(batch)
+		for _, t := range batch {
+			if err != nil {
+				result.Failed = append(result.Failed, TenantError{Name: t.Name, Message: err.Error()})
+				continue
+			}
+			result.Tenants = append(result.Tenants, t.Name)
+		}
+	}
+
+	return result
+}
+
+// normalizeTenantStatus returns the current name of the activity status, older weaviate
+// versions report HOT, COLD and FROZEN as ACTIVE, INACTIVE and OFFLOADED
+func normalizeTenantStatus(status string) string {
+	switch status {
+	case models.TenantActivityStatusACTIVE:
+		return models.TenantActivityStatusHOT
+	case models.TenantActivityStatusINACTIVE:
+		return models.TenantActivityStatusCOLD
+	case models.TenantActivityStatusOFFLOADED:
+		return models.TenantActivityStatusFROZEN
+	default:
+		return status
+	}
+}
+
+func invalidTenantStatus(status string) error {
+	return fmt.Errorf(
+		"invalid tenant activity status %q, expected one of %s",
+		status,
+		strings.Join(tenantStatuses, ", "),
+	)
+}
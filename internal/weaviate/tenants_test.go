@@ -0,0 +1,397 @@
+package weaviate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestTenants(t *testing.T) {
+	connectionID := int64(1)
+	tenants := []weaviate_models.Tenant{
+		{Name: "customer-b", ActivityStatus: "HOT"},
+		{Name: "internal", ActivityStatus: "HOT"},
+		{Name: "customer-a", ActivityStatus: "HOT"},
+		{Name: "customer-c", ActivityStatus: "COLD"},
+	}
+
+	// newWeaviate serves the tenants and records the tenant changes as method and names
+	newWeaviate := func(t *testing.T, requests *[]string) *Weaviate {
+		t.Helper()
+
+		var mu sync.Mutex
+		mockServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case r.URL.Path == "/v1/meta":
+				w.Write([]byte(`{"version": "1.30.0"}`))
+			case r.URL.Path == "/v1/schema/TestCollection/tenants" && r.Method == http.MethodGet:
+				json.NewEncoder(w).Encode(tenants)
+			case r.URL.Path == "/v1/schema/TestCollection/tenants" && r.Method == http.MethodDelete:
+				var names []string
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&names))
+				*requests = append(*requests, "DELETE "+strings.Join(names, ","))
+			case r.URL.Path == "/v1/schema/TestCollection/tenants":
+				var changed []weaviate_models.Tenant
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&changed))
+
+				names := []string{}
+				for _, t := range changed {
+					names = append(names, t.Name+":"+t.ActivityStatus)
+				}
+				*requests = append(*requests, r.Method+" "+strings.Join(names, ","))
+				json.NewEncoder(w).Encode(changed)
+			case r.URL.Path == "/v1/graphql":
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				if strings.Contains(string(body), `tenant: \"customer-c\"`) {
+					w.Write([]byte(`{"errors":[{"message":"tenant not active"}]}`))
+					return
+				}
+				fmt.Fprint(w, `{"data":{"Aggregate":{"TestCollection":[{"meta":{"count":7}}]}}}`)
+			default:
+				t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+				t.Fail()
+			}
+		}))
+		t.Cleanup(mockServer.Close)
+
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+		client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+		require.NoError(t, err)
+		weaviate.clients.add(connectionID, client)
+
+		return weaviate
+	}
+
+	tenantNames := func(tenants []weaviate_models.Tenant) []string {
+		names := []string{}
+		for _, t := range tenants {
+			names = append(names, t.Name)
+		}
+		return names
+	}
+
+	t.Run("GetTenantsPage", func(t *testing.T) {
+		t.Run("should page tenants sorted by name", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			page, err := weaviate.GetTenantsPage(connectionID, TenantsPageInput{
+				Collection: "TestCollection",
+				Limit:      3,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"customer-a", "customer-b", "customer-c"}, tenantNames(page.Tenants))
+			assert.Equal(t, "customer-c", page.NextCursor)
+			assert.Equal(t, 4, page.Total)
+
+			page, err = weaviate.GetTenantsPage(connectionID, TenantsPageInput{
+				Collection: "TestCollection",
+				Cursor:     page.NextCursor,
+				Limit:      3,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"internal"}, tenantNames(page.Tenants))
+			assert.Empty(t, page.NextCursor)
+		})
+
+		t.Run("should filter tenants by pattern and status", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			page, err := weaviate.GetTenantsPage(connectionID, TenantsPageInput{
+				Collection:     "TestCollection",
+				Pattern:        "customer-*",
+				ActivityStatus: "HOT",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"customer-a", "customer-b"}, tenantNames(page.Tenants))
+			assert.Equal(t, 2, page.Total)
+		})
+
+		t.Run("should retrieve the tenants once per walk matching status aliases", func(t *testing.T) {
+			// older weaviate versions report ACTIVE and INACTIVE for HOT and COLD
+			reported := []weaviate_models.Tenant{
+				{Name: "tenant-a", ActivityStatus: "HOT"},
+				{Name: "tenant-b", ActivityStatus: "ACTIVE"},
+				{Name: "tenant-c", ActivityStatus: "INACTIVE"},
+				{Name: "tenant-d", ActivityStatus: "ACTIVE"},
+			}
+
+			retrieved := 0
+			mockServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/v1/meta":
+					w.Write([]byte(`{"version": "1.30.0"}`))
+				case r.URL.Path == "/v1/schema/TestCollection/tenants" && r.Method == http.MethodGet:
+					retrieved++
+					json.NewEncoder(w).Encode(reported)
+				default:
+					t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+					t.Fail()
+				}
+			}))
+			t.Cleanup(mockServer.Close)
+
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+			client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+			require.NoError(t, err)
+			weaviate.clients.add(connectionID, client)
+
+			names := []string{}
+			input := TenantsPageInput{Collection: "TestCollection", ActivityStatus: "HOT", Limit: 1}
+			for {
+				page, err := weaviate.GetTenantsPage(connectionID, input)
+				require.NoError(t, err)
+				assert.Equal(t, 3, page.Total)
+
+				names = append(names, tenantNames(page.Tenants)...)
+				if page.NextCursor == "" {
+					break
+				}
+				input.Cursor = page.NextCursor
+			}
+
+			assert.Equal(t, []string{"tenant-a", "tenant-b", "tenant-d"}, names)
+			assert.Equal(t, 1, retrieved)
+
+			page, err := weaviate.GetTenantsPage(connectionID, TenantsPageInput{
+				Collection:     "TestCollection",
+				ActivityStatus: "COLD",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"tenant-c"}, tenantNames(page.Tenants))
+			// a new walk retrieves the current tenants
+			assert.Equal(t, 2, retrieved)
+		})
+
+		t.Run("should return error on invalid pattern", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			page, err := weaviate.GetTenantsPage(connectionID, TenantsPageInput{
+				Collection: "TestCollection",
+				Pattern:    "customer-[",
+			})
+
+			assert.Nil(t, page)
+			assert.EqualError(t, err, `invalid tenant pattern "customer-[": syntax error in pattern`)
+		})
+	})
+
+	t.Run("CreateTenants", func(t *testing.T) {
+		t.Run("should create tenants", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			result, err := weaviate.CreateTenants(connectionID, "TestCollection", []TenantInput{
+				{Name: "customer-d"},
+				{Name: "customer-e", ActivityStatus: "COLD"},
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"customer-d", "customer-e"}, result.Tenants)
+			assert.Empty(t, result.Failed)
+			assert.Equal(t, []string{"POST customer-d:,customer-e:COLD"}, requests)
+		})
+
+		t.Run("should return error on invalid status", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			result, err := weaviate.CreateTenants(connectionID, "TestCollection", []TenantInput{
+				{Name: "customer-d", ActivityStatus: "WARM"},
+			})
+
+			assert.Nil(t, result)
+			assert.EqualError(
+				t,
+				err,
+				`invalid tenant activity status "WARM", expected one of HOT, COLD, FROZEN, ACTIVE, INACTIVE, OFFLOADED`,
+			)
+			assert.Empty(t, requests)
+		})
+	})
+
+	t.Run("UpdateTenantsStatus", func(t *testing.T) {
+		t.Run("should update the tenants matching the pattern", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			result, err := weaviate.UpdateTenantsStatus(
+				connectionID,
+				"TestCollection",
+				TenantSelector{Pattern: "customer-*"},
+				"FROZEN",
+			)
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"customer-a", "customer-b", "customer-c"}, result.Tenants)
+			assert.Equal(t, []string{"PUT customer-a:FROZEN,customer-b:FROZEN,customer-c:FROZEN"}, requests)
+		})
+
+		t.Run("should update the tenants in batches", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			names := make([]string, tenantsBatchSize+1)
+			for i := range names {
+				names[i] = fmt.Sprintf("tenant-%d", i)
+			}
+
+			result, err := weaviate.UpdateTenantsStatus(
+				connectionID,
+				"TestCollection",
+				TenantSelector{Names: names},
+				"COLD",
+			)
+
+			require.NoError(t, err)
+			assert.Len(t, result.Tenants, tenantsBatchSize+1)
+			require.Len(t, requests, 2)
+			assert.Equal(t, "PUT tenant-100:COLD", requests[1])
+		})
+
+		t.Run("should retrieve the tenants once to match the pattern", func(t *testing.T) {
+			many := make([]weaviate_models.Tenant, 3*tenantsBatchSize)
+			for i := range many {
+				many[i] = weaviate_models.Tenant{Name: fmt.Sprintf("tenant-%03d", i), ActivityStatus: "HOT"}
+			}
+
+			var retrieved, updated int
+			mockServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/v1/meta":
+					w.Write([]byte(`{"version": "1.30.0"}`))
+				case r.URL.Path == "/v1/schema/TestCollection/tenants" && r.Method == http.MethodGet:
+					retrieved++
+					json.NewEncoder(w).Encode(many)
+				case r.URL.Path == "/v1/schema/TestCollection/tenants" && r.Method == http.MethodPut:
+					var changed []weaviate_models.Tenant
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&changed))
+					updated += len(changed)
+					json.NewEncoder(w).Encode(changed)
+				default:
+					t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+					t.Fail()
+				}
+			}))
+			t.Cleanup(mockServer.Close)
+
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+			client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+			require.NoError(t, err)
+			weaviate.clients.add(connectionID, client)
+
+			result, err := weaviate.UpdateTenantsStatus(
+				connectionID,
+				"TestCollection",
+				TenantSelector{Pattern: "tenant-*"},
+				"COLD",
+			)
+
+			require.NoError(t, err)
+			assert.Len(t, result.Tenants, len(many))
+			assert.Empty(t, result.Failed)
+			assert.Equal(t, 1, retrieved)
+			assert.Equal(t, len(many), updated)
+		})
+
+		t.Run("should return error if no tenant matches the pattern", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			result, err := weaviate.UpdateTenantsStatus(
+				connectionID,
+				"TestCollection",
+				TenantSelector{Pattern: "partner-*"},
+				"HOT",
+			)
+
+			assert.Nil(t, result)
+			assert.EqualError(t, err, `no tenants of TestCollection match "partner-*"`)
+			assert.Empty(t, requests)
+		})
+	})
+
+	t.Run("DeleteTenants", func(t *testing.T) {
+		t.Run("should delete the selected tenants", func(t *testing.T) {
+			requests := []string{}
+			weaviate := newWeaviate(t, &requests)
+
+			result, err := weaviate.DeleteTenants(
+				connectionID,
+				"TestCollection",
+				TenantSelector{Names: []string{"customer-a", "internal"}},
+			)
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"customer-a", "internal"}, result.Tenants)
+			assert.Equal(t, []string{"DELETE customer-a,internal"}, requests)
+		})
+
+		t.Run("should return error when selecting by names and pattern", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			result, err := weaviate.DeleteTenants(
+				connectionID,
+				"TestCollection",
+				TenantSelector{Names: []string{"customer-a"}, Pattern: "customer-*"},
+			)
+
+			assert.Nil(t, result)
+			assert.EqualError(t, err, "select tenants either by names or by pattern")
+		})
+	})
+
+	t.Run("GetTenantsObjectCount", func(t *testing.T) {
+		t.Run("should count objects per tenant reporting inactive tenants", func(t *testing.T) {
+			weaviate := newWeaviate(t, &[]string{})
+
+			counts, err := weaviate.GetTenantsObjectCount(
+				connectionID,
+				"TestCollection",
+				[]string{"customer-a", "customer-c"},
+			)
+
+			require.NoError(t, err)
+			assert.Equal(t, []TenantObjectCount{
+				{Name: "customer-a", Count: 7},
+				{Name: "customer-c", Count: -1, Error: "tenant not active,"},
+			}, counts)
+		})
+
+		t.Run("should return error if connection doesn't exist", func(t *testing.T) {
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+
+			counts, err := weaviate.GetTenantsObjectCount(connectionID, "TestCollection", []string{"customer-a"})
+
+			assert.Nil(t, counts)
+			assert.EqualError(t, err, "connection doesn't exist 1")
+		})
+	})
+}
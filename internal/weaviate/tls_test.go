@@ -0,0 +1,164 @@
+package weaviate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLS(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"version": "1.30.0"}`))
+	})
+
+	// writePEM writes the PEM block to a file in the test directory
+	writePEM := func(t *testing.T, name, blockType string, data []byte) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data}), 0o600))
+
+		return path
+	}
+
+	// newServer starts a TLS server and returns it with the path of its CA bundle
+	newServer := func(t *testing.T, tlsConfig *tls.Config) (*httptest.Server, string) {
+		t.Helper()
+
+		server := httptest.NewUnstartedServer(handler)
+		if tlsConfig != nil {
+			server.TLS = tlsConfig
+		}
+		server.StartTLS()
+		t.Cleanup(server.Close)
+
+		return server, writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+	}
+
+	newWeaviate := func(t *testing.T) *Weaviate {
+		return New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+	}
+
+	t.Run("should trust the CA bundle", func(t *testing.T) {
+		server, caFile := newServer(t, nil)
+
+		err := newWeaviate(t).TestConnection(TestConnectionInput{
+			URI:        server.URL,
+			CACertFile: utils.Pointer(caFile),
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail on unknown certificate authorities", func(t *testing.T) {
+		server, _ := newServer(t, nil)
+
+		err := newWeaviate(t).TestConnection(TestConnectionInput{URI: server.URL})
+
+		assert.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("should skip the verification when insecure", func(t *testing.T) {
+		server, _ := newServer(t, nil)
+
+		err := newWeaviate(t).TestConnection(TestConnectionInput{
+			URI:                server.URL,
+			InsecureSkipVerify: true,
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should close the connections of the transport with the client", func(t *testing.T) {
+		closed := make(chan struct{}, 1)
+		server := httptest.NewUnstartedServer(handler)
+		server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateClosed {
+				closed <- struct{}{}
+			}
+		}
+		server.StartTLS()
+		t.Cleanup(server.Close)
+
+		err := newWeaviate(t).TestConnection(TestConnectionInput{
+			URI:                server.URL,
+			InsecureSkipVerify: true,
+		})
+		require.NoError(t, err)
+
+		select {
+		case <-closed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the idle connection of the transport wasn't closed")
+		}
+	})
+
+	t.Run("should authenticate with the client certificate", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "weaviate-desktop"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(certDER)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(cert)
+		server, caFile := newServer(t, &tls.Config{
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  clientCAs,
+		})
+
+		input := TestConnectionInput{
+			URI:            server.URL,
+			CACertFile:     utils.Pointer(caFile),
+			ClientCertFile: utils.Pointer(writePEM(t, "client.pem", "CERTIFICATE", certDER)),
+			ClientKeyFile:  utils.Pointer(writePEM(t, "client-key.pem", "EC PRIVATE KEY", keyDER)),
+		}
+		assert.NoError(t, newWeaviate(t).TestConnection(input))
+
+		input.ClientCertFile, input.ClientKeyFile = nil, nil
+		assert.Error(t, newWeaviate(t).TestConnection(input))
+	})
+
+	t.Run("should return error on invalid options", func(t *testing.T) {
+		err := newWeaviate(t).TestConnection(TestConnectionInput{
+			URI:            "https://localhost:8080",
+			ClientCertFile: utils.Pointer("client.pem"),
+		})
+		assert.EqualError(t, err, "failed configuring TLS: both the client certificate and key are required")
+
+		err = newWeaviate(t).TestConnection(TestConnectionInput{
+			URI:        "https://localhost:8080",
+			CACertFile: utils.Pointer(filepath.Join(t.TempDir(), "missing.pem")),
+		})
+		assert.ErrorContains(t, err, "failed configuring TLS: failed reading CA bundle")
+	})
+}
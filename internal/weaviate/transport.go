@@ -0,0 +1,54 @@
+package weaviate
+
+import (
+	"errors"
+	"net/http"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+)
+
+// connectionHTTPClient returns the shared client unless the connection has TLS, proxy
+// or tunnel options, those connections get a client with their own transport
+func (w *Weaviate) connectionHTTPClient(
+	c *models.Connection,
+	tunnel *sshTunnel,
+) (*http.Client, error) {
+	opts := clientOptions(c, tunnel)
+	if opts.TLS == (http_util.TLSOptions{}) && opts.ProxyURL == "" && opts.DialContext == nil {
+		return w.httpClient, nil
+	}
+
+	return http_util.GetClientWithOptions(w.httpClient.Timeout, opts)
+}
+
+// clientOptions are the transport options of the connection, shared by its HTTP and
+// gRPC clients
+func clientOptions(c *models.Connection, tunnel *sshTunnel) http_util.ClientOptions {
+	opts := http_util.ClientOptions{
+		TLS: http_util.TLSOptions{
+			CAFile:             value(c.CACertFile),
+			CertFile:           value(c.ClientCertFile),
+			KeyFile:            value(c.ClientKeyFile),
+			InsecureSkipVerify: c.InsecureSkipVerify,
+		},
+		ProxyURL: value(c.ProxyURL),
+	}
+	if tunnel != nil {
+		opts.DialContext = tunnel.DialContext
+	}
+
+	return opts
+}
+
+// openTunnel opens the SSH tunnel of the connection, nil when it has none
+func openTunnel(c *models.Connection) (*sshTunnel, error) {
+	if value(c.SSHHost) == "" {
+		return nil, nil
+	}
+	if value(c.ProxyURL) != "" {
+		return nil, errors.New("a connection can't use both a proxy and an ssh tunnel")
+	}
+
+	return openSSHTunnel(c)
+}
@@ -0,0 +1,231 @@
+package weaviate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"weaviate-desktop/internal/models"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	// sshDialTimeout bounds the TCP connection and the handshake with the SSH server
+	sshDialTimeout = 10 * time.Second
+	// sshKeepAliveInterval is how often the tunnel is checked, a keepalive without
+	// a reply within the interval fails the tunnel
+	sshKeepAliveInterval = 5 * time.Second
+)
+
+var errTunnelClosed = errors.New("ssh tunnel closed")
+
+// sshTunnel forwards the connections to weaviate through an SSH server, like a
+// local forward (ssh -L). Every connection is dialed by the SSH server so the
+// URI of the connection, and the name verified by TLS, don't change.
+type sshTunnel struct {
+	host   string
+	client *ssh.Client
+	done   chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// openSSHTunnel connects to the SSH server of the connection, the host key is
+// verified with the known hosts of the user
+func openSSHTunnel(c *models.Connection) (*sshTunnel, error) {
+	host := value(c.SSHHost)
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "22")
+	}
+	if value(c.SSHUser) == "" {
+		return nil, errors.New("ssh user is required")
+	}
+
+	hostKeys, err := knownHosts()
+	if err != nil {
+		return nil, err
+	}
+
+	auth, closeAuth, err := sshAuth(value(c.SSHKeyFile))
+	if err != nil {
+		return nil, err
+	}
+	// the agent is only needed for the handshake
+	defer closeAuth()
+
+	client, err := ssh.Dial("tcp", host, &ssh.ClientConfig{
+		User:            *c.SSHUser,
+		Auth:            []ssh.AuthMethod{auth},
+		HostKeyCallback: hostKeys,
+		Timeout:         sshDialTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed connecting to ssh server %s: %w", host, err)
+	}
+
+	t := &sshTunnel{
+		host:   host,
+		client: client,
+		done:   make(chan struct{}),
+	}
+	go t.wait()
+	go t.keepAlive(sshKeepAliveInterval)
+
+	return t, nil
+}
+
+// DialContext dials the address from the SSH server, it fails right away once the
+// tunnel is down instead of waiting for the timeout of the request
+func (t *sshTunnel) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if err := t.Err(); err != nil {
+		return nil, err
+	}
+
+	conn, err := t.client.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed dialing %s through ssh tunnel %s: %w", addr, t.host, err)
+	}
+
+	return conn, nil
+}
+
+// Err returns why the tunnel is down, nil while it's up
+func (t *sshTunnel) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.err
+}
+
+// Close closes the tunnel and waits for the SSH connection to be closed
+func (t *sshTunnel) Close() {
+	t.fail(errTunnelClosed)
+	<-t.done
+}
+
+// fail records the first error of the tunnel and closes the SSH connection
+func (t *sshTunnel) fail(err error) {
+	t.mu.Lock()
+	if t.err == nil {
+		t.err = err
+	}
+	t.mu.Unlock()
+
+	t.client.Close()
+}
+
+// wait records the SSH connection being closed by the server or the network
+func (t *sshTunnel) wait() {
+	err := t.client.Wait()
+	if err == nil {
+		err = errors.New("connection closed by server")
+	}
+	t.fail(fmt.Errorf("ssh tunnel to %s failed: %w", t.host, err))
+
+	close(t.done)
+}
+
+// keepAlive sends keepalive requests to detect a dead tunnel, a broken network
+// doesn't close the SSH connection on its own
+func (t *sshTunnel) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+		}
+
+		reply := make(chan error, 1)
+		go func() {
+			_, _, err := t.client.SendRequest("keepalive@openssh.com", true, nil)
+			reply <- err
+		}()
+
+		select {
+		case <-t.done:
+			return
+		case err := <-reply:
+			if err != nil {
+				t.fail(fmt.Errorf("ssh tunnel to %s failed: %w", t.host, err))
+				return
+			}
+		case <-time.After(interval):
+			t.fail(fmt.Errorf("ssh tunnel to %s failed: keepalive timed out", t.host))
+			return
+		}
+	}
+}
+
+// sshAuth authenticates with the private key, or with the keys of the SSH agent
+// when the key file is empty. The returned function closes the agent connection.
+func sshAuth(keyFile string) (ssh.AuthMethod, func(), error) {
+	if keyFile != "" {
+		if rest, ok := strings.CutPrefix(keyFile, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed finding ssh key: %w", err)
+			}
+			keyFile = filepath.Join(home, rest)
+		}
+
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed reading ssh key: %w", err)
+		}
+
+		signer, err := ssh.ParsePrivateKey(data)
+		var passphraseErr *ssh.PassphraseMissingError
+		if errors.As(err, &passphraseErr) {
+			return nil, nil, fmt.Errorf(
+				"ssh key %s is protected by a passphrase, add it to the ssh agent instead",
+				keyFile,
+			)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed parsing ssh key: %w", err)
+		}
+
+		return ssh.PublicKeys(signer), func() {}, nil
+	}
+
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, nil, errors.New("ssh key file is required when no ssh agent is running")
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed connecting to ssh agent: %w", err)
+	}
+
+	return ssh.PublicKeysCallback(agent.NewClient(conn).Signers), func() { conn.Close() }, nil
+}
+
+// knownHosts verifies the host keys with the known hosts of the user, unknown hosts
+// have to be trusted with ssh first
+func knownHosts() (ssh.HostKeyCallback, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed finding known hosts: %w", err)
+	}
+
+	callback, err := knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading known hosts: %w", err)
+	}
+
+	return callback, nil
+}
@@ -0,0 +1,435 @@
+package weaviate
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"weaviate-desktop/internal/http_util"
+	"weaviate-desktop/internal/models"
+	"weaviate-desktop/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestProxy(t *testing.T) {
+	newWeaviate := func(t *testing.T) *Weaviate {
+		return New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+	}
+
+	t.Run("should send the requests through the HTTP proxy", func(t *testing.T) {
+		hosts := []string{}
+		proxyServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hosts = append(hosts, r.URL.Host)
+			w.Write([]byte(`{"version": "1.30.0"}`))
+		}))
+		defer proxyServer.Close()
+
+		err := newWeaviate(t).TestConnection(TestConnectionInput{
+			URI:      "http://weaviate.internal:8080",
+			ProxyURL: utils.Pointer(proxyServer.URL),
+		})
+
+		assert.NoError(t, err)
+		// the meta is requested by the go client when it's created and by the test
+		assert.Equal(t, []string{"weaviate.internal:8080", "weaviate.internal:8080"}, hosts)
+	})
+
+	t.Run("should dial through the SOCKS5 proxy", func(t *testing.T) {
+		server := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"version": "1.30.0"}`))
+		}))
+		defer server.Close()
+		proxyAddr, targets := newSOCKS5Server(t)
+
+		err := newWeaviate(t).TestConnection(TestConnectionInput{
+			URI:      server.URL,
+			ProxyURL: utils.Pointer("socks5://" + proxyAddr),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{server.Listener.Addr().String()}, targets())
+	})
+
+	t.Run("should return error on invalid options", func(t *testing.T) {
+		err := newWeaviate(t).TestConnection(TestConnectionInput{
+			URI:      "http://localhost:8080",
+			ProxyURL: utils.Pointer("ftp://proxy:21"),
+		})
+		assert.EqualError(
+			t,
+			err,
+			`failed configuring proxy: unsupported proxy scheme "ftp", expected http, https, socks5 or socks5h`,
+		)
+
+		err = newWeaviate(t).TestConnection(TestConnectionInput{
+			URI:      "http://localhost:8080",
+			ProxyURL: utils.Pointer("http://proxy:3128"),
+			SSHHost:  utils.Pointer("bastion"),
+		})
+		assert.EqualError(t, err, "a connection can't use both a proxy and an ssh tunnel")
+	})
+}
+
+func TestSSHTunnel(t *testing.T) {
+	connectionID := int64(1)
+
+	weaviateServer := http_util.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"version": "1.30.0"}`))
+	}))
+	defer weaviateServer.Close()
+
+	// newConnection returns a connection tunneled through a new SSH server, with the
+	// host key of the server in the known hosts
+	newConnection := func(t *testing.T) (*models.Connection, *sshServer) {
+		t.Helper()
+
+		server := newSSHServer(t)
+		t.Setenv("HOME", t.TempDir())
+		server.trust(t)
+
+		return &models.Connection{
+			ID:         connectionID,
+			URI:        weaviateServer.URL,
+			SSHHost:    utils.Pointer(server.addr),
+			SSHUser:    utils.Pointer("weaviate"),
+			SSHKeyFile: utils.Pointer(server.clientKeyFile(t)),
+		}, server
+	}
+
+	t.Run("should connect through the tunnel", func(t *testing.T) {
+		connection, server := newConnection(t)
+
+		storage := NewMockStorage(t)
+		storage.EXPECT().GetConnection(connectionID, true).Return(connection, nil)
+		weaviate := New(storage, Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+
+		require.NoError(t, weaviate.Connect(connectionID))
+		assert.Equal(t, []string{weaviateServer.Listener.Addr().String()}, server.targets())
+
+		healthy, err := weaviate.ClusterStatus(connectionID)
+		assert.NoError(t, err)
+		assert.True(t, healthy)
+
+		client, _ := weaviate.clients.get(connectionID)
+		require.NoError(t, weaviate.Disconnect(connectionID))
+		assert.ErrorIs(t, client.tunnelErr(), errTunnelClosed)
+	})
+
+	t.Run("should report tunnel failures in the cluster status", func(t *testing.T) {
+		connection, server := newConnection(t)
+
+		weaviate := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		})
+		client, err := weaviate.getClientFromConnection(connection)
+		require.NoError(t, err)
+		weaviate.clients.add(connectionID, client)
+
+		server.closeConnections()
+		require.Eventually(
+			t,
+			func() bool { return client.tunnelErr() != nil },
+			5*time.Second,
+			10*time.Millisecond,
+		)
+
+		healthy, err := weaviate.ClusterStatus(connectionID)
+		assert.False(t, healthy)
+		assert.ErrorContains(t, err, "ssh tunnel to "+server.addr+" failed")
+
+		// requests fail right away instead of waiting for the timeout
+		start := time.Now()
+		_, err = weaviate.GetObjectsPage(connectionID, ObjectsPageInput{Collection: "Article", PageSize: 10})
+		assert.ErrorContains(t, err, "ssh tunnel to "+server.addr+" failed")
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("should reject unknown host keys", func(t *testing.T) {
+		connection, _ := newConnection(t)
+		// the known hosts of another home don't contain the server
+		t.Setenv("HOME", t.TempDir())
+		require.NoError(t, os.MkdirAll(filepath.Join(os.Getenv("HOME"), ".ssh"), 0o700))
+		knownHostsFile := filepath.Join(os.Getenv("HOME"), ".ssh", "known_hosts")
+		require.NoError(t, os.WriteFile(knownHostsFile, nil, 0o600))
+
+		err := New(NewMockStorage(t), Configuration{
+			StatusUpdateInterval: time.Hour,
+		}).TestConnection(TestConnectionInput{
+			URI:        connection.URI,
+			SSHHost:    connection.SSHHost,
+			SSHUser:    connection.SSHUser,
+			SSHKeyFile: connection.SSHKeyFile,
+		})
+
+		assert.ErrorContains(t, err, "failed connecting to ssh server "+*connection.SSHHost)
+		assert.ErrorContains(t, err, "key is unknown")
+	})
+}
+
+// sshServer is an SSH server forwarding direct-tcpip channels, like sshd with
+// AllowTcpForwarding
+type sshServer struct {
+	addr      string
+	hostKey   ssh.Signer
+	clientKey ed25519.PrivateKey
+
+	mu          sync.Mutex
+	conns       []*ssh.ServerConn
+	dialTargets []string
+}
+
+func newSSHServer(t *testing.T) *sshServer {
+	t.Helper()
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(t, err)
+	clientSigner, err := ssh.NewSignerFromKey(clientKey)
+	require.NoError(t, err)
+
+	cfg := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientSigner.PublicKey().Marshal()) {
+				return nil, assert.AnError
+			}
+			return &ssh.Permissions{}, nil
+		},
+	}
+	cfg.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	s := &sshServer{
+		addr:      listener.Addr().String(),
+		hostKey:   hostSigner,
+		clientKey: clientKey,
+	}
+	t.Cleanup(s.closeConnections)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, cfg)
+		}
+	}()
+
+	return s
+}
+
+func (s *sshServer) serve(conn net.Conn, cfg *ssh.ServerConfig) {
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, cfg)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.conns = append(s.conns, serverConn)
+	s.mu.Unlock()
+
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "direct-tcpip" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		var target struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(newChannel.ExtraData(), &target); err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		addr := net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port)))
+		s.mu.Lock()
+		s.dialTargets = append(s.dialTargets, addr)
+		s.mu.Unlock()
+
+		targetConn, err := net.Dial("tcp", addr)
+		if err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			targetConn.Close()
+			continue
+		}
+		go ssh.DiscardRequests(channelRequests)
+		go pipe(channel, targetConn)
+	}
+}
+
+// trust adds the host key of the server to the known hosts of $HOME
+func (s *sshServer) trust(t *testing.T) {
+	t.Helper()
+
+	dir := filepath.Join(os.Getenv("HOME"), ".ssh")
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	line := knownhosts.Line([]string{s.addr}, s.hostKey.PublicKey()) + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "known_hosts"), []byte(line), 0o600))
+}
+
+// clientKeyFile writes the private key of the client in the OpenSSH format
+func (s *sshServer) clientKeyFile(t *testing.T) string {
+	t.Helper()
+
+	block, err := ssh.MarshalPrivateKey(s.clientKey, "")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+
+	return path
+}
+
+func (s *sshServer) targets() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.dialTargets
+}
+
+// closeConnections drops the SSH connections, like a bastion going away
+func (s *sshServer) closeConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+}
+
+// newSOCKS5Server starts a SOCKS5 proxy without authentication supporting CONNECT,
+// it returns its address and a function returning the dialed addresses
+func newSOCKS5Server(t *testing.T) (string, func() []string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	var mu sync.Mutex
+	targets := []string{}
+
+	serve := func(conn net.Conn) {
+		defer conn.Close()
+
+		// greeting: version, number of methods, methods
+		header := make([]byte, 2)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		if _, err := io.ReadFull(conn, make([]byte, header[1])); err != nil {
+			return
+		}
+		conn.Write([]byte{0x05, 0x00})
+
+		// request: version, command, reserved, address type, address, port
+		request := make([]byte, 4)
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return
+		}
+		var host string
+		switch request[3] {
+		case 0x01:
+			ip := make([]byte, 4)
+			if _, err := io.ReadFull(conn, ip); err != nil {
+				return
+			}
+			host = net.IP(ip).String()
+		case 0x03:
+			length := make([]byte, 1)
+			if _, err := io.ReadFull(conn, length); err != nil {
+				return
+			}
+			name := make([]byte, length[0])
+			if _, err := io.ReadFull(conn, name); err != nil {
+				return
+			}
+			host = string(name)
+		default:
+			return
+		}
+		port := make([]byte, 2)
+		if _, err := io.ReadFull(conn, port); err != nil {
+			return
+		}
+		addr := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+
+		mu.Lock()
+		targets = append(targets, addr)
+		mu.Unlock()
+
+		targetConn, err := net.Dial("tcp", addr)
+		if err != nil {
+			conn.Write([]byte{0x05, 0x05, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+			return
+		}
+		conn.Write([]byte{0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+		pipe(conn, targetConn)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn)
+		}
+	}()
+
+	return listener.Addr().String(), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return targets
+	}
+}
+
+// pipe copies between both connections until either is closed
+func pipe(a, b io.ReadWriteCloser) {
+	defer a.Close()
+	defer b.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(a, b)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(b, a)
+		done <- struct{}{}
+	}()
+	<-done
+}
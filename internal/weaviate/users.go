@@ -9,7 +9,7 @@ import (
 )
 
 func (w *Weaviate) UsersEnabled(connectionID int64) (bool, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return false, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -40,7 +40,7 @@ type UserInfo struct {
 }
 
 func (w *Weaviate) ListUsers(connectionID int64) ([]UserInfo, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -88,7 +88,7 @@ func (w *Weaviate) ListUsers(connectionID int64) ([]UserInfo, error) {
 }
 
 func (w *Weaviate) DeleteUser(connectionID int64, userID string) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -109,7 +109,7 @@ func (w *Weaviate) DeleteUser(connectionID int64, userID string) error {
 }
 
 func (w *Weaviate) CreateUser(connectionID int64, userID string) (string, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return "", fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -126,7 +126,7 @@ func (w *Weaviate) CreateUser(connectionID int64, userID string) (string, error)
 }
 
 func (w *Weaviate) AssignRolesToUser(connectionID int64, userID string, roleNames []string) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -146,7 +146,7 @@ func (w *Weaviate) RevokeRolesFromUser(
 	userID string,
 	roleNames []string,
 ) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -162,7 +162,7 @@ func (w *Weaviate) RevokeRolesFromUser(
 }
 
 func (w *Weaviate) RotateUserApiKey(connectionID int64, userID string) (string, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return "", fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -179,7 +179,7 @@ func (w *Weaviate) RotateUserApiKey(connectionID int64, userID string) (string,
 }
 
 func (w *Weaviate) DeactivateApiKey(connectionID int64, userID string, revokeKey bool) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -199,7 +199,7 @@ func (w *Weaviate) DeactivateApiKey(connectionID int64, userID string, revokeKey
 }
 
 func (w *Weaviate) ActivateApiKey(connectionID int64, userID string) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
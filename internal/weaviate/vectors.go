@@ -0,0 +1,394 @@
+package weaviate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
+	"github.com/weaviate/weaviate/entities/models"
+	pb "github.com/weaviate/weaviate/grpc/generated/protocol/v1"
+	"github.com/weaviate/weaviate/usecases/byteops"
+)
+
+// targetCombinations are the methods joining the distances of several target vectors
+var targetCombinations = map[string]pb.CombinationMethod{
+	"sum":           pb.CombinationMethod_COMBINATION_METHOD_TYPE_SUM,
+	"average":       pb.CombinationMethod_COMBINATION_METHOD_TYPE_AVERAGE,
+	"minimum":       pb.CombinationMethod_COMBINATION_METHOD_TYPE_MIN,
+	"relativeScore": pb.CombinationMethod_COMBINATION_METHOD_TYPE_RELATIVE_SCORE,
+	"manualWeights": pb.CombinationMethod_COMBINATION_METHOD_TYPE_MANUAL,
+}
+
+// vectorTargets are the target vectors of a vector search and the query vectors of a
+// nearVector search, either one vector for all targets or a vector per target
+type vectorTargets struct {
+	names       []string
+	combination string
+	weights     map[string]float32
+	vector      models.Vector
+	perTarget   map[string]models.Vector
+}
+
+// searchTargets validates the target vectors of the options against the named vectors
+// of the collection and parses the query vectors of nearVector searches
+func searchTargets(col *models.Class, searchType, query string, opts SearchOptions) (*vectorTargets, error) {
+	targets := &vectorTargets{
+		names:       opts.TargetVectors,
+		combination: opts.TargetCombination,
+		weights:     opts.TargetWeights,
+	}
+
+	if searchType == "nearVector" {
+		var err error
+		targets.vector, targets.perTarget, err = parseVectorQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the targets default to the vectors of the query or the weighted vectors
+	if len(targets.names) == 0 {
+		switch {
+		case targets.perTarget != nil:
+			targets.names = slices.Sorted(maps.Keys(targets.perTarget))
+		case len(targets.weights) > 0:
+			targets.names = slices.Sorted(maps.Keys(targets.weights))
+		}
+	}
+
+	for _, name := range targets.names {
+		if _, ok := col.VectorConfig[name]; !ok {
+			return nil, fmt.Errorf("named vector %q does not exist in collection %s", name, col.Class)
+		}
+	}
+	for name := range targets.perTarget {
+		if !slices.Contains(targets.names, name) {
+			return nil, fmt.Errorf("vector of %q is given but it isn't a target vector", name)
+		}
+	}
+	if targets.perTarget != nil {
+		for _, name := range targets.names {
+			if _, ok := targets.perTarget[name]; !ok {
+				return nil, fmt.Errorf("vector of target vector %q is missing", name)
+			}
+		}
+	}
+
+	if targets.combination != "" {
+		if _, ok := targetCombinations[targets.combination]; !ok {
+			return nil, fmt.Errorf("unsupported target combination %q", targets.combination)
+		}
+		if len(targets.names) == 0 {
+			return nil, fmt.Errorf("target vectors are required for the %s combination", targets.combination)
+		}
+	}
+
+	switch targets.combination {
+	case "relativeScore", "manualWeights":
+		for _, name := range targets.names {
+			if _, ok := targets.weights[name]; !ok {
+				return nil, fmt.Errorf("weight of target vector %q is required for %s", name, targets.combination)
+			}
+		}
+		for name := range targets.weights {
+			if !slices.Contains(targets.names, name) {
+				return nil, fmt.Errorf("weight of %q is given but it isn't a target vector", name)
+			}
+		}
+	default:
+		if len(targets.weights) > 0 {
+			return nil, errors.New("target weights require the relativeScore or manualWeights combination")
+		}
+	}
+
+	return targets, nil
+}
+
+// parseVectorQuery parses the query of nearVector searches, a JSON array of floats or
+// an array of arrays for multi vectors. An object gives the vector per target vector.
+func parseVectorQuery(query string) (models.Vector, map[string]models.Vector, error) {
+	if !strings.HasPrefix(strings.TrimSpace(query), "{") {
+		vector, err := parseVector([]byte(query))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid vector: %w", err)
+		}
+		return vector, nil, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(query), &raw); err != nil {
+		return nil, nil, fmt.Errorf("invalid vectors per target vector: %w", err)
+	}
+
+	perTarget := make(map[string]models.Vector, len(raw))
+	for name, data := range raw {
+		vector, err := parseVector(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid vector of target vector %s: %w", name, err)
+		}
+		perTarget[name] = vector
+	}
+
+	return nil, perTarget, nil
+}
+
+func parseVector(data []byte) (models.Vector, error) {
+	var vector []float32
+	err := json.Unmarshal(data, &vector)
+	if err == nil {
+		return vector, nil
+	}
+
+	var multi [][]float32
+	if json.Unmarshal(data, &multi) == nil {
+		return multi, nil
+	}
+
+	return nil, fmt.Errorf(
+		"expected a JSON array of floats (e.g. [0.1, 0.2, ...]) or an array of arrays for multi vectors: %w",
+		err,
+	)
+}
+
+// gqlTargets is the argument selecting the target vectors, nil without targets
+func (t *vectorTargets) gqlTargets() *graphql.MultiTargetArgumentBuilder {
+	targets := &graphql.MultiTargetArgumentBuilder{}
+	switch t.combination {
+	case "sum":
+		return targets.Sum(t.names...)
+	case "average":
+		return targets.Average(t.names...)
+	case "minimum":
+		return targets.Minimum(t.names...)
+	case "relativeScore":
+		return targets.RelativeScore(t.weights)
+	case "manualWeights":
+		return targets.ManualWeights(t.weights)
+	default:
+		return nil
+	}
+}
+
+func (t *vectorTargets) gqlNearVector() *graphql.NearVectorArgumentBuilder {
+	nv := (&graphql.NearVectorArgumentBuilder{}).WithVector(t.vector)
+	if t.perTarget != nil {
+		nv = nv.WithVectorPerTarget(t.perTarget)
+	}
+
+	return nv
+}
+
+// gqlVectorsField requests the vectors of the targets
+func (t *vectorTargets) gqlVectorsField() graphql.Field {
+	names := make([]graphql.Field, 0, len(t.names))
+	for _, name := range t.names {
+		names = append(names, graphql.Field{Name: name})
+	}
+
+	return graphql.Field{Name: "vectors", Fields: names}
+}
+
+// grpcTargets is the gRPC message selecting the target vectors, nil without targets
+func (t *vectorTargets) grpcTargets() *pb.Targets {
+	if len(t.names) == 0 {
+		return nil
+	}
+
+	targets := &pb.Targets{
+		TargetVectors: t.names,
+		Combination:   targetCombinations[t.combination],
+	}
+	if len(t.weights) > 0 {
+		for _, name := range t.names {
+			targets.WeightsForTargets = append(targets.WeightsForTargets, &pb.WeightsForTarget{
+				Target: name,
+				Weight: t.weights[name],
+			})
+		}
+	}
+
+	return targets
+}
+
+func (t *vectorTargets) grpcNearVector() *pb.NearVector {
+	nv := &pb.NearVector{Targets: t.grpcTargets()}
+	switch v := t.vector.(type) {
+	case []float32:
+		// weaviate reads the vectors field since 1.29 only, multi vectors are newer
+		nv.VectorBytes = byteops.Fp32SliceToBytes(v) //nolint:staticcheck
+	case [][]float32:
+		nv.Vectors = []*pb.Vectors{grpcMultiVector(v)}
+	}
+
+	// the vectors are sent in the order of the targets
+	for _, name := range t.names {
+		vector, ok := t.perTarget[name]
+		if !ok {
+			continue
+		}
+
+		target := &pb.VectorForTarget{Name: name}
+		switch v := vector.(type) {
+		case []float32:
+			target.VectorBytes = byteops.Fp32SliceToBytes(v) //nolint:staticcheck
+		case [][]float32:
+			target.Vectors = []*pb.Vectors{grpcMultiVector(v)}
+		}
+		nv.VectorForTargets = append(nv.VectorForTargets, target)
+	}
+
+	return nv
+}
+
+func grpcMultiVector(v [][]float32) *pb.Vectors {
+	return &pb.Vectors{
+		VectorBytes: byteops.Fp32SliceOfSlicesToBytes(v),
+		Type:        pb.Vectors_VECTOR_TYPE_MULTI_FP32,
+	}
+}
+
+// hasQueryVectors reports whether the search has query vectors, only the distances to
+// those are computed by setDistances which needs the vectors of the targets
+func (t *vectorTargets) hasQueryVectors() bool {
+	return t.vector != nil || t.perTarget != nil
+}
+
+// setDistances sets the distance of the objects to the query vectors per target vector
+// of nearVector searches. Weaviate only returns the combined distance, so they are
+// computed from the returned vectors with the distance metric of the vector index.
+func (t *vectorTargets) setDistances(col *models.Class, objects []WeaviateObject) {
+	if !t.hasQueryVectors() {
+		return
+	}
+
+	for i := range objects {
+		for _, name := range t.names {
+			query := t.vector
+			if t.perTarget != nil {
+				query = t.perTarget[name]
+			}
+
+			distance, ok := vectorDistance(vectorMetric(col, name), query, toVector(objects[i].Vectors[name]))
+			if !ok {
+				continue
+			}
+			if objects[i].TargetDistances == nil {
+				objects[i].TargetDistances = make(map[string]float32, len(t.names))
+			}
+			objects[i].TargetDistances[name] = distance
+		}
+	}
+}
+
+// vectorMetric is the distance metric of the vector index of the named vector
+func vectorMetric(col *models.Class, name string) string {
+	config, _ := col.VectorConfig[name].VectorIndexConfig.(map[string]any)
+	if metric, ok := config["distance"].(string); ok && metric != "" {
+		return metric
+	}
+
+	return "cosine"
+}
+
+// toVector converts a vector of the results, GraphQL returns them as decoded JSON
+func toVector(value any) models.Vector {
+	switch v := value.(type) {
+	case []float32, [][]float32:
+		return v
+	case []any:
+		if len(v) > 0 {
+			if _, ok := v[0].([]any); ok {
+				multi := make([][]float32, 0, len(v))
+				for _, vector := range v {
+					single, _ := toVector(vector).([]float32)
+					multi = append(multi, single)
+				}
+				return multi
+			}
+		}
+
+		vector := make([]float32, 0, len(v))
+		for _, x := range v {
+			f, _ := toFloat(x)
+			vector = append(vector, float32(f))
+		}
+		return vector
+	default:
+		return nil
+	}
+}
+
+// vectorDistance is the distance like weaviate computes it, multi vectors use late
+// interaction: the sum of the minimal distance of each query vector to the vectors
+func vectorDistance(metric string, query, vector models.Vector) (float32, bool) {
+	switch q := query.(type) {
+	case []float32:
+		v, ok := vector.([]float32)
+		if !ok || len(v) != len(q) || len(v) == 0 {
+			return 0, false
+		}
+		return singleDistance(metric, q, v)
+	case [][]float32:
+		v, ok := vector.([][]float32)
+		if !ok || len(v) == 0 {
+			return 0, false
+		}
+
+		sum := float32(0)
+		for _, qv := range q {
+			nearest := float32(math.MaxFloat32)
+			for _, vv := range v {
+				if len(vv) != len(qv) {
+					return 0, false
+				}
+				d, ok := singleDistance(metric, qv, vv)
+				if !ok {
+					return 0, false
+				}
+				nearest = min(nearest, d)
+			}
+			sum += nearest
+		}
+		return sum, true
+	default:
+		return 0, false
+	}
+}
+
+func singleDistance(metric string, a, b []float32) (float32, bool) {
+	var dot, normA, normB, l2, manhattan, hamming float64
+	for i := range a {
+		x, y := float64(a[i]), float64(b[i])
+		dot += x * y
+		normA += x * x
+		normB += y * y
+		l2 += (x - y) * (x - y)
+		manhattan += math.Abs(x - y)
+		if x != y {
+			hamming++
+		}
+	}
+
+	switch metric {
+	case "cosine":
+		if normA == 0 || normB == 0 {
+			return 0, false
+		}
+		return float32(1 - dot/(math.Sqrt(normA)*math.Sqrt(normB))), true
+	case "dot":
+		return float32(-dot), true
+	case "l2-squared":
+		return float32(l2), true
+	case "manhattan":
+		return float32(manhattan), true
+	case "hamming":
+		return float32(hamming), true
+	default:
+		return 0, false
+	}
+}
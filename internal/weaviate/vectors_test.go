@@ -0,0 +1,248 @@
+package weaviate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	weaviate_models "github.com/weaviate/weaviate/entities/models"
+	pb "github.com/weaviate/weaviate/grpc/generated/protocol/v1"
+	"github.com/weaviate/weaviate/usecases/byteops"
+)
+
+func TestVectorSearch(t *testing.T) {
+	connectionID := int64(1)
+	class := &weaviate_models.Class{
+		Class:      "Article",
+		Properties: []*weaviate_models.Property{{Name: "title", DataType: []string{"text"}}},
+		VectorConfig: map[string]weaviate_models.VectorConfig{
+			"title": {VectorIndexConfig: map[string]any{"distance": "dot"}},
+			"body":  {VectorIndexConfig: map[string]any{}},
+			"colbert": {VectorIndexConfig: map[string]any{
+				"multivector": map[string]any{"enabled": true},
+			}},
+		},
+	}
+
+	search := searchTest{
+		connectionID: connectionID,
+		classes:      []*weaviate_models.Class{class},
+	}
+
+	t.Run("should search a vector per target with weights through grpc", func(t *testing.T) {
+		server := newGRPCServer(t)
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &[]string{}, server)
+
+		query := `{"title": [1, 0], "body": [0, 1]}`
+		res, err := weaviate.Search(connectionID, "Article", "", "nearVector", query, SearchOptions{
+			TargetCombination: "manualWeights",
+			TargetWeights:     map[string]float32{"title": 0.7, "body": 0.3},
+		})
+		require.NoError(t, err)
+
+		req := server.lastSearch()
+		assert.Equal(t, []string{"body", "title"}, req.Metadata.Vectors)
+		assert.Equal(t, []string{"body", "title"}, req.NearVector.Targets.TargetVectors)
+		assert.Equal(t, pb.CombinationMethod_COMBINATION_METHOD_TYPE_MANUAL, req.NearVector.Targets.Combination)
+		require.Len(t, req.NearVector.Targets.WeightsForTargets, 2)
+		assert.Equal(t, "body", req.NearVector.Targets.WeightsForTargets[0].Target)
+		assert.Equal(t, float32(0.3), req.NearVector.Targets.WeightsForTargets[0].Weight)
+		require.Len(t, req.NearVector.VectorForTargets, 2)
+		assert.Equal(t, "body", req.NearVector.VectorForTargets[0].Name)
+		assert.Equal(t, []float32{0, 1}, byteops.Fp32SliceFromBytes(req.NearVector.VectorForTargets[0].VectorBytes))
+
+		// the results have the vector [1, 0] for every target
+		require.Len(t, res.Objects, 1)
+		assert.Equal(t, map[string]float32{"body": 1, "title": -1}, res.Objects[0].TargetDistances)
+		assert.Equal(t, []float32{1, 0}, res.Objects[0].Vectors["title"])
+	})
+
+	t.Run("should search target vectors of hybrid and near text through grpc", func(t *testing.T) {
+		server := newGRPCServer(t)
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &[]string{}, server)
+
+		_, err := weaviate.Search(connectionID, "Article", "", "hybrid", "weaviate", SearchOptions{
+			TargetVectors:     []string{"title", "body"},
+			TargetCombination: "average",
+		})
+		require.NoError(t, err)
+
+		req := server.lastSearch()
+		// only the distances of near vector searches are computed from the vectors
+		assert.Empty(t, req.Metadata.Vectors)
+		assert.Equal(t, []string{"title", "body"}, req.HybridSearch.Targets.TargetVectors)
+		assert.Equal(t, pb.CombinationMethod_COMBINATION_METHOD_TYPE_AVERAGE, req.HybridSearch.Targets.Combination)
+
+		_, err = weaviate.Search(connectionID, "Article", "", "nearText", "weaviate", SearchOptions{
+			TargetVectors: []string{"body"},
+		})
+		require.NoError(t, err)
+
+		req = server.lastSearch()
+		assert.Empty(t, req.Metadata.Vectors)
+		assert.Equal(t, []string{"body"}, req.NearText.Targets.TargetVectors)
+		assert.Equal(t, pb.CombinationMethod_COMBINATION_METHOD_UNSPECIFIED, req.NearText.Targets.Combination)
+	})
+
+	t.Run("should search multi vectors through graphql", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{"data": {"Get": {"Article": [{
+			"title": "Weaviate",
+			"_additional": {
+				"id": "00000000-0000-0000-0000-000000000001",
+				"creationTimeUnix": "1", "lastUpdateTimeUnix": "2",
+				"vectors": {"colbert": [[1, 0]]}
+			}
+		}]}}}`, &queries, nil)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "nearVector", "[[1, 0], [0, 1]]", SearchOptions{
+			TargetVectors: []string{"colbert"},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], `nearVector:{vector: [[1,0],[0,1]] targetVectors: ["colbert"]}`)
+		assert.Contains(t, queries[0], "vectors{colbert}")
+
+		// the nearest vector of [1, 0] is at 0 and of [0, 1] at 1 with cosine distances
+		require.Len(t, res.Objects, 1)
+		assert.Equal(t, map[string]float32{"colbert": 1}, res.Objects[0].TargetDistances)
+	})
+
+	t.Run("should combine target vectors through graphql", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{"data": {"Get": {"Article": []}}}`, &queries, nil)
+
+		_, err := weaviate.Search(connectionID, "Article", "", "nearText", "weaviate", SearchOptions{
+			TargetVectors:     []string{"title", "body"},
+			TargetCombination: "sum",
+		})
+		require.NoError(t, err)
+
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], `targets:{combinationMethod: sum, targetVectors: ["title","body"]}`)
+		assert.NotContains(t, queries[0], "vectors{")
+	})
+
+	t.Run("should search near an object without the object through grpc", func(t *testing.T) {
+		server := newGRPCServer(t)
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &[]string{}, server)
+
+		id := "00000000-0000-0000-0000-000000000001"
+		_, err := weaviate.Search(connectionID, "Article", "", "nearObject", id, SearchOptions{
+			TargetVectors: []string{"title"},
+			Distance:      0.25,
+		})
+		require.NoError(t, err)
+
+		req := server.lastSearch()
+		assert.Equal(t, id, req.NearObject.Id)
+		assert.Equal(t, 0.25, req.NearObject.GetDistance())
+		assert.Nil(t, req.NearObject.Certainty)
+		assert.Equal(t, []string{"title"}, req.NearObject.Targets.TargetVectors)
+		assert.Equal(t, pb.Filters_OPERATOR_NOT_EQUAL, req.Filters.Operator)
+		assert.Equal(t, id, req.Filters.GetValueText())
+	})
+
+	t.Run("should search near an object through graphql", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{"data": {"Get": {"Article": []}}}`, &queries, nil)
+
+		id := "00000000-0000-0000-0000-000000000001"
+		_, err := weaviate.Search(connectionID, "Article", "", "nearObject", id, SearchOptions{
+			Filter:    &Filter{Operator: "Equal", Path: []string{"title"}, Value: "weaviate"},
+			Certainty: 0.8,
+		})
+		require.NoError(t, err)
+
+		_, err = weaviate.Search(connectionID, "Article", "", "nearObject", id, SearchOptions{
+			IncludeSource: true,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, queries, 2)
+		assert.Contains(t, queries[0], `nearObject:{id: "`+id+`" certainty: 0.8}`)
+		assert.Contains(t, queries[0], `where:{operator: And operands:[`)
+		assert.Contains(t, queries[0], `{operator: NotEqual path: ["id"] valueText: "`+id+`"}`)
+		assert.Contains(t, queries[1], `nearObject:{id: "`+id+`"}`)
+		assert.NotContains(t, queries[1], "where:")
+	})
+
+	t.Run("should not search near an invalid object id", func(t *testing.T) {
+		queries := []string{}
+		weaviate := newSearchTestWeaviate(t, search, `{}`, &queries, nil)
+
+		res, err := weaviate.Search(connectionID, "Article", "", "nearObject", "article-1", SearchOptions{})
+
+		assert.Nil(t, res)
+		assert.EqualError(t, err, `invalid object id "article-1": expected a UUID`)
+		assert.Empty(t, queries)
+	})
+
+	t.Run("should not search if targets are invalid", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			query    string
+			opts     SearchOptions
+			expected string
+		}{
+			{
+				name:     "unknown target",
+				query:    "[1, 0]",
+				opts:     SearchOptions{TargetVectors: []string{"image"}},
+				expected: `named vector "image" does not exist in collection Article`,
+			},
+			{
+				name:     "missing vector of target",
+				query:    `{"title": [1, 0]}`,
+				opts:     SearchOptions{TargetVectors: []string{"title", "body"}},
+				expected: `vector of target vector "body" is missing`,
+			},
+			{
+				name:     "unknown combination",
+				query:    "[1, 0]",
+				opts:     SearchOptions{TargetVectors: []string{"title"}, TargetCombination: "max"},
+				expected: `unsupported target combination "max"`,
+			},
+			{
+				name:  "missing weight",
+				query: "[1, 0]",
+				opts: SearchOptions{
+					TargetVectors:     []string{"title", "body"},
+					TargetCombination: "relativeScore",
+					TargetWeights:     map[string]float32{"title": 1},
+				},
+				expected: `weight of target vector "body" is required for relativeScore`,
+			},
+			{
+				name:  "weights without weighted combination",
+				query: "[1, 0]",
+				opts: SearchOptions{
+					TargetVectors: []string{"title"},
+					TargetWeights: map[string]float32{"title": 1},
+				},
+				expected: "target weights require the relativeScore or manualWeights combination",
+			},
+			{
+				name:  "invalid vector",
+				query: `{"title": "vector"}`,
+				expected: "invalid vector of target vector title: expected a JSON array of floats " +
+					"(e.g. [0.1, 0.2, ...]) or an array of arrays for multi vectors: " +
+					"json: cannot unmarshal string into Go value of type []float32",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				queries := []string{}
+				weaviate := newSearchTestWeaviate(t, search, `{}`, &queries, nil)
+
+				res, err := weaviate.Search(connectionID, "Article", "", "nearVector", tc.query, tc.opts)
+
+				assert.Nil(t, res)
+				assert.EqualError(t, err, tc.expected)
+				assert.Empty(t, queries)
+			})
+		}
+	})
+}
@@ -7,30 +7,81 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"weaviate-desktop/internal/http_util"
 	"weaviate-desktop/internal/models"
 
+	wails_runtime "github.com/wailsapp/wails/v2/pkg/runtime"
 	"github.com/weaviate/weaviate-go-client/v5/weaviate"
-	"github.com/weaviate/weaviate-go-client/v5/weaviate/auth"
-	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
 	weaviate_models "github.com/weaviate/weaviate/entities/models"
+	"golang.org/x/oauth2"
 )
 
+// this is for testing
+var eventsEmit = wails_runtime.EventsEmit
+
 type WClient struct {
-	w       *weaviate.Client
-	healthy bool
+	w *weaviate.Client
+	// uri, http and headers are used for the requests the go client doesn't support
+	uri     string
+	http    *http.Client
+	headers map[string]string
+	// tokens refreshes the OIDC access token, nil without OIDC
+	tokens oauth2.TokenSource
+	// ownHTTP is the client with the transport of the connection, e.g. for its TLS
+	// settings, proxy or tunnel. It's nil when the shared client is used.
+	ownHTTP *http.Client
+	// tunnel is the SSH tunnel of the connection, nil when it has none
+	tunnel *sshTunnel
+	// grpc is used for search, object fetching and batching when the connection has
+	// gRPC settings and its API is available, nil otherwise
+	grpc *grpcClient
+
+	healthMu sync.RWMutex
+	health   ClusterHealth
+
+	// tenantWalks are the tenants of the collections paged by GetTenantsPage by name
+	tenantWalksMu sync.Mutex
+	tenantWalks   map[string][]weaviate_models.Tenant
+}
+
+// close releases the resources of the client, e.g. its SSH tunnel
+func (c *WClient) close() {
+	if c.grpc != nil {
+		c.grpc.close()
+	}
+	if c.ownHTTP != nil {
+		c.ownHTTP.CloseIdleConnections()
+	}
+	if c.tunnel != nil {
+		c.tunnel.Close()
+	}
+}
+
+// tunnelErr returns why the SSH tunnel of the client is down, nil without a tunnel
+func (c *WClient) tunnelErr() error {
+	if c.tunnel == nil {
+		return nil
+	}
+	return c.tunnel.Err()
 }
 
 type Weaviate struct {
-	clients    map[int64]*WClient
+	clients    *clientRegistry
 	storage    Storage
 	httpClient *http.Client
+	// stopStatusUpdater stops the status updater and waits for it to return
+	stopStatusUpdater func()
+
+	runtimeMu  sync.RWMutex
+	runtimeCtx context.Context
 }
 
 type WeaviateObject struct {
@@ -40,10 +91,35 @@ type WeaviateObject struct {
 	CreationTimeUnix   int64  `json:"creationTimeUnix,omitempty"`
 	Tenant             string `json:"tenant,omitempty"`
 	Properties         any    `json:"properties,omitempty"`
+	// Vector and Vectors are only populated when explicitly requested
+	Vector  []float32      `json:"vector,omitempty"`
+	Vectors map[string]any `json:"vectors,omitempty"`
+	// Distance and Certainty are set by vector searches, Score and ExplainScore by
+	// keyword and hybrid searches
+	Distance     *float32 `json:"distance,omitempty"`
+	Certainty    *float32 `json:"certainty,omitempty"`
+	Score        *float32 `json:"score,omitempty"`
+	ExplainScore string   `json:"explainScore,omitempty"`
+	// KeywordScore and VectorScore are the contributions of the BM25 and the vector
+	// search to the score of hybrid searches
+	KeywordScore *HybridScore `json:"keywordScore,omitempty"`
+	VectorScore  *HybridScore `json:"vectorScore,omitempty"`
+	// RerankScore is the score of the reranker which ordered the results, next to the
+	// score or distance of the search
+	RerankScore *float64 `json:"rerankScore,omitempty"`
+	// TargetDistances are the distances to the query vectors per target vector of
+	// nearVector searches
+	TargetDistances map[string]float32 `json:"targetDistances,omitempty"`
+	// Generated is the result of the single prompt of a generative search, the error
+	// is set instead when generating failed for the object
+	Generated       string `json:"generated,omitempty"`
+	GenerativeError string `json:"generativeError,omitempty"`
 }
 
 type Storage interface {
 	GetConnection(id int64, decrypt bool) (*models.Connection, error)
+	SaveRequest(r models.RequestHistory) (int64, error)
+	UpdateRefreshToken(id int64, refreshToken string) error
 }
 
 type Configuration struct {
@@ -51,90 +127,182 @@ type Configuration struct {
 }
 
 func New(s Storage, c Configuration) *Weaviate {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
 	w := &Weaviate{
 		storage:    s,
-		clients:    map[int64]*WClient{},
+		clients:    newClientRegistry(),
 		httpClient: http_util.GetClient(30 * time.Second),
+		stopStatusUpdater: func() {
+			cancel()
+			<-done
+		},
 	}
 
-	go w.updateClusterStatus(c.StatusUpdateInterval)
+	go func() {
+		defer close(done)
+		w.updateClusterStatus(ctx, c.StatusUpdateInterval)
+	}()
 
 	return w
 }
 
-func (w *Weaviate) updateClusterStatus(d time.Duration) {
-	ticker := time.NewTicker(d)
-	var err error
-
-	for range ticker.C {
-		if len(w.clients) == 0 {
-			continue
-		}
-
-		slog.Debug("running status updater", slog.Int("clientsConnected", len(w.clients)))
-
-		for id, cl := range w.clients {
-			cl.healthy, err = cl.w.Misc().LiveChecker().Do(context.Background())
-			if err != nil {
-				slog.Error(
-					"failed querying status",
-					slog.Int64("connectionID", id),
-					slog.Any("error", err),
-				)
-			}
-		}
-	}
-}
-
 type TestConnectionInput struct {
 	URI    string
 	ApiKey *string
+	// AuthType is one of the models.Auth* types, connections with an api key and
+	// no auth type authenticate with the api key
+	AuthType     string  `json:"AuthType,omitempty"`
+	Username     *string `json:"Username,omitempty"`
+	Password     *string `json:"Password,omitempty"`
+	ClientSecret *string `json:"ClientSecret,omitempty"`
+	Scopes       *string `json:"Scopes,omitempty"`
+	AccessToken  *string `json:"AccessToken,omitempty"`
+	RefreshToken *string `json:"RefreshToken,omitempty"`
+	// Headers are sent with every request
+	Headers []models.ConnectionHeader `json:"Headers,omitempty"`
+	// CACertFile, ClientCertFile and ClientKeyFile are paths to PEM encoded files
+	CACertFile         *string `json:"CACertFile,omitempty"`
+	ClientCertFile     *string `json:"ClientCertFile,omitempty"`
+	ClientKeyFile      *string `json:"ClientKeyFile,omitempty"`
+	InsecureSkipVerify bool    `json:"InsecureSkipVerify,omitempty"`
+	// ProxyURL is a HTTP CONNECT or SOCKS5 proxy, SSHHost an SSH server to tunnel through
+	ProxyURL   *string `json:"ProxyURL,omitempty"`
+	SSHHost    *string `json:"SSHHost,omitempty"`
+	SSHUser    *string `json:"SSHUser,omitempty"`
+	SSHKeyFile *string `json:"SSHKeyFile,omitempty"`
+	// GRPCHost and GRPCPort enable the gRPC API, the host defaults to the host of the URI
+	GRPCHost *string `json:"GRPCHost,omitempty"`
+	GRPCPort *int64  `json:"GRPCPort,omitempty"`
 }
 
-func (w Weaviate) TestConnection(i TestConnectionInput) error {
+func (w *Weaviate) TestConnection(i TestConnectionInput) error {
 	c, err := w.getClientFromConnection(&models.Connection{
-		URI:    i.URI,
-		ApiKey: i.ApiKey,
+		URI:          i.URI,
+		ApiKey:       i.ApiKey,
+		AuthType:     i.AuthType,
+		Username:     i.Username,
+		Password:     i.Password,
+		ClientSecret: i.ClientSecret,
+		Scopes:       i.Scopes,
+		AccessToken:  i.AccessToken,
+		RefreshToken: i.RefreshToken,
+		Headers:      i.Headers,
+
+		CACertFile:         i.CACertFile,
+		ClientCertFile:     i.ClientCertFile,
+		ClientKeyFile:      i.ClientKeyFile,
+		InsecureSkipVerify: i.InsecureSkipVerify,
+
+		ProxyURL:   i.ProxyURL,
+		SSHHost:    i.SSHHost,
+		SSHUser:    i.SSHUser,
+		SSHKeyFile: i.SSHKeyFile,
+
+		GRPCHost: i.GRPCHost,
+		GRPCPort: i.GRPCPort,
 	})
 	if err != nil {
 		return err
 	}
+	defer c.close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	_, err = c.w.Misc().MetaGetter().Do(ctx)
+	meta, err := c.w.Misc().MetaGetter().Do(ctx)
+	if err != nil {
+		return err
+	}
 
-	return err
+	// a configured gRPC API has to be reachable when testing the connection
+	return c.checkGRPC(meta.Version)
 }
 
+// getClientFromConnection creates the client of the connection, opening its SSH
+// tunnel if it has one. The tunnel is owned by the client and closed with it.
 func (w *Weaviate) getClientFromConnection(c *models.Connection) (*WClient, error) {
 	u, err := url.Parse(c.URI)
 	if err != nil {
 		return nil, fmt.Errorf("failed parsing connection URI: %w", err)
 	}
 
-	cfg := weaviate.Config{
-		Host:   u.Host,
-		Scheme: u.Scheme,
+	tunnel, err := openTunnel(c)
+	if err != nil {
+		return nil, err
 	}
-	if c.ApiKey != nil {
-		cfg.AuthConfig = auth.ApiKey{Value: *c.ApiKey}
+
+	client, err := w.newClient(c, u, tunnel)
+	if err != nil {
+		if tunnel != nil {
+			tunnel.Close()
+		}
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (w *Weaviate) newClient(
+	c *models.Connection,
+	u *url.URL,
+	tunnel *sshTunnel,
+) (*WClient, error) {
+	httpClient, err := w.connectionHTTPClient(c, tunnel)
+	if err != nil {
+		return nil, err
+	}
+
+	// connections which aren't stored yet, e.g. when testing them, have no id
+	var saveRefreshToken func(string)
+	if c.ID != 0 {
+		saveRefreshToken = func(refreshToken string) { w.saveRefreshToken(c.ID, refreshToken) }
+	}
+
+	client, err := authenticate(c, u, httpClient, saveRefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	client.tunnel = tunnel
+	if httpClient != w.httpClient {
+		client.ownHTTP = httpClient
 	}
 
-	client, err := weaviate.NewClient(cfg)
+	// custom headers, e.g. the api keys of the modules, don't override the authentication
+	for _, h := range c.Headers {
+		if strings.TrimSpace(h.Name) == "" {
+			return nil, errors.New("header name is required")
+		}
+		name := http.CanonicalHeaderKey(strings.TrimSpace(h.Name))
+		if _, exists := client.headers[name]; exists {
+			continue
+		}
+		client.headers[name] = h.Value
+	}
+
+	// the go client shares the http client and headers of the requests sent directly
+	cfg := weaviate.Config{
+		Host:             u.Host,
+		Scheme:           u.Scheme,
+		Headers:          maps.Clone(client.headers),
+		ConnectionClient: client.http,
+	}
+
+	client.w, err = weaviate.NewClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed creating client: %w", err)
 	}
 
-	return &WClient{
-		healthy: true,
-		w:       client,
-	}, nil
+	client.grpc, err = newGRPCClient(c, u, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
 }
 
 func (w *Weaviate) Connect(id int64) error {
-	if _, exists := w.clients[id]; exists {
+	if _, exists := w.clients.get(id); exists {
 		return nil
 	}
 
@@ -149,20 +317,42 @@ func (w *Weaviate) Connect(id int64) error {
 	}
 
 	// verify connection is healthy
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	_, err = client.w.Misc().MetaGetter().Do(ctx)
+	health, err := client.probe(id, 5*time.Second)
 	if err != nil {
+		client.close()
 		return fmt.Errorf("failed connecting to %s: %w", connection.URI, err)
 	}
+	client.setHealth(health)
 
-	w.clients[id] = client
+	// the REST and GraphQL APIs are used when the gRPC API isn't available
+	if err := client.checkGRPC(health.Version); err != nil {
+		slog.Warn(
+			"grpc unavailable, falling back to rest and graphql",
+			slog.Int64("connectionID", id),
+			slog.Any("error", err),
+		)
+		client.grpc.close()
+		client.grpc = nil
+	}
+
+	// the connection was connected concurrently, its client is kept
+	if !w.clients.add(id, client) {
+		client.close()
+		return nil
+	}
+
+	w.emit(ClusterHealthEvent, health)
 	return nil
 }
 
-func (w *Weaviate) GetTotalObjects(connectionID int64, collection, tenant string) (int64, error) {
-	c, exists := w.clients[connectionID]
+// GetTotalObjects counts the objects of the collection (or tenant) matching the
+// filter, every object is counted when filter is nil
+func (w *Weaviate) GetTotalObjects(
+	connectionID int64,
+	collection, tenant string,
+	filter *Filter,
+) (int64, error) {
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return -1, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -170,42 +360,23 @@ func (w *Weaviate) GetTotalObjects(connectionID int64, collection, tenant string
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	meta := graphql.Field{
-		Name: "meta", Fields: []graphql.Field{
-			{Name: "count"},
-		},
-	}
-
-	get := c.w.GraphQL().Aggregate().
-		WithClassName(collection).
-		WithFields(meta)
-
-	if tenant != "" {
-		get = get.WithTenant(tenant)
-	}
-
-	result, err := get.Do(ctx)
+	res, err := c.aggregate(ctx, AggregationInput{
+		Collection: collection,
+		Tenant:     tenant,
+		Filter:     filter,
+	})
 	if err != nil {
-		return -1, fmt.Errorf("failed aggregating total objects for %s: %w", collection, err)
+		return -1, err
 	}
-	if len(result.Errors) != 0 {
-		err := strings.Builder{}
-
-		for _, e := range result.Errors {
-			err.WriteString(e.Message)
-			err.WriteString(",")
-		}
-
-		return -1, errors.New(err.String())
+	if len(res.Groups) == 0 {
+		return 0, nil
 	}
 
-	return int64(
-		result.Data["Aggregate"].(map[string]any)[collection].([]any)[0].(map[string]any)["meta"].(map[string]any)["count"].(float64),
-	), nil
+	return res.Groups[0].Count, nil
 }
 
 func (w *Weaviate) DeleteObject(connectionID int64, collection, id, tenant string) error {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -225,6 +396,8 @@ type PaginatedObjectResponse struct {
 	Objects       []WeaviateObject
 	ExecutionTime string
 	TotalResults  int
+	// GeneratedGrouped is the result of the grouped task of a generative search
+	GeneratedGrouped string
 }
 
 func (w *Weaviate) GetObjectsPaginated(
@@ -232,24 +405,69 @@ func (w *Weaviate) GetObjectsPaginated(
 	pageSize int,
 	collection, cursor, tenant string,
 ) (*PaginatedObjectResponse, error) {
-	connection, err := w.storage.GetConnection(connectionID, true)
-	if err != nil {
-		return nil, fmt.Errorf("failed retrieving connection %d: %w", connectionID, err)
+	return w.GetObjectsPage(connectionID, ObjectsPageInput{
+		Collection: collection,
+		Tenant:     tenant,
+		Cursor:     cursor,
+		PageSize:   pageSize,
+	})
+}
+
+type ObjectsPageInput struct {
+	Collection string `json:"collection"`
+	Tenant     string `json:"tenant,omitempty"`
+	// Cursor is the id of the last object of the previous page
+	Cursor        string `json:"cursor"`
+	PageSize      int    `json:"pageSize"`
+	IncludeVector bool   `json:"includeVector,omitempty"`
+}
+
+// GetObjectsPage walks a collection with the "after" cursor returning one page at a time
+func (w *Weaviate) GetObjectsPage(
+	connectionID int64,
+	input ObjectsPageInput,
+) (*PaginatedObjectResponse, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		// connections which aren't connected are read with a client of the stored connection
+		connection, err := w.storage.GetConnection(connectionID, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed retrieving connection %d: %w", connectionID, err)
+		}
+
+		c, err = w.getClientFromConnection(connection)
+		if err != nil {
+			return nil, err
+		}
+		defer c.close()
+
+		// the gRPC API is only used once it's verified when connecting
+		if c.grpc != nil {
+			c.grpc.close()
+			c.grpc = nil
+		}
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	u, err := url.Parse(fmt.Sprintf("%s/v1/objects", connection.URI))
+	if c.grpc != nil {
+		return c.getObjectsPageGRPC(ctx, input)
+	}
+
+	u, err := url.Parse(fmt.Sprintf("%s/v1/objects", c.uri))
 	if err != nil {
-		return nil, fmt.Errorf("failed parsing connection URI %s: %w", connection.URI, err)
+		return nil, fmt.Errorf("failed parsing connection URI %s: %w", c.uri, err)
 	}
 	q := u.Query()
-	q.Set("class", collection)
-	q.Set("limit", strconv.Itoa(pageSize))
-	q.Set("after", cursor)
-	if tenant != "" {
-		q.Set("tenant", tenant)
+	q.Set("class", input.Collection)
+	q.Set("limit", strconv.Itoa(input.PageSize))
+	q.Set("after", input.Cursor)
+	if input.Tenant != "" {
+		q.Set("tenant", input.Tenant)
+	}
+	if input.IncludeVector {
+		q.Set("include", "vector")
 	}
 	u.RawQuery = q.Encode()
 
@@ -257,11 +475,11 @@ func (w *Weaviate) GetObjectsPaginated(
 	if err != nil {
 		return nil, fmt.Errorf("failed creating paginated request: %w", err)
 	}
-	if connection.ApiKey != nil {
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", *connection.ApiKey))
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
 	}
 
-	resp, err := w.httpClient.Do(req)
+	resp, err := c.http.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed on paginated objects request: %w", err)
 	}
@@ -305,7 +523,7 @@ func (w *Weaviate) GetTenants(
 	connectionID int64,
 	collection string,
 ) ([]weaviate_models.Tenant, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -317,7 +535,7 @@ func (w *Weaviate) GetTenants(
 }
 
 func (w *Weaviate) NodesStatus(connectionID int64) (*weaviate_models.NodesStatusResponse, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -334,7 +552,7 @@ func (w *Weaviate) NodesStatus(connectionID int64) (*weaviate_models.NodesStatus
 }
 
 func (w *Weaviate) GetModules(connectionID int64) (any, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -351,16 +569,31 @@ func (w *Weaviate) GetModules(connectionID int64) (any, error) {
 }
 
 func (w *Weaviate) ClusterStatus(connectionID int64) (bool, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return false, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
+	if err := c.tunnelErr(); err != nil {
+		return false, err
+	}
 
-	return c.healthy, nil
+	return c.currentHealth().Healthy, nil
+}
+
+// ClusterHealth returns the health of the connection as of its last check, changes
+// are emitted as ClusterHealthEvent
+func (w *Weaviate) ClusterHealth(connectionID int64) (*ClusterHealth, error) {
+	c, exists := w.clients.get(connectionID)
+	if !exists {
+		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
+	}
+
+	health := c.currentHealth()
+	return &health, nil
 }
 
 func (w *Weaviate) GetCollections(connectionID int64) ([]*weaviate_models.Class, error) {
-	c, exists := w.clients[connectionID]
+	c, exists := w.clients.get(connectionID)
 	if !exists {
 		return nil, fmt.Errorf("connection doesn't exist %d", connectionID)
 	}
@@ -377,11 +610,21 @@ func (w *Weaviate) GetCollections(connectionID int64) ([]*weaviate_models.Class,
 }
 
 func (w *Weaviate) Disconnect(id int64) error {
-	_, exists := w.clients[id]
+	c, exists := w.clients.remove(id)
 	if !exists {
 		return nil
 	}
 
-	delete(w.clients, id)
+	c.close()
 	return nil
 }
+
+// Shutdown stops the status updater and disconnects every connection, closing their
+// SSH tunnels and gRPC connections. It's called when the app shuts down.
+func (w *Weaviate) Shutdown() {
+	w.stopStatusUpdater()
+
+	for id := range w.clients.all() {
+		_ = w.Disconnect(id)
+	}
+}
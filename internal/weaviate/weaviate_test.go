@@ -12,6 +12,7 @@ import (
 	"weaviate-desktop/internal/utils"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWeaviate(t *testing.T) {
@@ -45,6 +46,46 @@ func TestWeaviate(t *testing.T) {
 			assert.EqualError(t, err, "failed retrieving connection 1: mock-error")
 		})
 
+		t.Run("should read the objects of a stored connection which isn't connected", func(t *testing.T) {
+			mockServer := http_util.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/v1/objects" && r.Method == http.MethodGet {
+						assert.Equal(t, "Bearer mock-api-key", r.Header.Get("Authorization"))
+						w.Write([]byte(`{"totalResults": 1, "objects": [{"class": "TestClass0"}]}`))
+						return
+					}
+
+					if r.URL.Path == "/v1/meta" {
+						w.Write([]byte(`{"version": "1.30.0"}`))
+						return
+					}
+
+					t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+					t.Fail()
+				}),
+			)
+			t.Cleanup(mockServer.Close)
+
+			mockStorage := NewMockStorage(t)
+
+			mockStorage.EXPECT().GetConnection(connectionID, true).Return(&models.Connection{
+				URI:    mockServer.URL,
+				ApiKey: utils.Pointer("mock-api-key"),
+			}, nil)
+
+			weaviate := New(mockStorage, Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+
+			objects, err := weaviate.GetObjectsPaginated(connectionID, limit, collection, cursor, "")
+
+			require.NoError(t, err)
+			assert.Len(t, objects.Objects, 1)
+			assert.Equal(t, "TestClass0", objects.Objects[0].Class)
+			_, connected := weaviate.clients.get(connectionID)
+			assert.False(t, connected)
+		})
+
 		t.Run(
 			"should set class/limit/after/tenant on the query and returns paginated objects",
 			func(t *testing.T) {
@@ -77,21 +118,23 @@ func TestWeaviate(t *testing.T) {
 							return
 						}
 
+						if r.URL.Path == "/v1/meta" {
+							w.Write([]byte(`{"version": "1.30.0"}`))
+							return
+						}
+
 						t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
 						t.Fail()
 					}),
 				)
 				t.Cleanup(mockServer.Close)
 
-				mockStorage := NewMockStorage(t)
-
-				mockStorage.EXPECT().GetConnection(connectionID, true).Return(&models.Connection{
-					URI: mockServer.URL + "/v1/objects",
-				}, nil)
-
-				weaviate := New(mockStorage, Configuration{
+				weaviate := New(NewMockStorage(t), Configuration{
 					StatusUpdateInterval: time.Hour,
 				})
+				client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+				require.NoError(t, err)
+				weaviate.clients.add(connectionID, client)
 
 				objects, err := weaviate.GetObjectsPaginated(
 					connectionID,
@@ -107,7 +150,6 @@ func TestWeaviate(t *testing.T) {
 				assert.Equal(t, "TestClass0", objects.Objects[0].Class)
 				assert.Equal(t, "TestClass1", objects.Objects[1].Class)
 				assert.Equal(t, "TestClass2", objects.Objects[2].Class)
-				mockStorage.AssertExpectations(t)
 			},
 		)
 
@@ -141,22 +183,26 @@ func TestWeaviate(t *testing.T) {
 						return
 					}
 
+					if r.URL.Path == "/v1/meta" {
+						w.Write([]byte(`{"version": "1.30.0"}`))
+						return
+					}
+
 					t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
 					t.Fail()
 				}),
 			)
 			t.Cleanup(mockServer.Close)
 
-			mockStorage := NewMockStorage(t)
-
-			mockStorage.EXPECT().GetConnection(connectionID, true).Return(&models.Connection{
-				URI:    mockServer.URL + "/v1/objects",
-				ApiKey: utils.Pointer("mock-api-key"),
-			}, nil)
-
-			weaviate := New(mockStorage, Configuration{
+			weaviate := New(NewMockStorage(t), Configuration{
 				StatusUpdateInterval: time.Hour,
 			})
+			client, err := weaviate.getClientFromConnection(&models.Connection{
+				URI:    mockServer.URL,
+				ApiKey: utils.Pointer("mock-api-key"),
+			})
+			require.NoError(t, err)
+			weaviate.clients.add(connectionID, client)
 
 			objects, err := weaviate.GetObjectsPaginated(
 				connectionID,
@@ -172,7 +218,35 @@ func TestWeaviate(t *testing.T) {
 			assert.Equal(t, "TestClass0", objects.Objects[0].Class)
 			assert.Equal(t, "TestClass1", objects.Objects[1].Class)
 			assert.Equal(t, "TestClass2", objects.Objects[2].Class)
-			mockStorage.AssertExpectations(t)
+		})
+
+		t.Run("should pass custom headers", func(t *testing.T) {
+			mockServer := http_util.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "mock-cohere-key", r.Header.Get("X-Cohere-Api-Key"))
+					if r.URL.Path == "/v1/meta" {
+						w.Write([]byte(`{"version": "1.30.0"}`))
+						return
+					}
+					w.Write([]byte(`{"totalResults": 0, "objects": []}`))
+				}),
+			)
+			t.Cleanup(mockServer.Close)
+
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+			client, err := weaviate.getClientFromConnection(&models.Connection{
+				URI:     mockServer.URL,
+				Headers: []models.ConnectionHeader{{Name: "X-Cohere-Api-Key", Value: "mock-cohere-key"}},
+			})
+			require.NoError(t, err)
+			weaviate.clients.add(connectionID, client)
+
+			objects, err := weaviate.GetObjectsPaginated(connectionID, limit, collection, cursor, "")
+
+			assert.NoError(t, err)
+			assert.Empty(t, objects.Objects)
 		})
 
 		t.Run("should return error if bad code status", func(t *testing.T) {
@@ -195,21 +269,23 @@ func TestWeaviate(t *testing.T) {
 						return
 					}
 
+					if r.URL.Path == "/v1/meta" {
+						w.Write([]byte(`{"version": "1.30.0"}`))
+						return
+					}
+
 					t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
 					t.Fail()
 				}),
 			)
 			t.Cleanup(mockServer.Close)
 
-			mockStorage := NewMockStorage(t)
-
-			mockStorage.EXPECT().GetConnection(connectionID, true).Return(&models.Connection{
-				URI: mockServer.URL + "/v1/objects",
-			}, nil)
-
-			weaviate := New(mockStorage, Configuration{
+			weaviate := New(NewMockStorage(t), Configuration{
 				StatusUpdateInterval: time.Hour,
 			})
+			client, err := weaviate.getClientFromConnection(&models.Connection{URI: mockServer.URL})
+			require.NoError(t, err)
+			weaviate.clients.add(connectionID, client)
 
 			objects, err := weaviate.GetObjectsPaginated(
 				connectionID,
@@ -225,7 +301,6 @@ func TestWeaviate(t *testing.T) {
 				err,
 				"weaviate return non successful http status code 400 Bad Request for after=mock-cursor&class=TestCollection&limit=10&tenant=mock-tenant: Bad request",
 			)
-			mockStorage.AssertExpectations(t)
 		})
 	})
 
@@ -261,6 +336,52 @@ func TestWeaviate(t *testing.T) {
 			assert.NoError(t, err)
 			mockStorage.AssertExpectations(t)
 		})
+
+		t.Run("should send custom headers without overriding the api key", func(t *testing.T) {
+			mockServer := http_util.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/v1/meta" && r.Method == http.MethodGet {
+						assert.Equal(t, "Bearer mock-api-key", r.Header.Get("Authorization"))
+						assert.Equal(t, "mock-openai-key", r.Header.Get("X-Openai-Api-Key"))
+						w.Write([]byte(`{}`))
+
+						return
+					}
+
+					t.Logf("unexpected request to %s with method %s", r.URL.Path, r.Method)
+					t.Fail()
+				}),
+			)
+			t.Cleanup(mockServer.Close)
+
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+
+			err := weaviate.TestConnection(TestConnectionInput{
+				URI:    mockServer.URL,
+				ApiKey: utils.Pointer("mock-api-key"),
+				Headers: []models.ConnectionHeader{
+					{Name: "X-OpenAI-Api-Key", Value: "mock-openai-key"},
+					{Name: "authorization", Value: "Bearer other"},
+				},
+			})
+
+			assert.NoError(t, err)
+		})
+
+		t.Run("should return error if header name is empty", func(t *testing.T) {
+			weaviate := New(NewMockStorage(t), Configuration{
+				StatusUpdateInterval: time.Hour,
+			})
+
+			err := weaviate.TestConnection(TestConnectionInput{
+				URI:     "http://localhost:8080",
+				Headers: []models.ConnectionHeader{{Name: " ", Value: "value"}},
+			})
+
+			assert.EqualError(t, err, "header name is required")
+		})
 	})
 
 	t.Run("Connect & Disconnect", func(t *testing.T) {
@@ -270,21 +391,20 @@ func TestWeaviate(t *testing.T) {
 				StatusUpdateInterval: time.Hour,
 			})
 
-			// Add a client manually to the map
-			weaviate.clients[connectionID] = &WClient{
-				healthy: true,
-				w:       nil,
-			}
+			// Add a client manually to the registry
+			weaviate.clients.add(connectionID, &WClient{})
 
 			// Verify client exists
-			assert.Contains(t, weaviate.clients, connectionID)
+			_, exists := weaviate.clients.get(connectionID)
+			assert.True(t, exists)
 
 			// Disconnect
 			err := weaviate.Disconnect(connectionID)
 
 			// Verify client no longer exists and no error was returned
 			assert.NoError(t, err)
-			assert.NotContains(t, weaviate.clients, connectionID)
+			_, exists = weaviate.clients.get(connectionID)
+			assert.False(t, exists)
 		})
 
 		t.Run("disconnect should return nil if client doesn't exist", func(t *testing.T) {
@@ -294,7 +414,8 @@ func TestWeaviate(t *testing.T) {
 			})
 
 			// Verify client doesn't exist
-			assert.NotContains(t, weaviate.clients, connectionID)
+			_, exists := weaviate.clients.get(connectionID)
+			assert.False(t, exists)
 
 			// Disconnect
 			err := weaviate.Disconnect(connectionID)
@@ -309,11 +430,8 @@ func TestWeaviate(t *testing.T) {
 				StatusUpdateInterval: time.Hour,
 			})
 
-			// Manually add a client to the map
-			weaviate.clients[connectionID] = &WClient{
-				healthy: true,
-				w:       nil, // Not needed for this test
-			}
+			// Manually add a client to the registry
+			weaviate.clients.add(connectionID, &WClient{})
 
 			assert.NoError(t, weaviate.Connect(connectionID))
 			mockStorage.AssertExpectations(t)
@@ -363,8 +481,9 @@ func TestWeaviate(t *testing.T) {
 			})
 
 			assert.NoError(t, weaviate.Connect(connectionID))
-			assert.Contains(t, weaviate.clients, connectionID)
-			assert.True(t, weaviate.clients[connectionID].healthy)
+			client, exists := weaviate.clients.get(connectionID)
+			assert.True(t, exists)
+			assert.True(t, client.currentHealth().Healthy)
 			mockStorage.AssertExpectations(t)
 		})
 
@@ -399,7 +518,8 @@ func TestWeaviate(t *testing.T) {
 
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), "failed connecting to")
-			assert.NotContains(t, weaviate.clients, connectionID)
+			_, exists := weaviate.clients.get(connectionID)
+			assert.False(t, exists)
 			mockStorage.AssertExpectations(t)
 		})
 	})
@@ -10,6 +10,8 @@ import (
 
 	"weaviate-desktop/internal/config"
 	"weaviate-desktop/internal/encrypter"
+	"weaviate-desktop/internal/exporter"
+	"weaviate-desktop/internal/importer"
 	"weaviate-desktop/internal/storage/sql"
 	"weaviate-desktop/internal/updater"
 	"weaviate-desktop/internal/weaviate"
@@ -41,6 +43,9 @@ func main() {
 		StatusUpdateInterval: 30 * time.Second,
 	})
 
+	objectImporter := importer.New(w)
+	objectExporter := exporter.New(w)
+
 	appUpdater := updater.New(
 		cfg.Version,
 		cfg.FileName,
@@ -72,8 +77,12 @@ func main() {
 
 			// Pass runtime context to those in need
 			appUpdater.SetRuntimeContext(ctx)
+			w.SetRuntimeContext(ctx)
+			objectImporter.SetRuntimeContext(ctx)
+			objectExporter.SetRuntimeContext(ctx)
 		},
 		OnShutdown: func(_ context.Context) {
+			w.Shutdown()
 			_ = dbCloser()
 		},
 		DragAndDrop: &options.DragAndDrop{
@@ -90,6 +99,8 @@ func main() {
 			w,
 			sqlStorage,
 			appUpdater,
+			objectImporter,
+			objectExporter,
 		},
 	}); err != nil {
 		println("Error:", err.Error())